@@ -0,0 +1,3 @@
+module github.com/13770129/containers/lists
+
+go 1.24.3
@@ -0,0 +1,107 @@
+package lists
+
+import "iter"
+
+// node is an element of a List. The list is a ring through a sentinel root
+// node, so insertion and removal never need nil checks.
+type node[T any] struct {
+	prev, next *node[T]
+	value      T
+}
+
+// List is a doubly-linked list of values of type T. Unlike container/list it
+// stores values without boxing them in interfaces.
+// The zero value is an empty list ready to use.
+type List[T any] struct {
+	root node[T] // Sentinel; root.next is the front and root.prev the back
+	len  int
+}
+
+// NewList creates an empty List.
+func NewList[T any]() *List[T] {
+	return new(List[T])
+}
+
+// lazyInit links the sentinel to itself the first time a zero List is used.
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+// insertAfter links a new node holding value after at.
+func (l *List[T]) insertAfter(value T, at *node[T]) {
+	n := &node[T]{prev: at, next: at.next, value: value}
+	at.next.prev = n
+	at.next = n
+	l.len++
+}
+
+// remove unlinks n and returns its value.
+func (l *List[T]) remove(n *node[T]) T {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.prev, n.next = nil, nil
+	l.len--
+	return n.value
+}
+
+// Len returns the number of values in the list.
+// Time complexity: O(1)
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// PushFront inserts value at the front of the list.
+// Time complexity: O(1)
+func (l *List[T]) PushFront(value T) {
+	l.lazyInit()
+	l.insertAfter(value, &l.root)
+}
+
+// PushBack inserts value at the back of the list.
+// Time complexity: O(1)
+func (l *List[T]) PushBack(value T) {
+	l.lazyInit()
+	l.insertAfter(value, l.root.prev)
+}
+
+// PopFront removes and returns the value at the front of the list, or the
+// zero value and false if the list is empty.
+// Time complexity: O(1)
+func (l *List[T]) PopFront() (value T, ok bool) {
+	if l.len == 0 {
+		return value, false
+	}
+	return l.remove(l.root.next), true
+}
+
+// PopBack removes and returns the value at the back of the list, or the
+// zero value and false if the list is empty.
+// Time complexity: O(1)
+func (l *List[T]) PopBack() (value T, ok bool) {
+	if l.len == 0 {
+		return value, false
+	}
+	return l.remove(l.root.prev), true
+}
+
+// Range calls f for each value from front to back until f returns false.
+// The list must not be modified during iteration.
+// Time complexity: O(n)
+func (l *List[T]) Range(f func(value T) bool) {
+	if l.len == 0 {
+		return
+	}
+	for n := l.root.next; n != &l.root; n = n.next {
+		if !f(n.value) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the values from front to back.
+func (l *List[T]) All() iter.Seq[T] {
+	return l.Range
+}
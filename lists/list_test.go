@@ -0,0 +1,126 @@
+package lists_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/lists"
+)
+
+func values[T any](l *lists.List[T]) []T {
+	return slices.Collect(l.All())
+}
+
+func TestListPushPop(t *testing.T) {
+	t.Run("BothEnds", func(t *testing.T) {
+		l := lists.NewList[int]()
+		l.PushBack(2)
+		l.PushBack(3)
+		l.PushFront(1)
+		l.PushFront(0)
+
+		if got := values(l); !slices.Equal(got, []int{0, 1, 2, 3}) {
+			t.Errorf("Expected [0 1 2 3], got %v", got)
+		}
+		if l.Len() != 4 {
+			t.Errorf("Expected length 4, got %d", l.Len())
+		}
+
+		if value, ok := l.PopFront(); !ok || value != 0 {
+			t.Errorf("Expected PopFront to return 0, got %d (ok=%v)", value, ok)
+		}
+		if value, ok := l.PopBack(); !ok || value != 3 {
+			t.Errorf("Expected PopBack to return 3, got %d (ok=%v)", value, ok)
+		}
+		if got := values(l); !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("Expected [1 2], got %v", got)
+		}
+	})
+
+	t.Run("DrainFromFront", func(t *testing.T) {
+		l := lists.NewList[string]()
+		for _, s := range []string{"a", "b", "c"} {
+			l.PushBack(s)
+		}
+		var popped []string
+		for {
+			value, ok := l.PopFront()
+			if !ok {
+				break
+			}
+			popped = append(popped, value)
+		}
+		if !slices.Equal(popped, []string{"a", "b", "c"}) {
+			t.Errorf("Expected FIFO order [a b c], got %v", popped)
+		}
+		if l.Len() != 0 {
+			t.Errorf("Expected empty list, got length %d", l.Len())
+		}
+	})
+
+	t.Run("DrainFromBack", func(t *testing.T) {
+		l := lists.NewList[string]()
+		for _, s := range []string{"a", "b", "c"} {
+			l.PushBack(s)
+		}
+		var popped []string
+		for l.Len() > 0 {
+			value, _ := l.PopBack()
+			popped = append(popped, value)
+		}
+		if !slices.Equal(popped, []string{"c", "b", "a"}) {
+			t.Errorf("Expected LIFO order [c b a], got %v", popped)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var l lists.List[int]
+		if _, ok := l.PopFront(); ok {
+			t.Error("Expected PopFront on an empty list to fail")
+		}
+		if _, ok := l.PopBack(); ok {
+			t.Error("Expected PopBack on an empty list to fail")
+		}
+		l.Range(func(int) bool {
+			t.Error("Expected Range on an empty list not to call f")
+			return true
+		})
+
+		// The zero value is usable.
+		l.PushBack(1)
+		if got := values(&l); !slices.Equal(got, []int{1}) {
+			t.Errorf("Expected [1], got %v", got)
+		}
+	})
+}
+
+func TestListRange(t *testing.T) {
+	l := lists.NewList[int]()
+	for i := 0; i < 5; i++ {
+		l.PushBack(i)
+	}
+
+	t.Run("EarlyTermination", func(t *testing.T) {
+		var visited []int
+		l.Range(func(value int) bool {
+			visited = append(visited, value)
+			return value < 2
+		})
+		if !slices.Equal(visited, []int{0, 1, 2}) {
+			t.Errorf("Expected Range to stop after [0 1 2], visited %v", visited)
+		}
+	})
+
+	t.Run("IteratorBreak", func(t *testing.T) {
+		var visited []int
+		for value := range l.All() {
+			if value == 3 {
+				break
+			}
+			visited = append(visited, value)
+		}
+		if !slices.Equal(visited, []int{0, 1, 2}) {
+			t.Errorf("Expected [0 1 2], got %v", visited)
+		}
+	})
+}
@@ -1,5 +1,12 @@
 package maps
 
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sync/atomic"
+)
+
 type MapOps[Key, Value any] interface {
 	Delete(key Key)
 	Load(key Key) (value Value, ok bool)
@@ -20,16 +27,73 @@ type AbstractMap[Key, Value any] interface {
 	Swap(key Key, value Value) (previous Value, loaded bool)
 }
 
+// grower is implemented by maps that can reserve room for more entries.
+type grower interface {
+	Grow(n int)
+}
+
+// readSafe is implemented by maps that report whether their read-only
+// methods never modify the map, so ConcurrentMap may run them concurrently
+// under a shared read lock. Maps that reorder, expire or insert entries on
+// reads must not implement it, or must return false if they inherit it.
+type readSafe interface {
+	readSafe() bool
+}
+
+// atomicUpdater is implemented by maps with an AtomicUpdate method; the
+// concurrency-safe maps override it to run under their lock.
+type atomicUpdater[K, V any] interface {
+	AtomicUpdate(key K, f func(old V, loaded bool) (new V, store bool)) (V, bool)
+}
+
+// lockedUpdater is implemented by maps that report whether their
+// AtomicUpdate holds a lock across the load, f and store. The plain
+// DefaultAbstractMap.AtomicUpdate that every map inherits does not, so
+// callers needing atomicity check this rather than atomicUpdater.
+type lockedUpdater interface {
+	updatesUnderLock() bool
+}
+
+// FromGoMaps stores the entries of gms into m and returns m. When a key
+// occurs more than once, the value from the last map containing it wins.
+// If m has a Grow method it is first grown by the total size of the inputs.
 func FromGoMaps[Key comparable, Value any, Map AbstractMap[Key, Value]](m Map, gms ...map[Key]Value) Map {
+	return FromGoMapsFunc(m, func(_ Key, _, incoming Value) Value { return incoming }, gms...)
+}
+
+// FromGoMapsFunc is like FromGoMaps but lets resolve decide the value for a
+// key that is already present, whether from an earlier map in gms or from m
+// itself. The maps are applied in argument order, so keeping existing gives
+// first-wins semantics.
+func FromGoMapsFunc[Key comparable, Value any, Map AbstractMap[Key, Value]](m Map, resolve func(key Key, existing, incoming Value) Value, gms ...map[Key]Value) Map {
+	if g, ok := any(m).(grower); ok {
+		total := 0
+		for _, gm := range gms {
+			total += len(gm)
+		}
+		g.Grow(total)
+	}
 	for _, gm := range gms {
 		for k, v := range gm {
+			if existing, ok := m.Load(k); ok {
+				v = resolve(k, existing, v)
+			}
 			m.Store(k, v)
 		}
 	}
 	return m
 }
 
+// FromAbstractMaps stores the entries of ams into m and returns m.
+// If m has a Grow method it is first grown by the combined length of the inputs.
 func FromAbstractMaps[Key, Value any, Map AbstractMap[Key, Value]](m Map, ams ...AbstractMap[Key, Value]) Map {
+	if g, ok := any(m).(grower); ok {
+		total := 0
+		for _, am := range ams {
+			total += am.Len()
+		}
+		g.Grow(total)
+	}
 	for _, am := range ams {
 		for k, v := range am.Range {
 			m.Store(k, v)
@@ -38,17 +102,279 @@ func FromAbstractMaps[Key, Value any, Map AbstractMap[Key, Value]](m Map, ams ..
 	return m
 }
 
+// ToGoMap copies the entries of m into a new builtin map.
+// Any iteration order m has is lost.
+func ToGoMap[Key comparable, Value any](m AbstractMap[Key, Value]) map[Key]Value {
+	gm := make(map[Key]Value, m.Len())
+	for k, v := range m.Range {
+		gm[k] = v
+	}
+	return gm
+}
+
+// Cloner is implemented by maps that can copy themselves.
+type Cloner[Key, Value any] interface {
+	// Clone returns an independent map of the same concrete type holding
+	// the same entries in the same iteration order. Values are copied
+	// shallowly: pointers, slices and maps stored as values stay shared.
+	Clone() AbstractMap[Key, Value]
+}
+
+// Clone returns an independent copy of src. If src implements Cloner the copy
+// has the same concrete type; otherwise the entries are copied into a new
+// OrderedMap in src's Range order. Values are copied shallowly.
+func Clone[Key comparable, Value any](src AbstractMap[Key, Value]) AbstractMap[Key, Value] {
+	if c, ok := src.(Cloner[Key, Value]); ok {
+		return c.Clone()
+	}
+	return FromAbstractMaps(NewOrderedMap[Key, Value](), src)
+}
+
+// Partition splits src into two new maps of the same concrete kind: matched
+// holds the entries for which pred returns true and unmatched holds the rest.
+// Both results are built with Clone, so an OrderedMap keeps its relative
+// order within each result. src is not modified.
+func Partition[K comparable, V any](src AbstractMap[K, V], pred func(key K, value V) bool) (matched, unmatched AbstractMap[K, V]) {
+	matched, unmatched = Clone(src), Clone(src)
+	for k, v := range src.Range {
+		if pred(k, v) {
+			unmatched.Delete(k)
+		} else {
+			matched.Delete(k)
+		}
+	}
+	return matched, unmatched
+}
+
+// RetainAll removes every entry of m whose key is not among keys and
+// returns how many were removed. Keys are matched with ==, through a lookup
+// set built first, so the cost is O(n + len(keys)). Entries are removed with
+// m's DeleteWhere when it has one, and otherwise collected in one pass and
+// deleted afterwards.
+func RetainAll[K comparable, V any](m AbstractMap[K, V], keys ...K) int {
+	keep := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		keep[key] = struct{}{}
+	}
+	doomed := func(key K, _ V) bool {
+		_, ok := keep[key]
+		return !ok
+	}
+	if d, ok := m.(interface {
+		DeleteWhere(pred func(key K, value V) bool) int
+	}); ok {
+		return d.DeleteWhere(doomed)
+	}
+	var removed []K
+	m.Range(func(key K, value V) bool {
+		if doomed(key, value) {
+			removed = append(removed, key)
+		}
+		return true
+	})
+	for _, key := range removed {
+		m.Delete(key)
+	}
+	return len(removed)
+}
+
+// Reduce folds the entries of m into an accumulator, starting from init and
+// calling f for each entry in m's Range order. For an OrderedMap that is
+// insertion order, so the result is deterministic.
+func Reduce[K, V, A any](m AbstractMap[K, V], init A, f func(acc A, key K, value V) A) A {
+	acc := init
+	for k, v := range m.Range {
+		acc = f(acc, k, v)
+	}
+	return acc
+}
+
+// Invert returns a new map with the keys and values of src swapped. When
+// several keys share a value, the one Range visits last wins. If src is an
+// UnorderedMap the result is one too; otherwise it is an OrderedMap in which
+// each value sits where Range first met it.
+func Invert[K, V comparable](src AbstractMap[K, V]) AbstractMap[V, K] {
+	dst := newResultMap[K, V, V, K](src)
+	for k, v := range src.Range {
+		dst.Store(v, k)
+	}
+	return dst
+}
+
+// InvertMulti returns a new map from each value of src to all the keys that
+// hold it, in Range order. The result kind follows the same rules as Invert.
+func InvertMulti[K, V comparable](src AbstractMap[K, V]) AbstractMap[V, []K] {
+	dst := newResultMap[K, V, V, []K](src)
+	for k, v := range src.Range {
+		keys, _ := dst.Load(v)
+		dst.Store(v, append(keys, k))
+	}
+	return dst
+}
+
+// MapKeys returns a new map holding each value of src under f(key). When f
+// maps several keys to the same new key, the value Range visits last wins.
+// The result kind follows the same rules as Invert, so for an ordered src
+// each new key sits where Range first produced it.
+func MapKeys[K1, K2 comparable, V any](src AbstractMap[K1, V], f func(key K1) K2) AbstractMap[K2, V] {
+	dst := newResultMap[K1, V, K2, V](src)
+	for k, v := range src.Range {
+		dst.Store(f(k), v)
+	}
+	return dst
+}
+
+// FlatMap returns a new map holding every pair f returns for the entries of
+// src. When pairs share a key, the one stored last wins. The result kind
+// follows the same rules as Invert, so for an ordered src each key sits
+// where it was first produced, in Range order and then in the order of f's
+// result.
+func FlatMap[K comparable, V any, K2 comparable, V2 any](src AbstractMap[K, V], f func(key K, value V) []Pair[K2, V2]) AbstractMap[K2, V2] {
+	dst := newResultMap[K, V, K2, V2](src)
+	for k, v := range src.Range {
+		for _, p := range f(k, v) {
+			dst.Store(p.Key, p.Value)
+		}
+	}
+	return dst
+}
+
+// Index returns a new UnorderedMap from keyFn(item) to item for each of
+// items. When several items share a key, the last one wins.
+func Index[T any, K comparable](items []T, keyFn func(item T) K) AbstractMap[K, T] {
+	um := NewUnorderedMapWithCapacity[K, T](len(items))
+	for _, item := range items {
+		um.Store(keyFn(item), item)
+	}
+	return um
+}
+
+// IndexOrdered is like Index but returns an OrderedMap whose keys are in the
+// order they first appear in items.
+func IndexOrdered[T any, K comparable](items []T, keyFn func(item T) K) *OrderedMap[K, T] {
+	om := NewOrderedMapWithCapacity[K, T](len(items))
+	for _, item := range items {
+		om.Store(keyFn(item), item)
+	}
+	return om
+}
+
+// CollectSeq returns a new UnorderedMap holding the pairs of seq. A key that
+// appears more than once keeps its last value.
+func CollectSeq[K comparable, V any](seq iter.Seq2[K, V]) *UnorderedMap[K, V] {
+	um := NewUnorderedMap[K, V]()
+	for k, v := range seq {
+		um.Store(k, v)
+	}
+	return um
+}
+
+// CollectSeqOrdered returns a new OrderedMap holding the pairs of seq in
+// sequence order. A key that appears more than once keeps its first
+// position and its last value.
+func CollectSeqOrdered[K comparable, V any](seq iter.Seq2[K, V]) *OrderedMap[K, V] {
+	om := NewOrderedMap[K, V]()
+	for k, v := range seq {
+		om.Store(k, v)
+	}
+	return om
+}
+
+// DistinctValues returns each distinct value of m once, in the order Range
+// first encounters it, so first-insertion order for an OrderedMap.
+func DistinctValues[K any, V comparable](m AbstractMap[K, V]) []V {
+	return DistinctValuesFunc(m, func(value V) V { return value })
+}
+
+// DistinctValuesFunc is like DistinctValues but treats two values as equal
+// when key returns the same result for them, for non-comparable V. The first
+// value encountered for each key is kept.
+func DistinctValuesFunc[K, V any, D comparable](m AbstractMap[K, V], key func(value V) D) []V {
+	seen := make(map[D]struct{})
+	var values []V
+	m.Values(func(value V) bool {
+		d := key(value)
+		if _, ok := seen[d]; !ok {
+			seen[d] = struct{}{}
+			values = append(values, value)
+		}
+		return true
+	})
+	return values
+}
+
+// SortedKeys returns the keys of m in ascending order.
+func SortedKeys[K cmp.Ordered, V any](m AbstractMap[K, V]) []K {
+	keys := collectKeys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// SortedKeysFunc returns the keys of m sorted by less.
+func SortedKeysFunc[K, V any](m AbstractMap[K, V], less func(a, b K) bool) []K {
+	keys := collectKeys(m)
+	slices.SortFunc(keys, func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return keys
+}
+
+// RangeSorted calls f for each entry of m in ascending key order until f
+// returns false. It sorts a snapshot of the keys and then Loads each one, so
+// keys deleted in the meantime are skipped.
+func RangeSorted[K cmp.Ordered, V any](m AbstractMap[K, V], f func(key K, value V) bool) {
+	for _, k := range SortedKeys(m) {
+		if v, ok := m.Load(k); ok && !f(k, v) {
+			return
+		}
+	}
+}
+
+// collectKeys returns the keys of m in Range order.
+func collectKeys[K, V any](m AbstractMap[K, V]) []K {
+	keys := make([]K, 0, m.Len())
+	m.Keys(func(key K) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// newResultMap returns an empty map to hold a transformation of src: an
+// UnorderedMap if src is one, since its order is meaningless anyway, and an
+// OrderedMap otherwise so that src's iteration order can be preserved.
+func newResultMap[K comparable, V any, K2 comparable, V2 any](src AbstractMap[K, V]) AbstractMap[K2, V2] {
+	if _, ok := src.(*UnorderedMap[K, V]); ok {
+		return NewUnorderedMapWithCapacity[K2, V2](src.Len())
+	}
+	return NewOrderedMapWithCapacity[K2, V2](src.Len())
+}
+
+// DefaultAbstractMap provides the AbstractMap methods that can be built from
+// MapOps. Implementations embed it and must supply MapOps and Len themselves;
+// there is no default Len because counting through Range would be O(n).
 type DefaultAbstractMap[Key, Value any] struct {
-	impl AbstractMap[Key, Value]
+	impl      AbstractMap[Key, Value]
+	observers atomic.Pointer[observers[Key, Value]] // Registered with Observe
+	frozen    *atomic.Bool                          // Set by Freeze; shared by BiMap views
 }
 
 func NewDefaultAbstractMap[Key, Value any](impl AbstractMap[Key, Value]) *DefaultAbstractMap[Key, Value] {
 	return &DefaultAbstractMap[Key, Value]{
-		impl: impl,
+		impl:   impl,
+		frozen: new(atomic.Bool),
 	}
 }
 
 func (m *DefaultAbstractMap[Key, Value]) Clear() {
+	m.checkMutable("Clear")
 	var keys []Key
 	for key := range m.impl.Range {
 		keys = append(keys, key)
@@ -58,41 +384,52 @@ func (m *DefaultAbstractMap[Key, Value]) Clear() {
 	}
 }
 
+// CompareAndDelete deletes the entry for key if its value equals old.
+// Values are compared with ==, which panics if V is not comparable
+// (slices, maps, funcs); use CompareAndDeleteFunc for such types.
 func (m *DefaultAbstractMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return m.CompareAndDeleteFunc(key, old, equalAny[V])
+}
+
+// CompareAndDeleteFunc deletes the entry for key if eq reports its value
+// equal to old.
+func (m *DefaultAbstractMap[K, V]) CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) (deleted bool) {
+	m.checkMutable("CompareAndDeleteFunc")
 	value, ok := m.impl.Load(key)
 	if !ok {
 		return false
 	}
-	// Compare using interface{} since we can't assume comparable types
-	if any(value) == any(old) {
+	if eq(value, old) {
 		m.impl.Delete(key)
 		return true
 	}
 	return false
 }
 
+// CompareAndSwap stores new for key if its current value equals old.
+// Values are compared with ==, which panics if V is not comparable
+// (slices, maps, funcs); use CompareAndSwapFunc for such types.
 func (m *DefaultAbstractMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return m.CompareAndSwapFunc(key, old, new, equalAny[V])
+}
+
+// CompareAndSwapFunc stores new for key if eq reports its current value
+// equal to old.
+func (m *DefaultAbstractMap[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) (swapped bool) {
+	m.checkMutable("CompareAndSwapFunc")
 	value, ok := m.impl.Load(key)
 	if !ok {
 		return false
 	}
-	// Compare using interface{} since we can't assume comparable types
-	if any(value) == any(old) {
+	if eq(value, old) {
 		m.impl.Store(key, new)
 		return true
 	}
 	return false
 }
 
-func (m *DefaultAbstractMap[K, V]) Len() int {
-	var len int
-	for range m.impl.Range {
-		len += 1
-	}
-	return len
-}
-
 func (m *DefaultAbstractMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	m.checkMutable("LoadAndDelete")
 	value, loaded = m.impl.Load(key)
 	if loaded {
 		m.impl.Delete(key)
@@ -101,6 +438,7 @@ func (m *DefaultAbstractMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
 }
 
 func (m *DefaultAbstractMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	m.checkMutable("LoadOrStore")
 	actual, loaded = m.impl.Load(key)
 	if !loaded {
 		m.impl.Store(key, value)
@@ -126,7 +464,381 @@ func (m *DefaultAbstractMap[K, V]) Values(f func(value V) bool) {
 }
 
 func (m *DefaultAbstractMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	m.checkMutable("Swap")
 	previous, loaded = m.impl.Load(key)
 	m.impl.Store(key, value)
 	return previous, loaded
 }
+
+// All returns an iterator over the map's entries in Range order,
+// for use with range-over-func loops.
+func (m *DefaultAbstractMap[K, V]) All() iter.Seq2[K, V] {
+	return m.impl.Range
+}
+
+// KeysSeq returns an iterator over the map's keys in Range order.
+func (m *DefaultAbstractMap[K, V]) KeysSeq() iter.Seq[K] {
+	return m.impl.Keys
+}
+
+// ValuesSeq returns an iterator over the map's values in Range order.
+func (m *DefaultAbstractMap[K, V]) ValuesSeq() iter.Seq[V] {
+	return m.impl.Values
+}
+
+// Entries returns an iterator over the map's entries as Pairs in Range
+// order, for APIs that take a single-value iter.Seq.
+func (m *DefaultAbstractMap[K, V]) Entries() iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		m.impl.Range(func(key K, value V) bool {
+			return yield(Pair[K, V]{Key: key, Value: value})
+		})
+	}
+}
+
+// ComputeIfAbsent returns the value for key, first storing f(key) if the key
+// is missing. f is not called when the key is present.
+func (m *DefaultAbstractMap[K, V]) ComputeIfAbsent(key K, f func(key K) V) V {
+	m.checkMutable("ComputeIfAbsent")
+	if value, ok := m.impl.Load(key); ok {
+		return value
+	}
+	value := f(key)
+	m.impl.Store(key, value)
+	return value
+}
+
+// LoadOrCompute is like LoadOrStore but only calls f to build the value
+// when key is missing. The concurrency-safe maps override it to hold their
+// lock across f, so f runs at most once per missing key; f must not call
+// back into the map.
+func (m *DefaultAbstractMap[K, V]) LoadOrCompute(key K, f func() V) (actual V, loaded bool) {
+	m.checkMutable("LoadOrCompute")
+	if actual, loaded = m.impl.Load(key); loaded {
+		return actual, true
+	}
+	actual = f()
+	m.impl.Store(key, actual)
+	return actual, false
+}
+
+// loadOrCompute implements LoadOrCompute with update, a lock-held
+// AtomicUpdate, so f runs under the same lock as the load and store.
+func loadOrCompute[K, V any](key K, f func() V, update func(K, func(V, bool) (V, bool)) (V, bool)) (actual V, loaded bool) {
+	loaded = true
+	actual, _ = update(key, func(old V, present bool) (V, bool) {
+		if present {
+			return old, false
+		}
+		loaded = false
+		return f(), true
+	})
+	return actual, loaded
+}
+
+// ComputeIfPresent recomputes the value for key if it is present.
+// f receives the current value and returns the new one and whether to keep
+// the entry; returning false deletes the key. It returns the resulting value
+// and whether the key is present afterwards. f is not called for missing keys.
+func (m *DefaultAbstractMap[K, V]) ComputeIfPresent(key K, f func(key K, value V) (V, bool)) (V, bool) {
+	m.checkMutable("ComputeIfPresent")
+	old, ok := m.impl.Load(key)
+	if !ok {
+		return old, false
+	}
+	value, keep := f(key, old)
+	if !keep {
+		m.impl.Delete(key)
+		var zero V
+		return zero, false
+	}
+	m.impl.Store(key, value)
+	return value, true
+}
+
+// AtomicUpdate loads the value for key, calls f with it and whether it was
+// present, and stores the value f returns if f also returns true. It returns
+// the value now held for key and whether the key is present.
+// The concurrency-safe maps override AtomicUpdate to run the whole sequence
+// under one lock, so no other mutation of the key can interleave; f must not
+// call back into the map. For other maps it is a plain Load and Store.
+func (m *DefaultAbstractMap[K, V]) AtomicUpdate(key K, f func(old V, loaded bool) (new V, store bool)) (V, bool) {
+	m.checkMutable("AtomicUpdate")
+	return atomicUpdate(key, f, m.impl.Load, m.impl.Store)
+}
+
+// atomicUpdate implements AtomicUpdate with the given load and store
+// primitives. Callers that need atomicity hold their lock around it.
+func atomicUpdate[K, V any](key K, f func(old V, loaded bool) (V, bool), load func(K) (V, bool), store func(K, V)) (V, bool) {
+	old, loaded := load(key)
+	value, ok := f(old, loaded)
+	if !ok {
+		return old, loaded
+	}
+	store(key, value)
+	return value, true
+}
+
+// Compute recomputes the value for key whether or not it is present.
+// f receives the current value (the zero value if missing) and whether it was
+// present, and returns the new value and whether to keep the entry; returning
+// false deletes the key if present. It returns the resulting value and
+// whether the key is present afterwards.
+func (m *DefaultAbstractMap[K, V]) Compute(key K, f func(key K, value V, loaded bool) (V, bool)) (V, bool) {
+	m.checkMutable("Compute")
+	old, loaded := m.impl.Load(key)
+	value, keep := f(key, old, loaded)
+	if !keep {
+		if loaded {
+			m.impl.Delete(key)
+		}
+		var zero V
+		return zero, false
+	}
+	m.impl.Store(key, value)
+	return value, true
+}
+
+// Merge stores every entry of other into the map. For keys already present
+// the stored value is resolve(key, existing, incoming); other keys are stored
+// as is. Passing a resolve that returns incoming gives the overwrite behaviour
+// of FromAbstractMaps. Maps that preserve insertion order append new keys and
+// leave existing keys in place.
+func (m *DefaultAbstractMap[K, V]) Merge(other AbstractMap[K, V], resolve func(key K, existing, incoming V) V) {
+	m.checkMutable("Merge")
+	other.Range(func(key K, incoming V) bool {
+		if existing, ok := m.impl.Load(key); ok {
+			m.impl.Store(key, resolve(key, existing, incoming))
+		} else {
+			m.impl.Store(key, incoming)
+		}
+		return true
+	})
+}
+
+// KeysSlice returns the map's keys in Range order.
+func (m *DefaultAbstractMap[K, V]) KeysSlice() []K {
+	return m.AppendKeys(make([]K, 0, m.impl.Len()))
+}
+
+// AppendKeys appends the map's keys to dst in Range order and returns the
+// extended slice, growing it at most once. Passing a reused buffer such as
+// buf[:0] avoids allocating on every call.
+func (m *DefaultAbstractMap[K, V]) AppendKeys(dst []K) []K {
+	dst = slices.Grow(dst, m.impl.Len())
+	m.impl.Range(func(key K, _ V) bool {
+		dst = append(dst, key)
+		return true
+	})
+	return dst
+}
+
+// ValuesSlice returns the map's values in Range order.
+func (m *DefaultAbstractMap[K, V]) ValuesSlice() []V {
+	return m.AppendValues(make([]V, 0, m.impl.Len()))
+}
+
+// AppendValues appends the map's values to dst in Range order and returns
+// the extended slice, growing it at most once.
+func (m *DefaultAbstractMap[K, V]) AppendValues(dst []V) []V {
+	dst = slices.Grow(dst, m.impl.Len())
+	m.impl.Range(func(_ K, value V) bool {
+		dst = append(dst, value)
+		return true
+	})
+	return dst
+}
+
+// EntriesSlice returns the map's key-value pairs in Range order.
+func (m *DefaultAbstractMap[K, V]) EntriesSlice() []Pair[K, V] {
+	entries := make([]Pair[K, V], 0, m.impl.Len())
+	m.impl.Range(func(key K, value V) bool {
+		entries = append(entries, Pair[K, V]{Key: key, Value: value})
+		return true
+	})
+	return entries
+}
+
+// ContainsKey reports whether key is present in the map.
+func (m *DefaultAbstractMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.impl.Load(key)
+	return ok
+}
+
+// ContainsValue reports whether any entry holds value, stopping at the first
+// match. Values are compared with ==, which panics if V is not comparable;
+// use ContainsValueFunc for such types.
+func (m *DefaultAbstractMap[K, V]) ContainsValue(value V) bool {
+	return m.ContainsValueFunc(func(v V) bool { return equalAny(v, value) })
+}
+
+// ContainsValueFunc reports whether any entry's value satisfies pred,
+// stopping at the first match.
+func (m *DefaultAbstractMap[K, V]) ContainsValueFunc(pred func(value V) bool) bool {
+	found := false
+	m.impl.Range(func(_ K, v V) bool {
+		found = pred(v)
+		return !found
+	})
+	return found
+}
+
+// Count returns the number of entries satisfying pred.
+func (m *DefaultAbstractMap[K, V]) Count(pred func(key K, value V) bool) int {
+	n := 0
+	m.impl.Range(func(key K, value V) bool {
+		if pred(key, value) {
+			n++
+		}
+		return true
+	})
+	return n
+}
+
+// Any reports whether some entry satisfies pred, stopping at the first match.
+func (m *DefaultAbstractMap[K, V]) Any(pred func(key K, value V) bool) bool {
+	_, _, found := m.Find(pred)
+	return found
+}
+
+// Every reports whether all entries satisfy pred, stopping at the first
+// failure. It is true for an empty map.
+func (m *DefaultAbstractMap[K, V]) Every(pred func(key K, value V) bool) bool {
+	every := true
+	m.impl.Range(func(key K, value V) bool {
+		every = pred(key, value)
+		return every
+	})
+	return every
+}
+
+// Find returns the first entry in Range order satisfying pred.
+func (m *DefaultAbstractMap[K, V]) Find(pred func(key K, value V) bool) (key K, value V, found bool) {
+	m.impl.Range(func(k K, v V) bool {
+		if pred(k, v) {
+			key, value, found = k, v, true
+		}
+		return !found
+	})
+	return key, value, found
+}
+
+// StoreAll stores each pair in argument order, as if by calling Store for
+// each one.
+func (m *DefaultAbstractMap[K, V]) StoreAll(pairs ...Pair[K, V]) {
+	m.checkMutable("StoreAll")
+	for _, p := range pairs {
+		m.impl.Store(p.Key, p.Value)
+	}
+}
+
+// Pop removes and returns one entry: the first one Range visits, so the
+// front entry of an OrderedMap and an arbitrary one of an UnorderedMap.
+// ok is false if the map is empty.
+func (m *DefaultAbstractMap[K, V]) Pop() (key K, value V, ok bool) {
+	m.checkMutable("Pop")
+	m.impl.Range(func(k K, v V) bool {
+		key, value, ok = k, v, true
+		return false
+	})
+	if ok {
+		m.impl.Delete(key)
+	}
+	return key, value, ok
+}
+
+// DrainTo moves every entry into dst, storing them in Range order, and then
+// clears the map. Entries from an OrderedMap are therefore appended to an
+// ordered dst in insertion order. dst must not be the map itself.
+func (m *DefaultAbstractMap[K, V]) DrainTo(dst AbstractMap[K, V]) {
+	m.checkMutable("DrainTo")
+	m.impl.Range(func(key K, value V) bool {
+		dst.Store(key, value)
+		return true
+	})
+	m.impl.Clear()
+}
+
+// DeleteAll removes each of keys from the map, ignoring absent ones.
+func (m *DefaultAbstractMap[K, V]) DeleteAll(keys ...K) {
+	m.checkMutable("DeleteAll")
+	for _, key := range keys {
+		m.impl.Delete(key)
+	}
+}
+
+// DeleteWhere removes every entry for which pred returns true and returns
+// how many were removed. The matching keys are collected in one pass over
+// the map and deleted afterwards, so pred never sees a map being modified.
+func (m *DefaultAbstractMap[K, V]) DeleteWhere(pred func(key K, value V) bool) int {
+	m.checkMutable("DeleteWhere")
+	var keys []K
+	m.impl.Range(func(key K, value V) bool {
+		if pred(key, value) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	for _, key := range keys {
+		m.impl.Delete(key)
+	}
+	return len(keys)
+}
+
+// RetainWhere removes every entry for which pred returns false and returns
+// how many were removed.
+func (m *DefaultAbstractMap[K, V]) RetainWhere(pred func(key K, value V) bool) int {
+	m.checkMutable("RetainWhere")
+	return m.DeleteWhere(func(key K, value V) bool {
+		return !pred(key, value)
+	})
+}
+
+// Rename moves the value stored for oldKey to newKey. It returns false,
+// leaving the map unchanged, if oldKey is absent or newKey is already
+// present. This default deletes oldKey and then stores newKey, so an
+// insertion-ordered map would move the entry to the end; OrderedMap
+// overrides it to keep the position.
+func (m *DefaultAbstractMap[K, V]) Rename(oldKey, newKey K) bool {
+	m.checkMutable("Rename")
+	value, ok := m.impl.Load(oldKey)
+	if !ok {
+		return false
+	}
+	if _, exists := m.impl.Load(newKey); exists {
+		return false
+	}
+	m.impl.Delete(oldKey)
+	m.impl.Store(newKey, value)
+	return true
+}
+
+// StoreIfAbsent stores value for key only if the key is missing and reports
+// whether it did. It is LoadOrStore for callers that only need to know
+// whether their insert won.
+func (m *DefaultAbstractMap[K, V]) StoreIfAbsent(key K, value V) bool {
+	m.checkMutable("StoreIfAbsent")
+	_, loaded := m.impl.LoadOrStore(key, value)
+	return !loaded
+}
+
+// StoreIfPresent replaces the value for key only if the key is present and
+// reports whether it did. On the concurrency-safe maps the check and the
+// store happen under one lock.
+func (m *DefaultAbstractMap[K, V]) StoreIfPresent(key K, value V) bool {
+	m.checkMutable("StoreIfPresent")
+	update := func(_ V, loaded bool) (V, bool) { return value, loaded }
+	var stored bool
+	if u, ok := m.impl.(atomicUpdater[K, V]); ok {
+		_, stored = u.AtomicUpdate(key, update)
+	} else {
+		_, stored = atomicUpdate(key, update, m.impl.Load, m.impl.Store)
+	}
+	return stored
+}
+
+// equalAny compares two values using interface{} since we can't assume
+// comparable types. It panics if the dynamic type is not comparable.
+func equalAny[V any](a, b V) bool {
+	return any(a) == any(b)
+}
@@ -1,8 +1,12 @@
 package maps_test
 
 import (
+	"fmt"
+	"iter"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/13770129/containers/maps"
 )
@@ -455,3 +459,1424 @@ func testEdgeCases[K comparable, V comparable](t *testing.T, factory MapFactory[
 		}
 	})
 }
+
+// TestCompareFuncNonComparableValues exercises the comparator-aware
+// CompareAndSwapFunc/CompareAndDeleteFunc with slice values, which would
+// panic under the ==-based CompareAndSwap/CompareAndDelete.
+func TestCompareFuncNonComparableValues(t *testing.T) {
+	type sliceMap interface {
+		maps.AbstractMap[string, []int]
+		CompareAndSwapFunc(key string, old, new []int, eq func(a, b []int) bool) bool
+		CompareAndDeleteFunc(key string, old []int, eq func(a, b []int) bool) bool
+	}
+	factories := map[string]func() sliceMap{
+		"UnorderedMap":  func() sliceMap { return maps.NewUnorderedMap[string, []int]() },
+		"OrderedMap":    func() sliceMap { return maps.NewOrderedMap[string, []int]() },
+		"ConcurrentMap": func() sliceMap { return maps.NewConcurrentMap[string, []int]() },
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			m.Store("key", []int{1, 2, 3})
+
+			if m.CompareAndSwapFunc("key", []int{9}, []int{4}, slices.Equal[[]int]) {
+				t.Error("Expected CompareAndSwapFunc to fail with wrong old value")
+			}
+			if !m.CompareAndSwapFunc("key", []int{1, 2, 3}, []int{4, 5}, slices.Equal[[]int]) {
+				t.Error("Expected CompareAndSwapFunc to succeed with equal old value")
+			}
+			if value, _ := m.Load("key"); !slices.Equal(value, []int{4, 5}) {
+				t.Errorf("Expected [4 5] after swap, got %v", value)
+			}
+
+			if m.CompareAndDeleteFunc("key", []int{1, 2, 3}, slices.Equal[[]int]) {
+				t.Error("Expected CompareAndDeleteFunc to fail with stale value")
+			}
+			if !m.CompareAndDeleteFunc("key", []int{4, 5}, slices.Equal[[]int]) {
+				t.Error("Expected CompareAndDeleteFunc to succeed with current value")
+			}
+			if _, ok := m.Load("key"); ok {
+				t.Error("Expected key to be deleted")
+			}
+		})
+	}
+
+	t.Run("CompareAndSwapPanicsOnSlices", func(t *testing.T) {
+		m := maps.NewUnorderedMap[string, []int]()
+		m.Store("key", []int{1})
+
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected CompareAndSwap on slice values to panic")
+			}
+		}()
+		m.CompareAndSwap("key", []int{1}, []int{2})
+	})
+}
+
+// BenchmarkClear compares the generic DefaultAbstractMap.Clear, which deletes
+// keys one by one, against the specialized implementations.
+func BenchmarkClear(b *testing.B) {
+	const size = 100000
+
+	fill := func(m maps.AbstractMap[int, int]) {
+		for i := 0; i < size; i++ {
+			m.Store(i, i)
+		}
+	}
+
+	b.Run("UnorderedMapDefault", func(b *testing.B) {
+		b.ReportAllocs()
+		m := maps.NewUnorderedMap[int, int]()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			fill(m)
+			b.StartTimer()
+			maps.NewDefaultAbstractMap[int, int](m).Clear()
+		}
+	})
+
+	b.Run("UnorderedMapSpecialized", func(b *testing.B) {
+		b.ReportAllocs()
+		m := maps.NewUnorderedMap[int, int]()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			fill(m)
+			b.StartTimer()
+			m.Clear()
+		}
+	})
+
+	b.Run("OrderedMapDefault", func(b *testing.B) {
+		b.ReportAllocs()
+		m := maps.NewOrderedMap[int, int]()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			fill(m)
+			b.StartTimer()
+			maps.NewDefaultAbstractMap[int, int](m).Clear()
+		}
+	})
+
+	b.Run("OrderedMapSpecialized", func(b *testing.B) {
+		b.ReportAllocs()
+		m := maps.NewOrderedMap[int, int]()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			fill(m)
+			b.StartTimer()
+			m.Clear()
+		}
+	})
+}
+
+func TestIterators(t *testing.T) {
+	t.Run("OrderedMapAll", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"c", "a", "d", "b"} {
+			om.Store(key, i)
+		}
+
+		var keys []string
+		var values []int
+		for k, v := range om.All() {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		if !slices.Equal(keys, []string{"c", "a", "d", "b"}) {
+			t.Errorf("Expected keys in insertion order, got %v", keys)
+		}
+		if !slices.Equal(values, []int{0, 1, 2, 3}) {
+			t.Errorf("Expected values in insertion order, got %v", values)
+		}
+	})
+
+	t.Run("OrderedMapEarlyBreak", func(t *testing.T) {
+		om := maps.NewOrderedMap[int, int]()
+		for i := 0; i < 10; i++ {
+			om.Store(i, i*i)
+		}
+
+		var keys []int
+		for k := range om.KeysSeq() {
+			keys = append(keys, k)
+			if len(keys) == 3 {
+				break
+			}
+		}
+		if !slices.Equal(keys, []int{0, 1, 2}) {
+			t.Errorf("Expected iteration to stop after [0 1 2], got %v", keys)
+		}
+
+		var values []int
+		for v := range om.ValuesSeq() {
+			values = append(values, v)
+			if len(values) == 4 {
+				break
+			}
+		}
+		if !slices.Equal(values, []int{0, 1, 4, 9}) {
+			t.Errorf("Expected iteration to stop after [0 1 4 9], got %v", values)
+		}
+	})
+
+	t.Run("UnorderedMapEarlyBreak", func(t *testing.T) {
+		um := maps.NewUnorderedMap[int, int]()
+		for i := 0; i < 10; i++ {
+			um.Store(i, i)
+		}
+
+		visited := 0
+		for range um.All() {
+			visited++
+			if visited == 5 {
+				break
+			}
+		}
+		if visited != 5 {
+			t.Errorf("Expected 5 iterations before break, got %d", visited)
+		}
+
+		seen := map[int]bool{}
+		for k, v := range um.All() {
+			if k != v {
+				t.Errorf("Expected value %d for key %d, got %d", k, k, v)
+			}
+			seen[k] = true
+		}
+		if len(seen) != 10 {
+			t.Errorf("Expected to visit all 10 keys, got %d", len(seen))
+		}
+
+		count := 0
+		for range um.ValuesSeq() {
+			count++
+			break
+		}
+		if count != 1 {
+			t.Errorf("Expected ValuesSeq to stop after 1, got %d", count)
+		}
+	})
+}
+
+func TestClone(t *testing.T) {
+	factories := map[string]MapFactory[string, int]{
+		"UnorderedMap":  func() maps.AbstractMap[string, int] { return maps.NewUnorderedMap[string, int]() },
+		"OrderedMap":    func() maps.AbstractMap[string, int] { return maps.NewOrderedMap[string, int]() },
+		"ConcurrentMap": func() maps.AbstractMap[string, int] { return maps.NewConcurrentMap[string, int]() },
+		"SortedMap": func() maps.AbstractMap[string, int] {
+			return maps.NewSortedMap[string, int](func(a, b string) bool { return a < b })
+		},
+		"LRUMap": func() maps.AbstractMap[string, int] { return maps.NewLRUMap[string, int](10) },
+		"TTLMap": func() maps.AbstractMap[string, int] {
+			return maps.NewTTLMapWithClock[string, int](time.Minute, newFakeClock())
+		},
+		"CustomKeyMap": func() maps.AbstractMap[string, int] {
+			return maps.NewCustomKeyMap[string, int](
+				func(s string) uint64 { return uint64(len(s)) },
+				func(a, b string) bool { return a == b },
+			)
+		},
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			src := factory()
+			src.Store("a", 1)
+			src.Store("b", 2)
+			src.Store("c", 3)
+
+			clone := maps.Clone(src)
+			if diff := maps.DiffString(src, clone); diff != "" {
+				t.Errorf("Expected clone to equal source, diff:\n%s", diff)
+			}
+			if fmt.Sprintf("%T", clone) != fmt.Sprintf("%T", src) {
+				t.Errorf("Expected clone of type %T, got %T", src, clone)
+			}
+
+			clone.Store("a", 100)
+			clone.Delete("b")
+			clone.Store("d", 4)
+
+			if value, _ := src.Load("a"); value != 1 {
+				t.Errorf("Expected source a=1 after mutating clone, got %d", value)
+			}
+			if _, ok := src.Load("b"); !ok {
+				t.Error("Expected source to keep b after deleting it from clone")
+			}
+			if _, ok := src.Load("d"); ok {
+				t.Error("Expected source not to gain d")
+			}
+			if src.Len() != 3 {
+				t.Errorf("Expected source length 3, got %d", src.Len())
+			}
+		})
+	}
+
+	t.Run("OrderedMapPreservesOrder", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"delta", "alpha", "charlie", "bravo"} {
+			om.Store(key, i)
+		}
+
+		var keys []string
+		maps.Clone[string, int](om).Keys(func(key string) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if !slices.Equal(keys, []string{"delta", "alpha", "charlie", "bravo"}) {
+			t.Errorf("Expected clone in insertion order, got %v", keys)
+		}
+	})
+
+	t.Run("ValuesAreShallow", func(t *testing.T) {
+		um := maps.NewUnorderedMap[string, *int]()
+		value := 1
+		um.Store("ptr", &value)
+
+		clone := maps.Clone[string, *int](um)
+		shared, _ := clone.Load("ptr")
+		*shared = 2
+		if value != 2 {
+			t.Error("Expected pointer values to be shared between source and clone")
+		}
+	})
+}
+
+func TestCompute(t *testing.T) {
+	t.Run("ComputeIfAbsent", func(t *testing.T) {
+		m := maps.NewUnorderedMap[string, int]()
+		calls := 0
+		length := func(key string) int {
+			calls++
+			return len(key)
+		}
+
+		if value := m.ComputeIfAbsent("hello", length); value != 5 {
+			t.Errorf("Expected computed value 5, got %d", value)
+		}
+		if value, ok := m.Load("hello"); !ok || value != 5 {
+			t.Errorf("Expected computed value to be stored, got %d (ok=%v)", value, ok)
+		}
+
+		m.Store("hello", 42)
+		if value := m.ComputeIfAbsent("hello", length); value != 42 {
+			t.Errorf("Expected existing value 42, got %d", value)
+		}
+		if calls != 1 {
+			t.Errorf("Expected f to be called once, got %d", calls)
+		}
+	})
+
+	t.Run("ComputeIfPresentAbsentKey", func(t *testing.T) {
+		m := maps.NewUnorderedMap[string, int]()
+		called := false
+
+		value, ok := m.ComputeIfPresent("missing", func(key string, value int) (int, bool) {
+			called = true
+			return 1, true
+		})
+		if called {
+			t.Error("Expected f not to be called for an absent key")
+		}
+		if ok || value != 0 {
+			t.Errorf("Expected (0, false) for absent key, got (%d, %v)", value, ok)
+		}
+		if m.Len() != 0 {
+			t.Error("Expected nothing to be stored for an absent key")
+		}
+	})
+
+	t.Run("ComputeIfPresentUpdateAndDelete", func(t *testing.T) {
+		m := maps.NewOrderedMap[string, int]()
+		m.Store("count", 1)
+
+		value, ok := m.ComputeIfPresent("count", func(key string, value int) (int, bool) {
+			return value + 1, true
+		})
+		if !ok || value != 2 {
+			t.Errorf("Expected (2, true), got (%d, %v)", value, ok)
+		}
+
+		_, ok = m.ComputeIfPresent("count", func(key string, value int) (int, bool) {
+			return 0, false
+		})
+		if ok {
+			t.Error("Expected delete intent to report the key absent")
+		}
+		if _, found := m.Load("count"); found {
+			t.Error("Expected delete intent to remove the key")
+		}
+	})
+
+	t.Run("Compute", func(t *testing.T) {
+		m := maps.NewUnorderedMap[string, int]()
+		increment := func(key string, value int, loaded bool) (int, bool) {
+			if !loaded {
+				return 1, true
+			}
+			return value + 1, true
+		}
+
+		m.Compute("hits", increment)
+		m.Compute("hits", increment)
+		if value, _ := m.Load("hits"); value != 2 {
+			t.Errorf("Expected hits=2, got %d", value)
+		}
+
+		value, ok := m.Compute("hits", func(key string, value int, loaded bool) (int, bool) {
+			if !loaded {
+				t.Error("Expected loaded=true for existing key")
+			}
+			return 0, false
+		})
+		if ok || value != 0 {
+			t.Errorf("Expected (0, false) after delete intent, got (%d, %v)", value, ok)
+		}
+		if _, found := m.Load("hits"); found {
+			t.Error("Expected Compute delete intent to remove the key")
+		}
+
+		// Delete intent on a missing key is a no-op.
+		m.Compute("missing", func(key string, value int, loaded bool) (int, bool) {
+			return 0, false
+		})
+		if m.Len() != 0 {
+			t.Errorf("Expected empty map, got length %d", m.Len())
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	sum := func(_ string, a, b int) int { return a + b }
+	overwrite := func(_ string, _, b int) int { return b }
+
+	t.Run("Sum", func(t *testing.T) {
+		m := maps.NewUnorderedMap[string, int]()
+		m.Store("a", 1)
+		m.Store("b", 2)
+
+		other := maps.NewUnorderedMap[string, int]()
+		other.Store("b", 10)
+		other.Store("c", 5)
+
+		m.Merge(other, sum)
+
+		expected := map[string]int{"a": 1, "b": 12, "c": 5}
+		for key, want := range expected {
+			if got, _ := m.Load(key); got != want {
+				t.Errorf("Key %s: expected %d, got %d", key, want, got)
+			}
+		}
+		if m.Len() != len(expected) {
+			t.Errorf("Expected length %d, got %d", len(expected), m.Len())
+		}
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		m := maps.NewUnorderedMap[string, int]()
+		m.Store("a", 1)
+
+		other := maps.NewUnorderedMap[string, int]()
+		other.Store("a", 100)
+
+		m.Merge(other, overwrite)
+		if got, _ := m.Load("a"); got != 100 {
+			t.Errorf("Expected overwritten value 100, got %d", got)
+		}
+	})
+
+	t.Run("ResolverOnlyForConflicts", func(t *testing.T) {
+		m := maps.NewUnorderedMap[string, int]()
+		m.Store("a", 1)
+
+		other := maps.NewUnorderedMap[string, int]()
+		other.Store("a", 2)
+		other.Store("b", 3)
+
+		var resolved []string
+		m.Merge(other, func(key string, a, b int) int {
+			resolved = append(resolved, key)
+			return a
+		})
+		if !slices.Equal(resolved, []string{"a"}) {
+			t.Errorf("Expected resolver to run only for [a], got %v", resolved)
+		}
+	})
+
+	t.Run("OrderedMapOrdering", func(t *testing.T) {
+		m := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"x", "y", "z"} {
+			m.Store(key, i)
+		}
+
+		other := maps.NewOrderedMap[string, int]()
+		other.Store("new1", 1)
+		other.Store("x", 10)
+		other.Store("new2", 2)
+
+		m.Merge(other, sum)
+
+		var keys []string
+		var values []int
+		m.Range(func(key string, value int) bool {
+			keys = append(keys, key)
+			values = append(values, value)
+			return true
+		})
+		if !slices.Equal(keys, []string{"x", "y", "z", "new1", "new2"}) {
+			t.Errorf("Expected existing keys in place and new keys appended, got %v", keys)
+		}
+		if !slices.Equal(values, []int{10, 1, 2, 1, 2}) {
+			t.Errorf("Expected values [10 1 2 1 2], got %v", values)
+		}
+	})
+}
+
+func TestSliceAccessors(t *testing.T) {
+	t.Run("OrderedMap", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"c", "a", "b"} {
+			om.Store(key, i)
+		}
+
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"c", "a", "b"}) {
+			t.Errorf("Expected keys in insertion order, got %v", keys)
+		}
+		if values := om.ValuesSlice(); !slices.Equal(values, []int{0, 1, 2}) {
+			t.Errorf("Expected values in insertion order, got %v", values)
+		}
+		expected := []maps.Pair[string, int]{{"c", 0}, {"a", 1}, {"b", 2}}
+		if entries := om.EntriesSlice(); !slices.Equal(entries, expected) {
+			t.Errorf("Expected entries %v, got %v", expected, entries)
+		}
+	})
+
+	t.Run("UnorderedMap", func(t *testing.T) {
+		um := maps.NewUnorderedMap[int, int]()
+		for i := 0; i < 20; i++ {
+			um.Store(i, i*2)
+		}
+
+		keys := um.KeysSlice()
+		values := um.ValuesSlice()
+		entries := um.EntriesSlice()
+		if len(keys) != um.Len() || len(values) != um.Len() || len(entries) != um.Len() {
+			t.Errorf("Expected slices of length %d, got %d, %d, %d", um.Len(), len(keys), len(values), len(entries))
+		}
+		slices.Sort(keys)
+		for i, key := range keys {
+			if key != i {
+				t.Errorf("Expected sorted key %d at position %d, got %d", i, i, key)
+			}
+		}
+		for _, e := range entries {
+			if e.Value != e.Key*2 {
+				t.Errorf("Entry %v: expected value %d", e, e.Key*2)
+			}
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		um := maps.NewUnorderedMap[string, int]()
+		if len(um.KeysSlice()) != 0 || len(um.ValuesSlice()) != 0 || len(um.EntriesSlice()) != 0 {
+			t.Error("Expected empty slices for an empty map")
+		}
+	})
+}
+
+func TestToGoMap(t *testing.T) {
+	t.Run("UnorderedMap", func(t *testing.T) {
+		um := maps.NewUnorderedMap[string, int]()
+		um.Store("a", 1)
+		um.Store("b", 2)
+
+		gm := um.ToGoMap()
+		if len(gm) != 2 || gm["a"] != 1 || gm["b"] != 2 {
+			t.Errorf("Expected map[a:1 b:2], got %v", gm)
+		}
+
+		gm["a"] = 100
+		gm["c"] = 3
+		delete(gm, "b")
+		if value, _ := um.Load("a"); value != 1 {
+			t.Errorf("Expected container a=1 after mutating the copy, got %d", value)
+		}
+		if um.Len() != 2 {
+			t.Errorf("Expected container length 2, got %d", um.Len())
+		}
+	})
+
+	t.Run("OrderedMap", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.Store("z", 26)
+		om.Store("a", 1)
+
+		gm := om.ToGoMap()
+		if len(gm) != 2 || gm["z"] != 26 || gm["a"] != 1 {
+			t.Errorf("Expected map[a:1 z:26], got %v", gm)
+		}
+
+		gm["z"] = 0
+		if value, _ := om.Load("z"); value != 26 {
+			t.Errorf("Expected container z=26 after mutating the copy, got %d", value)
+		}
+	})
+
+	t.Run("PackageHelper", func(t *testing.T) {
+		sm := maps.NewSortedMap[int, string](func(a, b int) bool { return a < b })
+		sm.Store(2, "two")
+		sm.Store(1, "one")
+
+		gm := maps.ToGoMap[int, string](sm)
+		if len(gm) != 2 || gm[1] != "one" || gm[2] != "two" {
+			t.Errorf("Expected map[1:one 2:two], got %v", gm)
+		}
+	})
+}
+
+func TestContains(t *testing.T) {
+	m := maps.NewOrderedMap[string, int]()
+	m.Store("one", 1)
+	m.Store("two", 2)
+	m.Store("three", 3)
+
+	t.Run("ContainsKey", func(t *testing.T) {
+		if !m.ContainsKey("two") {
+			t.Error("Expected ContainsKey(two) to be true")
+		}
+		if m.ContainsKey("four") {
+			t.Error("Expected ContainsKey(four) to be false")
+		}
+	})
+
+	t.Run("ContainsValue", func(t *testing.T) {
+		if !m.ContainsValue(3) {
+			t.Error("Expected ContainsValue(3) to be true")
+		}
+		if m.ContainsValue(4) {
+			t.Error("Expected ContainsValue(4) to be false")
+		}
+	})
+
+	t.Run("ContainsValueFuncStopsEarly", func(t *testing.T) {
+		var checked []int
+		found := m.ContainsValueFunc(func(value int) bool {
+			checked = append(checked, value)
+			return value%2 == 0
+		})
+		if !found {
+			t.Error("Expected an even value to be found")
+		}
+		if !slices.Equal(checked, []int{1, 2}) {
+			t.Errorf("Expected search to stop at the first match, checked %v", checked)
+		}
+
+		if m.ContainsValueFunc(func(value int) bool { return value > 10 }) {
+			t.Error("Expected no value greater than 10")
+		}
+	})
+
+	t.Run("ContainsValueFuncSlices", func(t *testing.T) {
+		um := maps.NewUnorderedMap[string, []int]()
+		um.Store("evens", []int{2, 4})
+
+		if !um.ContainsValueFunc(func(v []int) bool { return slices.Equal(v, []int{2, 4}) }) {
+			t.Error("Expected predicate to match slice value")
+		}
+	})
+}
+
+func TestPredicates(t *testing.T) {
+	newMap := func() *maps.OrderedMap[string, int] {
+		om := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"a", "b", "c", "d", "e"} {
+			om.Store(key, i+1)
+		}
+		return om
+	}
+	isEven := func(_ string, v int) bool { return v%2 == 0 }
+
+	t.Run("Count", func(t *testing.T) {
+		if n := newMap().Count(isEven); n != 2 {
+			t.Errorf("Expected 2 even values, got %d", n)
+		}
+		if n := maps.NewOrderedMap[string, int]().Count(isEven); n != 0 {
+			t.Errorf("Expected 0 for an empty map, got %d", n)
+		}
+	})
+
+	t.Run("AnyStopsAtFirstMatch", func(t *testing.T) {
+		var visited []string
+		found := newMap().Any(func(k string, v int) bool {
+			visited = append(visited, k)
+			return v%2 == 0
+		})
+		if !found {
+			t.Error("Expected Any to find an even value")
+		}
+		if !slices.Equal(visited, []string{"a", "b"}) {
+			t.Errorf("Expected Any to stop after [a b], visited %v", visited)
+		}
+		if newMap().Any(func(_ string, v int) bool { return v > 10 }) {
+			t.Error("Expected Any to be false when nothing matches")
+		}
+	})
+
+	t.Run("EveryStopsAtFirstFailure", func(t *testing.T) {
+		var visited []string
+		all := newMap().Every(func(k string, v int) bool {
+			visited = append(visited, k)
+			return v < 3
+		})
+		if all {
+			t.Error("Expected Every to fail")
+		}
+		if !slices.Equal(visited, []string{"a", "b", "c"}) {
+			t.Errorf("Expected Every to stop after [a b c], visited %v", visited)
+		}
+		if !newMap().Every(func(_ string, v int) bool { return v > 0 }) {
+			t.Error("Expected Every to hold for all positive values")
+		}
+		if !maps.NewOrderedMap[string, int]().Every(isEven) {
+			t.Error("Expected Every to be true for an empty map")
+		}
+	})
+
+	t.Run("FindFirstInInsertionOrder", func(t *testing.T) {
+		key, value, found := newMap().Find(isEven)
+		if !found || key != "b" || value != 2 {
+			t.Errorf("Expected (b, 2, true), got (%s, %d, %v)", key, value, found)
+		}
+
+		key, value, found = newMap().Find(func(_ string, v int) bool { return v > 10 })
+		if found || key != "" || value != 0 {
+			t.Errorf("Expected zero values and false, got (%q, %d, %v)", key, value, found)
+		}
+	})
+}
+
+func TestFromAbstractMapsGrows(t *testing.T) {
+	a := maps.FromGoMaps(maps.NewOrderedMap[string, int](), map[string]int{"a": 1, "b": 2})
+	b := maps.FromGoMaps(maps.NewOrderedMap[string, int](), map[string]int{"b": 20, "c": 3})
+
+	m := maps.FromAbstractMaps[string, int](maps.NewUnorderedMap[string, int](), a, b)
+
+	expected := map[string]int{"a": 1, "b": 20, "c": 3}
+	if m.Len() != len(expected) {
+		t.Errorf("Expected length %d, got %d", len(expected), m.Len())
+	}
+	for key, value := range expected {
+		if got, ok := m.Load(key); !ok || got != value {
+			t.Errorf("Expected %s=%d, got %d (ok=%v)", key, value, got, ok)
+		}
+	}
+}
+
+// noGrow hides the Grow method of the wrapped map so FromGoMaps cannot
+// pre-size it.
+type noGrow[K comparable, V any] struct {
+	maps.AbstractMap[K, V]
+}
+
+// BenchmarkFromGoMapsPresize imports a 500k-entry builtin map with and
+// without pre-sizing the destination.
+func BenchmarkFromGoMapsPresize(b *testing.B) {
+	const size = 500_000
+	src := make(map[int]int, size)
+	for i := 0; i < size; i++ {
+		src[i] = i
+	}
+
+	b.Run("NoGrow", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			maps.FromGoMaps(noGrow[int, int]{maps.NewUnorderedMap[int, int]()}, src)
+		}
+	})
+
+	b.Run("Grow", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			maps.FromGoMaps(maps.NewUnorderedMap[int, int](), src)
+		}
+	})
+}
+
+func TestPop(t *testing.T) {
+	entries := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+
+	t.Run("OrderedMapFront", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		for _, key := range []string{"c", "a", "b"} {
+			om.Store(key, entries[key])
+		}
+		var popped []string
+		for {
+			key, value, ok := om.Pop()
+			if !ok {
+				break
+			}
+			if value != entries[key] {
+				t.Errorf("Expected %s=%d, got %d", key, entries[key], value)
+			}
+			popped = append(popped, key)
+		}
+		if !slices.Equal(popped, []string{"c", "a", "b"}) {
+			t.Errorf("Expected to pop in insertion order [c a b], got %v", popped)
+		}
+	})
+
+	t.Run("UnorderedMapExactlyOnce", func(t *testing.T) {
+		um := maps.FromGoMaps(maps.NewUnorderedMap[string, int](), entries)
+		seen := map[string]int{}
+		for um.Len() > 0 {
+			key, value, ok := um.Pop()
+			if !ok {
+				t.Fatal("Expected Pop to succeed on a non-empty map")
+			}
+			if value != entries[key] {
+				t.Errorf("Expected %s=%d, got %d", key, entries[key], value)
+			}
+			seen[key]++
+		}
+		for key := range entries {
+			if seen[key] != 1 {
+				t.Errorf("Expected %s to be popped once, got %d", key, seen[key])
+			}
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		key, value, ok := maps.NewUnorderedMap[string, int]().Pop()
+		if ok || key != "" || value != 0 {
+			t.Errorf("Expected zero values and ok=false, got (%q, %d, %v)", key, value, ok)
+		}
+	})
+}
+
+func TestLenThroughOverwriteAndDelete(t *testing.T) {
+	factories := map[string]func() maps.AbstractMap[string, int]{
+		"UnorderedMap": func() maps.AbstractMap[string, int] { return maps.NewUnorderedMap[string, int]() },
+		"OrderedMap":   func() maps.AbstractMap[string, int] { return maps.NewOrderedMap[string, int]() },
+		"SortedMap": func() maps.AbstractMap[string, int] {
+			return maps.NewSortedMap[string, int](func(a, b string) bool { return a < b })
+		},
+		"TrieMap":        func() maps.AbstractMap[string, int] { return maps.NewTrieMap[int]() },
+		"ShardedMap":     func() maps.AbstractMap[string, int] { return maps.NewShardedMap[string, int](4) },
+		"CopyOnWriteMap": func() maps.AbstractMap[string, int] { return maps.NewCopyOnWriteMap[string, int]() },
+	}
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			steps := []struct {
+				op       func()
+				expected int
+			}{
+				{func() { m.Store("a", 1) }, 1},
+				{func() { m.Store("b", 2) }, 2},
+				{func() { m.Store("a", 10) }, 2},
+				{func() { m.LoadOrStore("b", 20) }, 2},
+				{func() { m.Swap("c", 3) }, 3},
+				{func() { m.Delete("missing") }, 3},
+				{func() { m.Delete("a") }, 2},
+				{func() { m.LoadAndDelete("b") }, 1},
+				{func() { m.CompareAndDelete("c", 3) }, 0},
+			}
+			for i, step := range steps {
+				step.op()
+				if m.Len() != step.expected {
+					t.Fatalf("Step %d: expected length %d, got %d", i, step.expected, m.Len())
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLen compares an implementation's Len with counting the entries
+// through Range, which is what the removed default Len used to do.
+func BenchmarkLen(b *testing.B) {
+	const size = 1_000_000
+	om := maps.NewOrderedMapWithCapacity[int, int](size)
+	for i := 0; i < size; i++ {
+		om.Store(i, i)
+	}
+
+	b.Run("RangeCount", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			n := 0
+			for range om.Range {
+				n++
+			}
+			if n != size {
+				b.Fatalf("Expected %d entries, got %d", size, n)
+			}
+		}
+	})
+
+	b.Run("Len", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if om.Len() != size {
+				b.Fatalf("Expected %d entries, got %d", size, om.Len())
+			}
+		}
+	})
+}
+
+func TestReduce(t *testing.T) {
+	t.Run("Sum", func(t *testing.T) {
+		um := maps.FromGoMaps(maps.NewUnorderedMap[string, int](), map[string]int{"a": 1, "b": 2, "c": 3})
+		sum := maps.Reduce[string, int](um, 0, func(acc int, _ string, v int) int {
+			return acc + v
+		})
+		if sum != 6 {
+			t.Errorf("Expected sum 6, got %d", sum)
+		}
+	})
+
+	t.Run("OrderedConcatenation", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.Store("z", 1)
+		om.Store("a", 2)
+		om.Store("m", 3)
+		joined := maps.Reduce[string, int](om, "", func(acc string, k string, v int) string {
+			return acc + fmt.Sprintf("%s%d", k, v)
+		})
+		if joined != "z1a2m3" {
+			t.Errorf("Expected z1a2m3, got %s", joined)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		result := maps.Reduce[string, int](maps.NewOrderedMap[string, int](), 42, func(acc int, _ string, v int) int {
+			return acc + v
+		})
+		if result != 42 {
+			t.Errorf("Expected the initial value 42, got %d", result)
+		}
+	})
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(_ string, v int) bool { return v%2 == 0 }
+
+	t.Run("OrderedMapKeepsOrder", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"f", "e", "d", "c", "b", "a"} {
+			om.Store(key, i)
+		}
+
+		matched, unmatched := maps.Partition[string, int](om, isEven)
+
+		if _, ok := matched.(*maps.OrderedMap[string, int]); !ok {
+			t.Errorf("Expected matched to be an OrderedMap, got %T", matched)
+		}
+		if keys := matched.(*maps.OrderedMap[string, int]).KeysSlice(); !slices.Equal(keys, []string{"f", "d", "b"}) {
+			t.Errorf("Expected matched keys [f d b], got %v", keys)
+		}
+		if keys := unmatched.(*maps.OrderedMap[string, int]).KeysSlice(); !slices.Equal(keys, []string{"e", "c", "a"}) {
+			t.Errorf("Expected unmatched keys [e c a], got %v", keys)
+		}
+		if om.Len() != 6 {
+			t.Errorf("Expected source to be unchanged, got length %d", om.Len())
+		}
+	})
+
+	t.Run("ReconstructsKeySet", func(t *testing.T) {
+		src := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}
+		um := maps.FromGoMaps(maps.NewUnorderedMap[string, int](), src)
+
+		matched, unmatched := maps.Partition[string, int](um, isEven)
+
+		if _, ok := unmatched.(*maps.UnorderedMap[string, int]); !ok {
+			t.Errorf("Expected unmatched to be an UnorderedMap, got %T", unmatched)
+		}
+		if matched.Len()+unmatched.Len() != len(src) {
+			t.Errorf("Expected %d entries in total, got %d", len(src), matched.Len()+unmatched.Len())
+		}
+		for key, value := range src {
+			_, inMatched := matched.Load(key)
+			_, inUnmatched := unmatched.Load(key)
+			if inMatched == inUnmatched {
+				t.Errorf("Expected %s in exactly one result, matched=%v unmatched=%v", key, inMatched, inUnmatched)
+			}
+			if inMatched != (value%2 == 0) {
+				t.Errorf("Expected %s=%d to be partitioned by the predicate", key, value)
+			}
+		}
+	})
+}
+
+func TestAppendKeysValues(t *testing.T) {
+	om := maps.Of(maps.P("c", 3), maps.P("a", 1), maps.P("b", 2))
+
+	keys := om.AppendKeys([]string{"prefix"})
+	if !slices.Equal(keys, []string{"prefix", "c", "a", "b"}) {
+		t.Errorf("Expected [prefix c a b], got %v", keys)
+	}
+
+	values := om.AppendValues(nil)
+	if !slices.Equal(values, []int{3, 1, 2}) {
+		t.Errorf("Expected [3 1 2], got %v", values)
+	}
+
+	buf := make([]string, 0, 8)
+	reused := om.AppendKeys(buf[:0])
+	if &reused[0] != &buf[:1][0] {
+		t.Error("Expected AppendKeys to reuse a buffer with enough capacity")
+	}
+
+	if empty := maps.NewOrderedMap[string, int]().KeysSlice(); empty == nil || len(empty) != 0 {
+		t.Errorf("Expected an empty non-nil slice, got %#v", empty)
+	}
+}
+
+// BenchmarkAppendKeys compares collecting keys into a reused buffer with
+// allocating a fresh slice on every call.
+func BenchmarkAppendKeys(b *testing.B) {
+	om := maps.NewOrderedMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		om.Store(i, i)
+	}
+
+	b.Run("KeysSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = om.KeysSlice()
+		}
+	})
+
+	b.Run("AppendKeysReused", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []int
+		for i := 0; i < b.N; i++ {
+			buf = om.AppendKeys(buf[:0])
+		}
+	})
+}
+
+func TestInvert(t *testing.T) {
+	t.Run("Unique", func(t *testing.T) {
+		src := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 3))
+		inv, ok := maps.Invert[string, int](src).(*maps.OrderedMap[int, string])
+		if !ok {
+			t.Fatalf("Expected an OrderedMap for an ordered source")
+		}
+		if got := inv.KeysSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("Expected keys [1 2 3], got %v", got)
+		}
+		if got := inv.ValuesSlice(); !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("Expected values [a b c], got %v", got)
+		}
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		src := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 1))
+		inv := maps.Invert[string, int](src).(*maps.OrderedMap[int, string])
+		if inv.Len() != 2 {
+			t.Errorf("Expected 2 entries, got %d", inv.Len())
+		}
+		if got := inv.KeysSlice(); !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("Expected first-seen order [1 2], got %v", got)
+		}
+		if v, _ := inv.Load(1); v != "c" {
+			t.Errorf("Expected the last key to win, got %q", v)
+		}
+	})
+
+	t.Run("Unordered", func(t *testing.T) {
+		src := maps.NewUnorderedMap[string, int]()
+		src.Store("a", 1)
+		inv := maps.Invert[string, int](src)
+		if _, ok := inv.(*maps.UnorderedMap[int, string]); !ok {
+			t.Errorf("Expected an UnorderedMap for an unordered source, got %T", inv)
+		}
+	})
+
+	t.Run("Multi", func(t *testing.T) {
+		src := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 1))
+		inv := maps.InvertMulti[string, int](src)
+		if got, _ := inv.Load(1); !slices.Equal(got, []string{"a", "c"}) {
+			t.Errorf("Expected [a c] for 1, got %v", got)
+		}
+		if got, _ := inv.Load(2); !slices.Equal(got, []string{"b"}) {
+			t.Errorf("Expected [b] for 2, got %v", got)
+		}
+	})
+}
+
+func TestMapKeys(t *testing.T) {
+	t.Run("Ordered", func(t *testing.T) {
+		src := maps.Of(maps.P("b", 2), maps.P("a", 1), maps.P("c", 3))
+		upper, ok := maps.MapKeys(src, strings.ToUpper).(*maps.OrderedMap[string, int])
+		if !ok {
+			t.Fatalf("Expected an OrderedMap for an ordered source")
+		}
+		if got := upper.KeysSlice(); !slices.Equal(got, []string{"B", "A", "C"}) {
+			t.Errorf("Expected keys [B A C] in source order, got %v", got)
+		}
+		if got := upper.ValuesSlice(); !slices.Equal(got, []int{2, 1, 3}) {
+			t.Errorf("Expected values [2 1 3], got %v", got)
+		}
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		src := maps.Of(maps.P("apple", 1), maps.P("banana", 2), maps.P("avocado", 3))
+		byInitial := maps.MapKeys(src, func(key string) byte { return key[0] }).(*maps.OrderedMap[byte, int])
+		if got := byInitial.KeysSlice(); !slices.Equal(got, []byte{'a', 'b'}) {
+			t.Errorf("Expected first-seen order [a b], got %q", got)
+		}
+		if v, _ := byInitial.Load('a'); v != 3 {
+			t.Errorf("Expected the last colliding value 3 to win, got %d", v)
+		}
+	})
+
+	t.Run("Unordered", func(t *testing.T) {
+		src := maps.NewUnorderedMap[int, string]()
+		src.Store(1, "one")
+		dst := maps.MapKeys(src, func(key int) int { return -key })
+		if _, ok := dst.(*maps.UnorderedMap[int, string]); !ok {
+			t.Errorf("Expected an UnorderedMap for an unordered source, got %T", dst)
+		}
+		if v, _ := dst.Load(-1); v != "one" {
+			t.Errorf("Expected -1=one, got %q", v)
+		}
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	explode := func(team string, members []string) []maps.Pair[string, string] {
+		var pairs []maps.Pair[string, string]
+		for _, m := range members {
+			pairs = append(pairs, maps.P(m, team))
+		}
+		return pairs
+	}
+
+	t.Run("Ordered", func(t *testing.T) {
+		src := maps.Of(maps.P("red", []string{"ann", "bob"}), maps.P("blue", []string{}), maps.P("green", []string{"cy", "dee"}))
+		flat, ok := maps.FlatMap(src, explode).(*maps.OrderedMap[string, string])
+		if !ok {
+			t.Fatalf("Expected an OrderedMap for an ordered source")
+		}
+		if got := flat.KeysSlice(); !slices.Equal(got, []string{"ann", "bob", "cy", "dee"}) {
+			t.Errorf("Expected keys in source then result order, got %v", got)
+		}
+		if got := flat.ValuesSlice(); !slices.Equal(got, []string{"red", "red", "green", "green"}) {
+			t.Errorf("Expected values [red red green green], got %v", got)
+		}
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		src := maps.Of(maps.P("red", []string{"ann", "bob"}), maps.P("green", []string{"ann"}))
+		flat := maps.FlatMap(src, explode).(*maps.OrderedMap[string, string])
+		if got := flat.KeysSlice(); !slices.Equal(got, []string{"ann", "bob"}) {
+			t.Errorf("Expected first-seen order [ann bob], got %v", got)
+		}
+		if v, _ := flat.Load("ann"); v != "green" {
+			t.Errorf("Expected the later pair to win, got %q", v)
+		}
+	})
+
+	t.Run("Unordered", func(t *testing.T) {
+		src := maps.NewUnorderedMap[string, []string]()
+		src.Store("red", []string{"ann"})
+		flat := maps.FlatMap(src, explode)
+		if _, ok := flat.(*maps.UnorderedMap[string, string]); !ok {
+			t.Errorf("Expected an UnorderedMap for an unordered source, got %T", flat)
+		}
+	})
+}
+
+func TestSortedKeys(t *testing.T) {
+	um := maps.NewUnorderedMap[string, int]()
+	for i, k := range []string{"delta", "alpha", "charlie", "bravo"} {
+		um.Store(k, i)
+	}
+
+	t.Run("Ascending", func(t *testing.T) {
+		expected := []string{"alpha", "bravo", "charlie", "delta"}
+		if got := maps.SortedKeys[string, int](um); !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("Func", func(t *testing.T) {
+		expected := []string{"delta", "charlie", "bravo", "alpha"}
+		got := maps.SortedKeysFunc[string, int](um, func(a, b string) bool { return a > b })
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("RangeSorted", func(t *testing.T) {
+		var keys []string
+		maps.RangeSorted[string, int](um, func(key string, value int) bool {
+			if v, _ := um.Load(key); v != value {
+				t.Errorf("Expected value %d for %q, got %d", v, key, value)
+			}
+			keys = append(keys, key)
+			return true
+		})
+		expected := []string{"alpha", "bravo", "charlie", "delta"}
+		if !slices.Equal(keys, expected) {
+			t.Errorf("Expected %v, got %v", expected, keys)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if got := maps.SortedKeys[string, int](maps.NewUnorderedMap[string, int]()); len(got) != 0 {
+			t.Errorf("Expected no keys, got %v", got)
+		}
+	})
+}
+
+func TestIndex(t *testing.T) {
+	type record struct {
+		ID      string
+		Version int
+	}
+	records := []record{{"b", 1}, {"a", 1}, {"b", 2}, {"c", 1}}
+
+	t.Run("Collision", func(t *testing.T) {
+		idx := maps.Index(records, func(r record) string { return r.ID })
+		if idx.Len() != 3 {
+			t.Errorf("Expected 3 entries, got %d", idx.Len())
+		}
+		if r, _ := idx.Load("b"); r.Version != 2 {
+			t.Errorf("Expected the later record to win, got version %d", r.Version)
+		}
+	})
+
+	t.Run("Ordered", func(t *testing.T) {
+		idx := maps.IndexOrdered(records, func(r record) string { return r.ID })
+		if got := idx.KeysSlice(); !slices.Equal(got, []string{"b", "a", "c"}) {
+			t.Errorf("Expected keys in first-seen order [b a c], got %v", got)
+		}
+		if r, _ := idx.Load("b"); r.Version != 2 {
+			t.Errorf("Expected the later record to win, got version %d", r.Version)
+		}
+	})
+}
+
+func TestDrainTo(t *testing.T) {
+	t.Run("Ordered", func(t *testing.T) {
+		src := maps.Of(maps.P("c", 3), maps.P("a", 1), maps.P("b", 2))
+		dst := maps.Of(maps.P("z", 26))
+		src.DrainTo(dst)
+
+		if src.Len() != 0 {
+			t.Errorf("Expected source to be empty, got %d entries", src.Len())
+		}
+		if got := dst.KeysSlice(); !slices.Equal(got, []string{"z", "c", "a", "b"}) {
+			t.Errorf("Expected [z c a b], got %v", got)
+		}
+		if got := dst.ValuesSlice(); !slices.Equal(got, []int{26, 3, 1, 2}) {
+			t.Errorf("Expected [26 3 1 2], got %v", got)
+		}
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		src := maps.NewUnorderedMap[string, int]()
+		src.Store("a", 10)
+		src.Store("b", 20)
+		dst := maps.NewUnorderedMap[string, int]()
+		dst.Store("a", 1)
+		src.DrainTo(dst)
+
+		expected := map[string]int{"a": 10, "b": 20}
+		if got := dst.ToGoMap(); !equalGoMaps(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+		if src.Len() != 0 {
+			t.Errorf("Expected source to be empty, got %d entries", src.Len())
+		}
+	})
+}
+
+func TestCollectSeq(t *testing.T) {
+	// countdown yields n-1..0 paired with their squares.
+	countdown := func(n int) iter.Seq2[int, int] {
+		return func(yield func(int, int) bool) {
+			for i := n - 1; i >= 0; i-- {
+				if !yield(i, i*i) {
+					return
+				}
+			}
+		}
+	}
+
+	t.Run("Unordered", func(t *testing.T) {
+		um := maps.CollectSeq(countdown(5))
+		if um.Len() != 5 {
+			t.Errorf("Expected 5 entries, got %d", um.Len())
+		}
+		if v, _ := um.Load(3); v != 9 {
+			t.Errorf("Expected 9 for 3, got %d", v)
+		}
+	})
+
+	t.Run("Ordered", func(t *testing.T) {
+		om := maps.CollectSeqOrdered(countdown(5))
+		if got := om.KeysSlice(); !slices.Equal(got, []int{4, 3, 2, 1, 0}) {
+			t.Errorf("Expected sequence order [4 3 2 1 0], got %v", got)
+		}
+	})
+
+	t.Run("RepeatedKeys", func(t *testing.T) {
+		seq := func(yield func(string, int) bool) {
+			_ = yield("a", 1) && yield("b", 2) && yield("a", 3)
+		}
+		om := maps.CollectSeqOrdered[string, int](seq)
+		if got := om.KeysSlice(); !slices.Equal(got, []string{"a", "b"}) {
+			t.Errorf("Expected [a b], got %v", got)
+		}
+		if v, _ := om.Load("a"); v != 3 {
+			t.Errorf("Expected the last value 3 for a, got %d", v)
+		}
+	})
+}
+
+func TestDeleteAllWhere(t *testing.T) {
+	t.Run("DeleteAll", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 3), maps.P("d", 4))
+		om.DeleteAll("b", "missing", "d")
+		if got := om.KeysSlice(); !slices.Equal(got, []string{"a", "c"}) {
+			t.Errorf("Expected [a c], got %v", got)
+		}
+		om.DeleteAll()
+		if om.Len() != 2 {
+			t.Errorf("Expected no change for no keys, got %d entries", om.Len())
+		}
+	})
+
+	t.Run("DeleteWhere", func(t *testing.T) {
+		um := maps.NewUnorderedMap[int, int]()
+		for i := range 10 {
+			um.Store(i, i*i)
+		}
+		removed := um.DeleteWhere(func(key, value int) bool { return key%2 == 0 })
+		if removed != 5 {
+			t.Errorf("Expected 5 removals, got %d", removed)
+		}
+		for i := range 10 {
+			if _, ok := um.Load(i); ok != (i%2 == 1) {
+				t.Errorf("Expected key %d present=%v, got %v", i, i%2 == 1, ok)
+			}
+		}
+		if removed := um.DeleteWhere(func(int, int) bool { return false }); removed != 0 || um.Len() != 5 {
+			t.Errorf("Expected nothing removed, got %d with %d entries left", removed, um.Len())
+		}
+	})
+}
+
+func TestEntries(t *testing.T) {
+	om := maps.Of(maps.P("c", 3), maps.P("a", 1), maps.P("b", 2))
+	expected := []maps.Pair[string, int]{{Key: "c", Value: 3}, {Key: "a", Value: 1}, {Key: "b", Value: 2}}
+	if got := slices.Collect(om.Entries()); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	var first []maps.Pair[string, int]
+	for p := range om.Entries() {
+		first = append(first, p)
+		if len(first) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(first, expected[:2]) {
+		t.Errorf("Expected iteration to stop after %v, got %v", expected[:2], first)
+	}
+
+	if got := slices.Collect(maps.NewUnorderedMap[string, int]().Entries()); len(got) != 0 {
+		t.Errorf("Expected no entries, got %v", got)
+	}
+}
+
+func TestRetain(t *testing.T) {
+	t.Run("RetainAll", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 3), maps.P("d", 4))
+		removed := maps.RetainAll(om, "d", "b", "missing")
+		if removed != 2 {
+			t.Errorf("Expected 2 removals, got %d", removed)
+		}
+		if got := om.KeysSlice(); !slices.Equal(got, []string{"b", "d"}) {
+			t.Errorf("Expected survivors in insertion order [b d], got %v", got)
+		}
+		if removed := maps.RetainAll(om); removed != 2 || om.Len() != 0 {
+			t.Errorf("Expected retaining nothing to empty the map, got %d removals and %d left", removed, om.Len())
+		}
+	})
+
+	t.Run("RetainWhere", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 3), maps.P("d", 4))
+		om.RetainWhere(func(key string, value int) bool { return value%2 == 1 })
+		if got := om.KeysSlice(); !slices.Equal(got, []string{"a", "c"}) {
+			t.Errorf("Expected survivors in insertion order [a c], got %v", got)
+		}
+	})
+}
+
+func TestStoreIfAbsentOrPresent(t *testing.T) {
+	type storer interface {
+		maps.AbstractMap[string, int]
+		StoreIfAbsent(key string, value int) bool
+		StoreIfPresent(key string, value int) bool
+	}
+	factories := map[string]func() storer{
+		"OrderedMap":    func() storer { return maps.NewOrderedMap[string, int]() },
+		"ConcurrentMap": func() storer { return maps.NewConcurrentMap[string, int]() },
+		"ShardedMap":    func() storer { return maps.NewShardedMap[string, int](4) },
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			m.Store("present", 1)
+
+			if m.StoreIfAbsent("present", 2) {
+				t.Errorf("Expected StoreIfAbsent to refuse a present key")
+			}
+			if !m.StoreIfAbsent("absent", 3) {
+				t.Errorf("Expected StoreIfAbsent to store a missing key")
+			}
+			if !m.StoreIfPresent("present", 4) {
+				t.Errorf("Expected StoreIfPresent to update a present key")
+			}
+			if m.StoreIfPresent("missing", 5) {
+				t.Errorf("Expected StoreIfPresent to refuse a missing key")
+			}
+
+			if value, _ := m.Load("present"); value != 4 {
+				t.Errorf("Expected present=4, got %d", value)
+			}
+			if value, _ := m.Load("absent"); value != 3 {
+				t.Errorf("Expected absent=3, got %d", value)
+			}
+			if _, ok := m.Load("missing"); ok || m.Len() != 2 {
+				t.Errorf("Expected only 2 keys, got %d", m.Len())
+			}
+		})
+	}
+}
+
+func TestDistinctValues(t *testing.T) {
+	t.Run("Comparable", func(t *testing.T) {
+		om := maps.Of(maps.P("a", "x"), maps.P("b", "y"), maps.P("c", "x"), maps.P("d", "z"), maps.P("e", "y"))
+		if got := maps.DistinctValues[string, string](om); !slices.Equal(got, []string{"x", "y", "z"}) {
+			t.Errorf("Expected [x y z] in encounter order, got %v", got)
+		}
+		if got := maps.DistinctValues(maps.AbstractMap[string, string](maps.NewOrderedMap[string, string]())); len(got) != 0 {
+			t.Errorf("Expected no values for an empty map, got %v", got)
+		}
+	})
+
+	t.Run("Func", func(t *testing.T) {
+		om := maps.Of(maps.P(1, []int{1, 2}), maps.P(2, []int{3}), maps.P(3, []int{1, 2}))
+		got := maps.DistinctValuesFunc[int, []int](om, func(v []int) string { return fmt.Sprint(v) })
+		if len(got) != 2 || !slices.Equal(got[0], []int{1, 2}) || !slices.Equal(got[1], []int{3}) {
+			t.Errorf("Expected [[1 2] [3]], got %v", got)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package maps
+
+// BiMap implements AbstractMap as a bijection: every key maps to a unique
+// value, so entries can be looked up from either side.
+// Storing a value that is already bound to a different key removes that
+// older binding, and storing a new value for an existing key releases the
+// key's previous value, keeping the mapping one-to-one at all times.
+type BiMap[K comparable, V comparable] struct {
+	*DefaultAbstractMap[K, V]
+	forward map[K]V
+	reverse map[V]K
+}
+
+// NewBiMap creates an empty BiMap.
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return newBiMap(make(map[K]V), make(map[V]K))
+}
+
+func newBiMap[K comparable, V comparable](forward map[K]V, reverse map[V]K) *BiMap[K, V] {
+	bm := &BiMap[K, V]{
+		forward: forward,
+		reverse: reverse,
+	}
+	bm.DefaultAbstractMap = NewDefaultAbstractMap(bm)
+	return bm
+}
+
+func (bm *BiMap[K, V]) Clear() {
+	bm.checkMutable("Clear")
+	bm.cleared(bm.Range, func() {
+		clear(bm.forward)
+		clear(bm.reverse)
+	})
+}
+
+func (bm *BiMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := NewBiMap[K, V]()
+	for k, v := range bm.forward {
+		clone.forward[k] = v
+		clone.reverse[v] = k
+	}
+	return clone
+}
+
+func (bm *BiMap[K, V]) Delete(key K) {
+	bm.checkMutable("Delete")
+	if value, ok := bm.forward[key]; ok {
+		delete(bm.forward, key)
+		delete(bm.reverse, value)
+		bm.deleted(key, value)
+	}
+}
+
+// Inverse returns a view of the map with keys and values swapped.
+// The view shares storage and the frozen state with the receiver, so
+// changes made through either one are visible in both, and freezing either
+// freezes both. Observers registered on one view are not notified of changes
+// made through the other.
+func (bm *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	inverse := newBiMap(bm.reverse, bm.forward)
+	inverse.frozen = bm.frozen
+	return inverse
+}
+
+func (bm *BiMap[K, V]) Len() int {
+	return len(bm.forward)
+}
+
+func (bm *BiMap[K, V]) Load(key K) (value V, ok bool) {
+	value, ok = bm.forward[key]
+	return value, ok
+}
+
+// LoadByValue returns the key bound to value.
+func (bm *BiMap[K, V]) LoadByValue(value V) (key K, ok bool) {
+	key, ok = bm.reverse[value]
+	return key, ok
+}
+
+func (bm *BiMap[K, V]) Range(f func(key K, value V) bool) {
+	for k, v := range bm.forward {
+		if !f(k, v) {
+			break
+		}
+	}
+}
+
+// Store binds key to value. Any existing binding of value to another key is
+// removed, as is the key's previous value.
+func (bm *BiMap[K, V]) Store(key K, value V) {
+	bm.checkMutable("Store")
+	if old, ok := bm.forward[key]; ok {
+		delete(bm.reverse, old)
+	}
+	other, bound := bm.reverse[value]
+	if bound {
+		delete(bm.forward, other)
+	}
+	bm.forward[key] = value
+	bm.reverse[value] = key
+	if bound && other != key {
+		bm.deleted(other, value)
+	}
+	bm.stored(key, value)
+}
@@ -0,0 +1,90 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestBiMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewBiMap[string, string]()
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestBiMapBijection(t *testing.T) {
+	t.Run("LoadByValue", func(t *testing.T) {
+		bm := maps.NewBiMap[string, int]()
+		bm.Store("one", 1)
+		bm.Store("two", 2)
+
+		if key, ok := bm.LoadByValue(2); !ok || key != "two" {
+			t.Errorf("Expected key two for value 2, got %q (ok=%v)", key, ok)
+		}
+		if _, ok := bm.LoadByValue(3); ok {
+			t.Error("Expected no key for unbound value 3")
+		}
+	})
+
+	t.Run("ValueConflictRemovesOldKey", func(t *testing.T) {
+		bm := maps.NewBiMap[string, int]()
+		bm.Store("first", 1)
+		bm.Store("second", 1)
+
+		if _, ok := bm.Load("first"); ok {
+			t.Error("Expected first to be unbound after its value moved to second")
+		}
+		if key, _ := bm.LoadByValue(1); key != "second" {
+			t.Errorf("Expected value 1 bound to second, got %q", key)
+		}
+		if bm.Len() != 1 {
+			t.Errorf("Expected length 1, got %d", bm.Len())
+		}
+	})
+
+	t.Run("KeyUpdateReleasesOldValue", func(t *testing.T) {
+		bm := maps.NewBiMap[string, int]()
+		bm.Store("key", 1)
+		bm.Store("key", 2)
+
+		if _, ok := bm.LoadByValue(1); ok {
+			t.Error("Expected old value 1 to be released")
+		}
+		if key, ok := bm.LoadByValue(2); !ok || key != "key" {
+			t.Errorf("Expected value 2 bound to key, got %q (ok=%v)", key, ok)
+		}
+	})
+
+	t.Run("InverseConsistentAfterDelete", func(t *testing.T) {
+		bm := maps.NewBiMap[string, int]()
+		bm.Store("a", 1)
+		bm.Store("b", 2)
+		inv := bm.Inverse()
+
+		bm.Delete("a")
+		if _, ok := inv.Load(1); ok {
+			t.Error("Expected inverse to drop value 1 after deleting a")
+		}
+		if inv.Len() != 1 {
+			t.Errorf("Expected inverse length 1, got %d", inv.Len())
+		}
+
+		inv.Store(3, "c")
+		if value, ok := bm.Load("c"); !ok || value != 3 {
+			t.Errorf("Expected store through inverse to be visible, got %d (ok=%v)", value, ok)
+		}
+
+		inv.Delete(2)
+		if _, ok := bm.Load("b"); ok {
+			t.Error("Expected delete through inverse to remove b")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package maps
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry is a cached loader result together with its expiry time.
+type cacheEntry[V any] struct {
+	value   V
+	err     error
+	expires time.Time
+}
+
+// Cache is a concurrency-safe read-through cache.
+// Concurrent GetOrLoad calls for the same key share a single loader
+// invocation, successful results are cached for the caller-supplied TTL,
+// and failures are cached for the cache's negative TTL so that a failing
+// backend is not hammered by retries.
+type Cache[K comparable, V any] struct {
+	mu          sync.Mutex
+	entries     map[K]cacheEntry[V]
+	flight      flightGroup[K, V]
+	negativeTTL time.Duration
+	clock       Clock
+}
+
+// NewCache creates an empty Cache that remembers loader errors for negativeTTL.
+// A negativeTTL of zero or less disables negative caching.
+func NewCache[K comparable, V any](negativeTTL time.Duration) *Cache[K, V] {
+	return NewCacheWithClock[K, V](negativeTTL, systemClock{})
+}
+
+// NewCacheWithClock creates an empty Cache that reads time from clock.
+func NewCacheWithClock[K comparable, V any](negativeTTL time.Duration, clock Clock) *Cache[K, V] {
+	return &Cache[K, V]{
+		entries:     make(map[K]cacheEntry[V]),
+		negativeTTL: negativeTTL,
+		clock:       clock,
+	}
+}
+
+// GetOrLoad returns the cached result for key, calling loader to produce it
+// when there is no live entry. Only one loader runs per key at a time; other
+// callers wait for it and receive the same value and error.
+// A successful result is cached for ttl and an error for the negative TTL;
+// a non-positive duration means the result is not cached.
+func (c *Cache[K, V]) GetOrLoad(key K, ttl time.Duration, loader func(K) (V, error)) (V, error) {
+	if e, ok := c.lookup(key); ok {
+		return e.value, e.err
+	}
+	return c.flight.do(key, func() (V, error) {
+		// A flight that finished just before ours may already have filled the entry.
+		if e, ok := c.lookup(key); ok {
+			return e.value, e.err
+		}
+		value, err := loader(key)
+		if err != nil {
+			ttl = c.negativeTTL
+		}
+		if ttl > 0 {
+			c.mu.Lock()
+			c.entries[key] = cacheEntry[V]{value: value, err: err, expires: c.clock.Now().Add(ttl)}
+			c.mu.Unlock()
+		}
+		return value, err
+	})
+}
+
+// Invalidate drops any cached result for key.
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// lookup returns the live entry for key, discarding it if it has expired.
+func (c *Cache[K, V]) lookup(key K) (cacheEntry[V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return e, false
+	}
+	if !c.clock.Now().Before(e.expires) {
+		delete(c.entries, key)
+		return cacheEntry[V]{}, false
+	}
+	return e, true
+}
@@ -0,0 +1,246 @@
+package maps_test
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/13770129/containers/maps"
+)
+
+// fakeClock is a manually advanced maps.TickerClock for deterministic
+// expiry tests. Its tickers fire only when Advance moves past their next
+// tick.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers map[*fakeTicker]struct{}
+}
+
+type fakeTicker struct {
+	c        chan time.Time
+	interval time.Duration
+	next     time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d. Like time.Ticker, a ticker whose
+// channel is still full when it is due drops the tick.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for t := range c.tickers {
+		for ; !t.next.After(c.now); t.next = t.next.Add(t.interval) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+		}
+	}
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{c: make(chan time.Time, 1), interval: d, next: c.now.Add(d)}
+	if c.tickers == nil {
+		c.tickers = map[*fakeTicker]struct{}{}
+	}
+	c.tickers[t] = struct{}{}
+	return t.c, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.tickers, t)
+	}
+}
+
+// Tickers returns the number of running tickers.
+func (c *fakeClock) Tickers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.tickers)
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	t.Run("ConcurrentCallersShareOneLoad", func(t *testing.T) {
+		clock := newFakeClock()
+		c := maps.NewCacheWithClock[string, int](time.Second, clock)
+
+		var calls atomic.Int32
+		started := make(chan struct{})
+		release := make(chan struct{})
+		loader := func(key string) (int, error) {
+			if calls.Add(1) == 1 {
+				close(started)
+			}
+			<-release
+			return len(key), nil
+		}
+
+		const workers = 20
+		var wg sync.WaitGroup
+		results := make([]int, workers)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				value, err := c.GetOrLoad("hello", time.Minute, loader)
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				results[w] = value
+			}(w)
+		}
+
+		<-started
+		for maps.CacheWaiters(c, "hello") < workers-1 {
+			runtime.Gosched() // let the other callers join the flight
+		}
+		close(release)
+		wg.Wait()
+
+		if n := calls.Load(); n != 1 {
+			t.Errorf("Expected loader to run once, ran %d times", n)
+		}
+		for w, value := range results {
+			if value != 5 {
+				t.Errorf("Worker %d: expected value 5, got %d", w, value)
+			}
+		}
+	})
+
+	t.Run("PanicReachesWaiters", func(t *testing.T) {
+		c := maps.NewCacheWithClock[string, int](time.Second, newFakeClock())
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		panicking := func(string) (int, error) {
+			close(started)
+			<-release
+			panic("backend exploded")
+		}
+		recovered := make(chan any, 1)
+		go func() {
+			defer func() { recovered <- recover() }()
+			c.GetOrLoad("key", time.Minute, panicking)
+		}()
+		<-started
+
+		const waiters = 5
+		var wg sync.WaitGroup
+		errs := make([]error, waiters)
+		for w := range waiters {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[w] = c.GetOrLoad("key", time.Minute, func(string) (int, error) { return 0, nil })
+			}()
+		}
+		for maps.CacheWaiters(c, "key") < waiters {
+			runtime.Gosched() // let the waiters join the flight
+		}
+		close(release)
+		wg.Wait()
+
+		if r := <-recovered; r != "backend exploded" {
+			t.Errorf("Expected the loading caller to panic, got %v", r)
+		}
+		for w, err := range errs {
+			if err == nil || !strings.Contains(err.Error(), "backend exploded") {
+				t.Errorf("Waiter %d: expected a panic error, got %v", w, err)
+			}
+		}
+		if value, err := c.GetOrLoad("key", time.Minute, func(string) (int, error) { return 1, nil }); err != nil || value != 1 {
+			t.Errorf("Expected a panicked load not to be cached, got %d, %v", value, err)
+		}
+	})
+
+	t.Run("SuccessCachedForTTL", func(t *testing.T) {
+		clock := newFakeClock()
+		c := maps.NewCacheWithClock[string, int](time.Second, clock)
+
+		calls := 0
+		loader := func(key string) (int, error) {
+			calls++
+			return calls, nil
+		}
+
+		if value, _ := c.GetOrLoad("key", time.Minute, loader); value != 1 {
+			t.Errorf("Expected first load to return 1, got %d", value)
+		}
+
+		clock.Advance(59 * time.Second)
+		if value, _ := c.GetOrLoad("key", time.Minute, loader); value != 1 {
+			t.Errorf("Expected cached value 1 before expiry, got %d", value)
+		}
+
+		clock.Advance(time.Second)
+		if value, _ := c.GetOrLoad("key", time.Minute, loader); value != 2 {
+			t.Errorf("Expected reload to return 2 after expiry, got %d", value)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 loader calls, got %d", calls)
+		}
+	})
+
+	t.Run("ErrorsCachedForNegativeTTL", func(t *testing.T) {
+		clock := newFakeClock()
+		c := maps.NewCacheWithClock[string, int](5*time.Second, clock)
+
+		errBackend := errors.New("backend down")
+		calls := 0
+		loader := func(key string) (int, error) {
+			calls++
+			return 0, errBackend
+		}
+
+		if _, err := c.GetOrLoad("key", time.Minute, loader); !errors.Is(err, errBackend) {
+			t.Errorf("Expected backend error, got %v", err)
+		}
+
+		clock.Advance(4 * time.Second)
+		if _, err := c.GetOrLoad("key", time.Minute, loader); !errors.Is(err, errBackend) {
+			t.Errorf("Expected cached backend error, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected cached error to suppress reload, got %d calls", calls)
+		}
+
+		// The negative TTL is much shorter than the success TTL.
+		clock.Advance(time.Second)
+		c.GetOrLoad("key", time.Minute, loader)
+		if calls != 2 {
+			t.Errorf("Expected reload after negative TTL, got %d calls", calls)
+		}
+	})
+
+	t.Run("Invalidate", func(t *testing.T) {
+		c := maps.NewCache[string, int](0)
+
+		calls := 0
+		loader := func(key string) (int, error) {
+			calls++
+			return calls, nil
+		}
+
+		c.GetOrLoad("key", time.Hour, loader)
+		c.Invalidate("key")
+		if value, _ := c.GetOrLoad("key", time.Hour, loader); value != 2 {
+			t.Errorf("Expected reload after Invalidate to return 2, got %d", value)
+		}
+	})
+}
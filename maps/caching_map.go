@@ -0,0 +1,60 @@
+package maps
+
+import "time"
+
+// CachingMap is a TTLMap that fills itself: Get, and Load through it, call
+// the loader for a key that is missing or expired and store the result for
+// the TTL the loader returns. Concurrent loads of the same key share one
+// loader call. Loader errors are returned to every caller sharing that call
+// but are not cached, so the next Get retries.
+// All other TTLMap methods, including Store and Delete, work as usual.
+type CachingMap[K comparable, V any] struct {
+	*TTLMap[K, V]
+	flight flightGroup[K, V]
+	loader func(key K) (V, time.Duration, error)
+}
+
+// NewCachingMap creates an empty CachingMap that loads values with loader.
+// A TTL of zero or less returned by loader caches the value until it is
+// deleted or replaced.
+func NewCachingMap[K comparable, V any](loader func(key K) (V, time.Duration, error)) *CachingMap[K, V] {
+	return NewCachingMapWithClock(loader, systemClock{})
+}
+
+// NewCachingMapWithClock creates an empty CachingMap that reads time from clock.
+func NewCachingMapWithClock[K comparable, V any](loader func(key K) (V, time.Duration, error), clock Clock) *CachingMap[K, V] {
+	return &CachingMap[K, V]{
+		TTLMap: NewTTLMapWithClock[K, V](0, clock),
+		loader: loader,
+	}
+}
+
+// Get returns the live value for key, loading and storing it first if it is
+// missing or expired.
+func (cm *CachingMap[K, V]) Get(key K) (V, error) {
+	if value, ok := cm.TTLMap.Load(key); ok {
+		return value, nil
+	}
+	return cm.flight.do(key, func() (V, error) {
+		// A flight that finished just before ours may already have stored the value.
+		if value, ok := cm.TTLMap.Load(key); ok {
+			return value, nil
+		}
+		value, ttl, err := cm.loader(key)
+		if err != nil {
+			return value, err
+		}
+		cm.StoreWithTTL(key, value, ttl)
+		return value, nil
+	})
+}
+
+// Load is Get with a loader error reported as the key being missing.
+func (cm *CachingMap[K, V]) Load(key K) (value V, ok bool) {
+	value, err := cm.Get(key)
+	if err != nil {
+		var zero V
+		return zero, false
+	}
+	return value, true
+}
@@ -0,0 +1,112 @@
+package maps_test
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestCachingMap(t *testing.T) {
+	t.Run("MissLoadsThenHits", func(t *testing.T) {
+		calls := 0
+		cm := maps.NewCachingMapWithClock(func(key string) (int, time.Duration, error) {
+			calls++
+			return len(key), time.Minute, nil
+		}, newFakeClock())
+
+		if value, err := cm.Get("hello"); err != nil || value != 5 {
+			t.Errorf("Expected a miss to load 5, got %d (err=%v)", value, err)
+		}
+		if value, ok := cm.Load("hello"); !ok || value != 5 {
+			t.Errorf("Expected a hit for 5, got %d (ok=%v)", value, ok)
+		}
+		if calls != 1 {
+			t.Errorf("Expected loader to run once, ran %d times", calls)
+		}
+	})
+
+	t.Run("ExpiryReloads", func(t *testing.T) {
+		clock := newFakeClock()
+		calls := 0
+		cm := maps.NewCachingMapWithClock(func(key string) (int, time.Duration, error) {
+			calls++
+			return calls, time.Minute, nil
+		}, clock)
+
+		cm.Get("key")
+		clock.Advance(59 * time.Second)
+		if value, _ := cm.Get("key"); value != 1 {
+			t.Errorf("Expected cached 1 before expiry, got %d", value)
+		}
+		clock.Advance(time.Second)
+		if value, _ := cm.Get("key"); value != 2 {
+			t.Errorf("Expected reload to return 2 after expiry, got %d", value)
+		}
+	})
+
+	t.Run("ErrorsNotCached", func(t *testing.T) {
+		fail := errors.New("backend down")
+		calls := 0
+		cm := maps.NewCachingMapWithClock(func(key string) (int, time.Duration, error) {
+			calls++
+			if calls == 1 {
+				return 0, time.Minute, fail
+			}
+			return 42, time.Minute, nil
+		}, newFakeClock())
+
+		if _, err := cm.Get("key"); !errors.Is(err, fail) {
+			t.Errorf("Expected the loader error, got %v", err)
+		}
+		if cm.Len() != 0 {
+			t.Errorf("Expected nothing cached after an error, got length %d", cm.Len())
+		}
+		if value, ok := cm.Load("key"); !ok || value != 42 {
+			t.Errorf("Expected retry to load 42, got %d (ok=%v)", value, ok)
+		}
+	})
+
+	t.Run("ConcurrentLoadsCoalesce", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		cm := maps.NewCachingMap(func(key string) (int, time.Duration, error) {
+			calls.Add(1)
+			<-release
+			return len(key), time.Hour, nil
+		})
+
+		const workers = 32
+		var wg sync.WaitGroup
+		results := make([]int, workers)
+		for w := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				value, err := cm.Get("hello")
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				results[w] = value
+			}()
+		}
+		for maps.CachingMapWaiters(cm, "hello") < workers-1 {
+			runtime.Gosched() // let the callers join the flight
+		}
+		close(release)
+		wg.Wait()
+
+		if n := calls.Load(); n != 1 {
+			t.Errorf("Expected loader to run once, ran %d times", n)
+		}
+		for w, value := range results {
+			if value != 5 {
+				t.Errorf("Worker %d: expected value 5, got %d", w, value)
+			}
+		}
+	})
+}
@@ -0,0 +1,39 @@
+package maps
+
+import "time"
+
+// Clock is the time source used by expiring containers.
+// Tests can supply their own implementation to control expiry without sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// TickerClock is a Clock that also drives periodic work, such as the
+// TTLMap reaper. NewTicker returns a channel that receives the time every
+// d, like time.Ticker, and a function that stops it. A Clock that does not
+// implement TickerClock gets real time.Tickers.
+type TickerClock interface {
+	Clock
+	NewTicker(d time.Duration) (c <-chan time.Time, stop func())
+}
+
+// systemClock reads the wall clock.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(d)
+	return ticker.C, ticker.Stop
+}
+
+// newTicker starts a ticker on clock if it is a TickerClock, and a real
+// time.Ticker otherwise.
+func newTicker(clock Clock, d time.Duration) (<-chan time.Time, func()) {
+	if tc, ok := clock.(TickerClock); ok {
+		return tc.NewTicker(d)
+	}
+	return systemClock{}.NewTicker(d)
+}
@@ -0,0 +1,314 @@
+package maps
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentMap is an AbstractMap that is safe for concurrent use by multiple
+// goroutines. It guards an inner AbstractMap with a sync.RWMutex: mutations
+// take the write lock, and read-only operations share the read lock when the
+// inner map is an UnorderedMap or SortedMap. Other inner maps, such as
+// LRUMap, may modify themselves on reads, so their reads take the write lock.
+// Unlike the DefaultAbstractMap helpers, the read-modify-write operations
+// (LoadOrStore, LoadAndDelete, Swap, CompareAndSwap, CompareAndDelete) hold
+// the write lock for their whole duration and are therefore atomic.
+//
+// Len does not take the lock: every operation holding the write lock,
+// including reads over an inner map that is not read-safe, copies the inner
+// map's length into an atomic counter before releasing it. Copying rather
+// than adjusting the counter keeps it exact even when the inner map changes
+// size on its own, as a TTLMap expiring keys in Load or a DefaultMap
+// storing on a miss does.
+//
+// Callbacks passed to Range, Keys and Values run while the lock is held and
+// must not modify the map.
+type ConcurrentMap[K, V any] struct {
+	*DefaultAbstractMap[K, V]
+	mu    sync.RWMutex
+	inner AbstractMap[K, V]
+	safe  bool         // Whether inner reports itself readSafe
+	len   atomic.Int64 // Copy of inner.Len(), refreshed under mu by syncLen
+}
+
+// NewConcurrentMap creates an empty ConcurrentMap backed by an UnorderedMap.
+func NewConcurrentMap[K comparable, V any]() *ConcurrentMap[K, V] {
+	return NewConcurrentMapFrom[K, V](NewUnorderedMap[K, V]())
+}
+
+// NewConcurrentMapFrom creates a ConcurrentMap guarding inner.
+// The caller must not access inner directly afterwards.
+func NewConcurrentMapFrom[K, V any](inner AbstractMap[K, V]) *ConcurrentMap[K, V] {
+	cm := &ConcurrentMap[K, V]{
+		inner: inner,
+	}
+	if r, ok := inner.(readSafe); ok {
+		cm.safe = r.readSafe()
+	}
+	cm.syncLen()
+	cm.DefaultAbstractMap = NewDefaultAbstractMap[K, V](cm)
+	return cm
+}
+
+// rlock locks cm for a read-only operation: shared when the inner map is
+// read-safe, exclusive otherwise.
+func (cm *ConcurrentMap[K, V]) rlock() {
+	if cm.safe {
+		cm.mu.RLock()
+	} else {
+		cm.mu.Lock()
+	}
+}
+
+// runlock releases the lock taken by rlock, first refreshing the length
+// if the read may have changed it.
+func (cm *ConcurrentMap[K, V]) runlock() {
+	if cm.safe {
+		cm.mu.RUnlock()
+	} else {
+		cm.syncLen()
+		cm.mu.Unlock()
+	}
+}
+
+// syncLen refreshes the lock-free length. Callers hold the write lock.
+func (cm *ConcurrentMap[K, V]) syncLen() {
+	cm.len.Store(int64(cm.inner.Len()))
+}
+
+func (cm *ConcurrentMap[K, V]) Clear() {
+	cm.checkMutable("Clear")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	cm.cleared(cm.inner.Range, cm.inner.Clear)
+}
+
+// Clone returns a new ConcurrentMap guarding a clone of the inner map.
+// It panics if the inner map does not implement Cloner.
+func (cm *ConcurrentMap[K, V]) Clone() AbstractMap[K, V] {
+	cm.rlock()
+	defer cm.runlock()
+	c, ok := cm.inner.(Cloner[K, V])
+	if !ok {
+		panic(fmt.Sprintf("maps: cannot clone ConcurrentMap, inner %T does not implement Cloner", cm.inner))
+	}
+	return NewConcurrentMapFrom(c.Clone())
+}
+
+// AtomicUpdate runs the load, f and store under the write lock.
+func (cm *ConcurrentMap[K, V]) AtomicUpdate(key K, f func(old V, loaded bool) (new V, store bool)) (V, bool) {
+	cm.checkMutable("AtomicUpdate")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	return atomicUpdate(key, f, cm.inner.Load, func(key K, value V) {
+		cm.inner.Store(key, value)
+		cm.stored(key, value)
+	})
+}
+
+func (cm *ConcurrentMap[K, V]) updatesUnderLock() bool { return true }
+
+func (cm *ConcurrentMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return cm.CompareAndDeleteFunc(key, old, equalAny[V])
+}
+
+func (cm *ConcurrentMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return cm.CompareAndSwapFunc(key, old, new, equalAny[V])
+}
+
+func (cm *ConcurrentMap[K, V]) Delete(key K) {
+	cm.LoadAndDelete(key)
+}
+
+func (cm *ConcurrentMap[K, V]) Keys(f func(key K) bool) {
+	cm.rlock()
+	defer cm.runlock()
+	cm.inner.Keys(f)
+}
+
+// Len returns the number of entries without taking the lock.
+func (cm *ConcurrentMap[K, V]) Len() int {
+	return int(cm.len.Load())
+}
+
+func (cm *ConcurrentMap[K, V]) Load(key K) (value V, ok bool) {
+	cm.rlock()
+	defer cm.runlock()
+	return cm.inner.Load(key)
+}
+
+func (cm *ConcurrentMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	cm.checkMutable("LoadAndDelete")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	value, loaded = cm.inner.LoadAndDelete(key)
+	if loaded {
+		cm.deleted(key, value)
+	}
+	return value, loaded
+}
+
+func (cm *ConcurrentMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	cm.checkMutable("LoadOrStore")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	actual, loaded = cm.inner.LoadOrStore(key, value)
+	if !loaded {
+		cm.stored(key, value)
+	}
+	return actual, loaded
+}
+
+// LoadOrCompute holds the write lock across f, so concurrent callers for a
+// missing key compute its value only once.
+func (cm *ConcurrentMap[K, V]) LoadOrCompute(key K, f func() V) (actual V, loaded bool) {
+	cm.checkMutable("LoadOrCompute")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	if actual, loaded = cm.inner.Load(key); loaded {
+		return actual, true
+	}
+	actual = f()
+	cm.inner.Store(key, actual)
+	cm.stored(key, actual)
+	return actual, false
+}
+
+func (cm *ConcurrentMap[K, V]) Range(f func(key K, value V) bool) {
+	cm.rlock()
+	defer cm.runlock()
+	cm.inner.Range(f)
+}
+
+func (cm *ConcurrentMap[K, V]) Store(key K, value V) {
+	cm.checkMutable("Store")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	cm.inner.Store(key, value)
+	cm.stored(key, value)
+}
+
+func (cm *ConcurrentMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	cm.checkMutable("Swap")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	previous, loaded = cm.inner.Swap(key, value)
+	cm.stored(key, value)
+	return previous, loaded
+}
+
+func (cm *ConcurrentMap[K, V]) Values(f func(value V) bool) {
+	cm.rlock()
+	defer cm.runlock()
+	cm.inner.Values(f)
+}
+
+// Update calls fn with a staging map and, if fn returns nil, applies the
+// changes made through it while the write lock is held, so other goroutines
+// observe either none or all of them; otherwise the map is left untouched
+// and fn's error is returned. The staging map starts as a Clone of the
+// contents, so fn reads a snapshot and runs without the lock; changes
+// committed by other goroutines while fn runs may be overwritten. Update
+// panics if the inner map does not implement Cloner.
+//
+// UnorderedMap, OrderedMap, ShardedMap, StripedMap and CopyOnWriteMap have
+// an Update of their own; the other maps, such as SortedMap, TTLMap and
+// LRUMap, support transactions only when wrapped in a ConcurrentMap.
+func (cm *ConcurrentMap[K, V]) Update(fn func(tx AbstractMap[K, V]) error) error {
+	cm.checkMutable("Update")
+	tx := newLogTxMap(cm.Clone())
+	if err := fn(tx); err != nil {
+		return err
+	}
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	tx.commit(
+		func(key K, value V) {
+			cm.inner.Store(key, value)
+			cm.stored(key, value)
+		},
+		func(key K) {
+			if value, loaded := cm.inner.LoadAndDelete(key); loaded {
+				cm.deleted(key, value)
+			}
+		},
+		func() { cm.cleared(cm.inner.Range, cm.inner.Clear) },
+	)
+	return nil
+}
+
+// CompareAndDeleteFunc atomically deletes the entry for key if eq reports
+// its value equal to old.
+func (cm *ConcurrentMap[K, V]) CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) (deleted bool) {
+	cm.checkMutable("CompareAndDeleteFunc")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	if value, ok := cm.inner.Load(key); ok && eq(value, old) {
+		cm.inner.Delete(key)
+		cm.deleted(key, value)
+		return true
+	}
+	return false
+}
+
+// CompareAndSwapFunc atomically stores new for key if eq reports its
+// current value equal to old.
+func (cm *ConcurrentMap[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) (swapped bool) {
+	cm.checkMutable("CompareAndSwapFunc")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	if value, ok := cm.inner.Load(key); ok && eq(value, old) {
+		cm.inner.Store(key, new)
+		cm.stored(key, new)
+		return true
+	}
+	return false
+}
+
+// Increment adds delta to the value for key, storing delta if the key is
+// missing, and returns the new value. On the concurrency-safe maps, and on
+// wrappers such as InstrumentedMap around them, it is one AtomicUpdate under
+// the key's lock. Any other map gets a LoadOrStore and CompareAndSwap retry
+// loop, which is atomic whenever those two methods are.
+func Increment[K comparable](m AbstractMap[K, int], key K, delta int) int {
+	if l, ok := m.(lockedUpdater); ok && l.updatesUnderLock() {
+		value, _ := m.(atomicUpdater[K, int]).AtomicUpdate(key, func(old int, _ bool) (int, bool) {
+			return old + delta, true
+		})
+		return value
+	}
+	for {
+		old, loaded := m.LoadOrStore(key, delta)
+		if !loaded {
+			return delta
+		}
+		if m.CompareAndSwap(key, old, old+delta) {
+			return old + delta
+		}
+	}
+}
+
+// IncrementConcurrent is Increment for a ConcurrentMap. It loads and stores
+// the inner map directly under the write lock, without the closure and
+// interface dispatch of AtomicUpdate.
+func IncrementConcurrent[K comparable](cm *ConcurrentMap[K, int], key K, delta int) int {
+	cm.checkMutable("Increment")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	defer cm.syncLen()
+	old, _ := cm.inner.Load(key)
+	cm.inner.Store(key, old+delta)
+	cm.stored(key, old+delta)
+	return old + delta
+}
@@ -0,0 +1,583 @@
+package maps_test
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestConcurrentMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewConcurrentMap[string, string]()
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestConcurrentMapOrderedInner(t *testing.T) {
+	factory := func() maps.AbstractMap[int, int] {
+		return maps.NewConcurrentMapFrom[int, int](maps.NewOrderedMap[int, int]())
+	}
+
+	testData := []TestCase[int, int]{
+		{1, 10},
+		{2, 20},
+		{3, 30},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+// Run with -race to verify the locking discipline.
+func TestConcurrentMapParallelAccess(t *testing.T) {
+	const (
+		workers = 16
+		keys    = 100
+	)
+	m := maps.NewConcurrentMap[int, int]()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := (w*31 + i) % keys
+				switch i % 3 {
+				case 0:
+					m.Store(key, i)
+				case 1:
+					m.Load(key)
+				case 2:
+					m.Delete(key)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// Len must agree with the number of entries Range observes.
+	counted := 0
+	m.Range(func(key, value int) bool {
+		counted++
+		return true
+	})
+	if length := m.Len(); length != counted {
+		t.Errorf("Expected Len %d to match Range count %d", length, counted)
+	}
+	if counted > keys {
+		t.Errorf("Expected at most %d keys, got %d", keys, counted)
+	}
+}
+
+// LRUMap moves entries on Load, so concurrent Loads must not share a lock.
+// Run with -race to verify.
+func TestConcurrentMapParallelLoadLRUInner(t *testing.T) {
+	const workers, keys = 16, 50
+	m := maps.NewConcurrentMapFrom[int, int](maps.NewLRUMap[int, int](100))
+	for i := range keys {
+		m.Store(i, i)
+	}
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				key := (w*13 + i) % keys
+				if value, ok := m.Load(key); !ok || value != key {
+					t.Errorf("Expected Load(%d) to return %d, true; got %d, %v", key, key, value, ok)
+					return
+				}
+				if i%100 == 0 {
+					m.Range(func(key, value int) bool { return true })
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m.Len() != keys {
+		t.Errorf("Expected Len %d, got %d", keys, m.Len())
+	}
+}
+
+// DefaultMap stores on a Load miss despite embedding UnorderedMap.
+// Run with -race to verify.
+func TestConcurrentMapParallelLoadDefaultInner(t *testing.T) {
+	const workers, keys = 16, 50
+	m := maps.NewConcurrentMapFrom[int, int](maps.NewDefaultMap(func(key int) int { return key }))
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				if value, _ := m.Load((w*13 + i) % keys); value != (w*13+i)%keys {
+					t.Errorf("Expected Load to return the factory value %d, got %d", (w*13+i)%keys, value)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentMapAtomicOperations(t *testing.T) {
+	t.Run("LoadOrStoreSingleWinner", func(t *testing.T) {
+		m := maps.NewConcurrentMap[string, int]()
+
+		const workers = 32
+		var wg sync.WaitGroup
+		results := make([]bool, workers)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				_, loaded := m.LoadOrStore("key", w)
+				results[w] = !loaded
+			}(w)
+		}
+		wg.Wait()
+
+		winners := 0
+		for _, stored := range results {
+			if stored {
+				winners++
+			}
+		}
+		if winners != 1 {
+			t.Errorf("Expected exactly one goroutine to store, got %d", winners)
+		}
+	})
+
+	t.Run("CompareAndSwapCounter", func(t *testing.T) {
+		m := maps.NewConcurrentMap[string, int]()
+		m.Store("counter", 0)
+
+		const workers, increments = 8, 200
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < increments; i++ {
+					for {
+						old, _ := m.Load("counter")
+						if m.CompareAndSwap("counter", old, old+1) {
+							break
+						}
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if value, _ := m.Load("counter"); value != workers*increments {
+			t.Errorf("Expected counter %d, got %d", workers*increments, value)
+		}
+	})
+}
+
+func TestAtomicUpdate(t *testing.T) {
+	type atomicUpdater interface {
+		maps.AbstractMap[string, int]
+		AtomicUpdate(key string, f func(old int, loaded bool) (int, bool)) (int, bool)
+	}
+	factories := map[string]func() atomicUpdater{
+		"ConcurrentMap":  func() atomicUpdater { return maps.NewConcurrentMap[string, int]() },
+		"ShardedMap":     func() atomicUpdater { return maps.NewShardedMap[string, int](4) },
+		"StripedMap":     func() atomicUpdater { return maps.NewStripedMap[string, int](4) },
+		"CopyOnWriteMap": func() atomicUpdater { return maps.NewCopyOnWriteMap[string, int]() },
+	}
+	increment := func(old int, loaded bool) (int, bool) { return old + 1, true }
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			const goroutines, increments = 16, 500
+			var wg sync.WaitGroup
+			for range goroutines {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range increments {
+						m.AtomicUpdate("counter", increment)
+					}
+				}()
+			}
+			wg.Wait()
+			if v, _ := m.Load("counter"); v != goroutines*increments {
+				t.Errorf("Expected %d, got %d", goroutines*increments, v)
+			}
+		})
+	}
+
+	t.Run("NoStore", func(t *testing.T) {
+		m := maps.NewConcurrentMap[string, int]()
+		v, ok := m.AtomicUpdate("missing", func(old int, loaded bool) (int, bool) { return 1, loaded })
+		if ok || v != 0 || m.Len() != 0 {
+			t.Errorf("Expected nothing stored, got %d, %v with length %d", v, ok, m.Len())
+		}
+		m.Store("a", 5)
+		v, ok = m.AtomicUpdate("a", func(old int, loaded bool) (int, bool) { return 0, false })
+		if !ok || v != 5 {
+			t.Errorf("Expected the existing value 5, got %d, %v", v, ok)
+		}
+	})
+
+	t.Run("NonConcurrent", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.AtomicUpdate("a", increment)
+		if v, ok := om.AtomicUpdate("a", increment); !ok || v != 2 {
+			t.Errorf("Expected 2, got %d, %v", v, ok)
+		}
+	})
+}
+
+func TestConcurrentMapLen(t *testing.T) {
+	const workers, keys = 16, 64
+	m := maps.NewConcurrentMap[int, int]()
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 2000 {
+				key := (w*17 + i) % keys
+				switch i % 7 {
+				case 0:
+					m.Store(key, i)
+				case 1:
+					m.LoadOrStore(key, i)
+				case 2:
+					m.Delete(key)
+				case 3:
+					m.Swap(key, i)
+				case 4:
+					m.LoadAndDelete(key)
+				case 5:
+					m.CompareAndDelete(key, i-5)
+				case 6:
+					m.AtomicUpdate(key, func(old int, loaded bool) (int, bool) { return old + 1, !loaded })
+				}
+				m.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	counted := 0
+	m.Range(func(key, value int) bool {
+		counted++
+		return true
+	})
+	if m.Len() != counted {
+		t.Errorf("Expected Len %d to match Range count %d", m.Len(), counted)
+	}
+
+	m.Clear()
+	if m.Len() != 0 {
+		t.Errorf("Expected Len 0 after Clear, got %d", m.Len())
+	}
+
+	evicting := maps.NewConcurrentMapFrom[int, int](maps.NewLRUMap[int, int](2))
+	for i := range 3 {
+		evicting.Store(i, i)
+	}
+	if evicting.Len() != 2 {
+		t.Errorf("Expected Len 2 after the inner LRUMap evicted, got %d", evicting.Len())
+	}
+
+	clock := newFakeClock()
+	expiring := maps.NewConcurrentMapFrom[int, int](maps.NewTTLMapWithClock[int, int](time.Minute, clock))
+	expiring.Store(1, 1)
+	expiring.Store(2, 2)
+	clock.Advance(2 * time.Minute)
+	if _, ok := expiring.Load(1); ok {
+		t.Error("Expected Load of an expired key to miss")
+	}
+	if expiring.Len() != 0 {
+		t.Errorf("Expected Len 0 after Load observed the expiry, got %d", expiring.Len())
+	}
+
+	defaulting := maps.NewConcurrentMapFrom[int, int](maps.NewDefaultMap(func(key int) int { return key }))
+	defaulting.Load(7)
+	if defaulting.Len() != 1 {
+		t.Errorf("Expected Len 1 after a DefaultMap miss stored a value, got %d", defaulting.Len())
+	}
+
+	prefilled := maps.NewOrderedMap[int, int]()
+	prefilled.Store(1, 1)
+	prefilled.Store(2, 2)
+	if l := maps.NewConcurrentMapFrom[int, int](prefilled).Len(); l != 2 {
+		t.Errorf("Expected Len 2 for a pre-filled inner map, got %d", l)
+	}
+}
+
+// casMap is a user map type whose LoadOrStore and CompareAndSwap are atomic
+// but which inherits the plain DefaultAbstractMap.AtomicUpdate.
+type casMap struct {
+	*maps.DefaultAbstractMap[string, int]
+	mu sync.Mutex
+	m  map[string]int
+}
+
+func newCASMap() *casMap {
+	cm := &casMap{m: map[string]int{}}
+	cm.DefaultAbstractMap = maps.NewDefaultAbstractMap[string, int](cm)
+	return cm
+}
+
+func (cm *casMap) Delete(key string) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	delete(cm.m, key)
+}
+
+func (cm *casMap) Len() int {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	return len(cm.m)
+}
+
+// Load yields after reading, so a non-atomic load then store loses updates
+// even on a single CPU.
+func (cm *casMap) Load(key string) (int, bool) {
+	cm.mu.Lock()
+	value, ok := cm.m[key]
+	cm.mu.Unlock()
+	runtime.Gosched()
+	return value, ok
+}
+
+func (cm *casMap) Range(f func(key string, value int) bool) {
+	cm.mu.Lock()
+	snapshot := make(map[string]int, len(cm.m))
+	for key, value := range cm.m {
+		snapshot[key] = value
+	}
+	cm.mu.Unlock()
+	for key, value := range snapshot {
+		if !f(key, value) {
+			return
+		}
+	}
+}
+
+func (cm *casMap) Store(key string, value int) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.m[key] = value
+}
+
+func (cm *casMap) LoadOrStore(key string, value int) (int, bool) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if actual, ok := cm.m[key]; ok {
+		return actual, true
+	}
+	cm.m[key] = value
+	return value, false
+}
+
+func (cm *casMap) CompareAndSwap(key string, old, new int) bool {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if value, ok := cm.m[key]; !ok || value != old {
+		return false
+	}
+	cm.m[key] = new
+	return true
+}
+
+func TestConcurrentMapUpdate(t *testing.T) {
+	m := maps.NewConcurrentMap[string, int]()
+	m.Store("a", 1)
+	m.Store("b", 2)
+	var events []string
+	m.Observe(
+		func(key string, value int) { events = append(events, "store "+key) },
+		func(key string, value int) { events = append(events, "delete "+key) },
+	)
+	err := m.Update(func(tx maps.AbstractMap[string, int]) error {
+		tx.Delete("a")
+		tx.Delete("missing")
+		tx.Store("c", 3)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Expected Len 2 after commit, got %d", m.Len())
+	}
+	if expected := []string{"delete a", "store c"}; !slices.Equal(events, expected) {
+		t.Errorf("Expected observers to see %v, got %v", expected, events)
+	}
+
+	// Run with -race: concurrent commits must keep Len exact.
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Update(func(tx maps.AbstractMap[string, int]) error {
+				tx.Store("d", 1)
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	if m.Len() != 3 {
+		t.Errorf("Expected Len 3, got %d", m.Len())
+	}
+}
+
+func TestIncrement(t *testing.T) {
+	factories := map[string]func() maps.AbstractMap[string, int]{
+		"ConcurrentMap": func() maps.AbstractMap[string, int] { return maps.NewConcurrentMap[string, int]() },
+		"ShardedMap":    func() maps.AbstractMap[string, int] { return maps.NewShardedMap[string, int](4) },
+		"StripedMap":    func() maps.AbstractMap[string, int] { return maps.NewStripedMap[string, int](4) },
+		// Its inherited AtomicUpdate is not atomic, so Increment must use
+		// the CompareAndSwap loop.
+		"CASMap": func() maps.AbstractMap[string, int] { return newCASMap() },
+		// A wrapper must reach the inner map's AtomicUpdate.
+		"InstrumentedConcurrentMap": func() maps.AbstractMap[string, int] {
+			return maps.NewInstrumentedMap[string, int](maps.NewConcurrentMap[string, int]())
+		},
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			const goroutines, increments = 16, 500
+			var wg sync.WaitGroup
+			for g := range goroutines {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range increments {
+						maps.Increment(m, "counter", g%2+1)
+					}
+				}()
+			}
+			wg.Wait()
+			if expected := goroutines / 2 * increments * 3; maps.Increment(m, "counter", 0) != expected {
+				v, _ := m.Load("counter")
+				t.Errorf("Expected %d, got %d", expected, v)
+			}
+		})
+	}
+
+	t.Run("IncrementConcurrent", func(t *testing.T) {
+		m := maps.NewConcurrentMap[string, int]()
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 500 {
+					maps.IncrementConcurrent(m, "counter", 2)
+				}
+			}()
+		}
+		wg.Wait()
+		if v, _ := m.Load("counter"); v != 8000 || m.Len() != 1 {
+			t.Errorf("Expected counter 8000 and Len 1, got %d and %d", v, m.Len())
+		}
+	})
+
+	t.Run("Absent", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		if v := maps.Increment[string](om, "a", 5); v != 5 {
+			t.Errorf("Expected a missing key to start at delta 5, got %d", v)
+		}
+		if v := maps.Increment[string](om, "a", -2); v != 3 {
+			t.Errorf("Expected 3, got %d", v)
+		}
+	})
+}
+
+func TestLoadOrCompute(t *testing.T) {
+	t.Run("PresentKeySkipsCompute", func(t *testing.T) {
+		for name, m := range map[string]interface {
+			maps.AbstractMap[string, int]
+			LoadOrCompute(key string, f func() int) (int, bool)
+		}{
+			"OrderedMap":     maps.NewOrderedMap[string, int](),
+			"ConcurrentMap":  maps.NewConcurrentMap[string, int](),
+			"ShardedMap":     maps.NewShardedMap[string, int](4),
+			"StripedMap":     maps.NewStripedMap[string, int](4),
+			"CopyOnWriteMap": maps.NewCopyOnWriteMap[string, int](),
+			"TTLMap":         maps.NewTTLMap[string, int](time.Hour),
+		} {
+			m.Store("a", 1)
+			actual, loaded := m.LoadOrCompute("a", func() int {
+				t.Errorf("%s: expected f not to be called for a present key", name)
+				return 0
+			})
+			if !loaded || actual != 1 {
+				t.Errorf("%s: expected loaded 1, got %d (loaded=%v)", name, actual, loaded)
+			}
+			if actual, loaded := m.LoadOrCompute("b", func() int { return 2 }); loaded || actual != 2 {
+				t.Errorf("%s: expected computed 2, got %d (loaded=%v)", name, actual, loaded)
+			}
+			if v, _ := m.Load("b"); v != 2 {
+				t.Errorf("%s: expected b=2 to be stored, got %d", name, v)
+			}
+		}
+	})
+
+	t.Run("ConcurrentComputesOnce", func(t *testing.T) {
+		for name, m := range map[string]interface {
+			maps.AbstractMap[string, int]
+			LoadOrCompute(key string, f func() int) (int, bool)
+		}{
+			"ConcurrentMap":  maps.NewConcurrentMap[string, int](),
+			"ShardedMap":     maps.NewShardedMap[string, int](4),
+			"StripedMap":     maps.NewStripedMap[string, int](4),
+			"CopyOnWriteMap": maps.NewCopyOnWriteMap[string, int](),
+			"TTLMap":         maps.NewTTLMap[string, int](time.Hour),
+		} {
+			var calls atomic.Int32
+			const goroutines = 32
+			var wg sync.WaitGroup
+			for range goroutines {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					actual, _ := m.LoadOrCompute("heavy", func() int {
+						calls.Add(1)
+						runtime.Gosched() // Let other callers race for the missing key
+						return 42
+					})
+					if actual != 42 {
+						t.Errorf("%s: expected 42, got %d", name, actual)
+					}
+				}()
+			}
+			wg.Wait()
+			if n := calls.Load(); n != 1 {
+				t.Errorf("%s: expected f to run once, ran %d times", name, n)
+			}
+			if m.Len() != 1 {
+				t.Errorf("%s: expected length 1, got %d", name, m.Len())
+			}
+		}
+	})
+}
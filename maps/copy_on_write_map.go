@@ -0,0 +1,261 @@
+package maps
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// CopyOnWriteMap is a concurrency-safe AbstractMap for read-mostly data.
+// Every mutation copies the current contents, applies the change to the copy
+// and publishes it atomically, so reads never take a lock and Snapshot is
+// O(1). Writers are serialized and each pays O(n) for the copy.
+type CopyOnWriteMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, V]
+	mu      sync.Mutex // Serializes writers
+	current atomic.Pointer[UnorderedMap[K, V]]
+}
+
+// NewCopyOnWriteMap creates an empty CopyOnWriteMap.
+func NewCopyOnWriteMap[K comparable, V any]() *CopyOnWriteMap[K, V] {
+	cm := &CopyOnWriteMap[K, V]{}
+	cm.current.Store(NewUnorderedMap[K, V]())
+	cm.DefaultAbstractMap = NewDefaultAbstractMap(cm)
+	return cm
+}
+
+// cowChange records a change made to the private copy during update.
+type cowChange[K, V any] struct {
+	key     K
+	value   V
+	deleted bool
+}
+
+// update applies f to a private copy of the current contents, with the
+// writer lock held, and publishes the copy. The changes f makes are reported
+// to the observers after publishing, so they see the new contents, and
+// before unlocking, so they are reported in publish order.
+func (cm *CopyOnWriteMap[K, V]) update(f func(next *UnorderedMap[K, V])) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	next := cm.current.Load().Clone().(*UnorderedMap[K, V])
+	var changes []cowChange[K, V]
+	if cm.loadObservers() != nil {
+		next.Observe(
+			func(key K, value V) {
+				changes = append(changes, cowChange[K, V]{key: key, value: value})
+			},
+			func(key K, value V) {
+				changes = append(changes, cowChange[K, V]{key: key, value: value, deleted: true})
+			},
+		)
+	}
+	f(next)
+	next.Observe(nil, nil)
+	cm.current.Store(next)
+	for _, c := range changes {
+		if c.deleted {
+			cm.deleted(c.key, c.value)
+		} else {
+			cm.stored(c.key, c.value)
+		}
+	}
+}
+
+// Snapshot returns a read-only view of the current contents. The snapshot
+// shares storage with the map but never observes later mutations.
+// Time complexity: O(1)
+func (cm *CopyOnWriteMap[K, V]) Snapshot() AbstractMap[K, V] {
+	return ReadOnly[K, V](cm.current.Load())
+}
+
+// Clear removes all entries.
+// Time complexity: O(1)
+func (cm *CopyOnWriteMap[K, V]) Clear() {
+	cm.checkMutable("Clear")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.cleared(cm.current.Load().Range, func() { cm.current.Store(NewUnorderedMap[K, V]()) })
+}
+
+// Clone returns an independent CopyOnWriteMap with the same entries. The
+// published contents are immutable, so the clone starts out sharing them.
+// Time complexity: O(1)
+func (cm *CopyOnWriteMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := &CopyOnWriteMap[K, V]{}
+	clone.current.Store(cm.current.Load())
+	clone.DefaultAbstractMap = NewDefaultAbstractMap(clone)
+	return clone
+}
+
+// AtomicUpdate runs the load, f and store under the writer lock, copying
+// the map only if f asks to store.
+// Time complexity: O(n) if a value is stored, O(1) otherwise
+func (cm *CopyOnWriteMap[K, V]) AtomicUpdate(key K, f func(old V, loaded bool) (new V, store bool)) (V, bool) {
+	cm.checkMutable("AtomicUpdate")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	current := cm.current.Load()
+	return atomicUpdate(key, f, current.Load, func(key K, value V) {
+		next := current.Clone().(*UnorderedMap[K, V])
+		next.Store(key, value)
+		cm.current.Store(next)
+		cm.stored(key, value)
+	})
+}
+
+func (cm *CopyOnWriteMap[K, V]) updatesUnderLock() bool { return true }
+
+// CompareAndDelete deletes key if its value equals old, comparing with ==.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return cm.CompareAndDeleteFunc(key, old, equalAny[V])
+}
+
+// CompareAndDeleteFunc deletes key if eq reports its value equal to old.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) (deleted bool) {
+	cm.checkMutable("CompareAndDeleteFunc")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	current := cm.current.Load()
+	value, ok := current.Load(key)
+	if !ok || !eq(value, old) {
+		return false
+	}
+	next := current.Clone().(*UnorderedMap[K, V])
+	next.Delete(key)
+	cm.current.Store(next)
+	cm.deleted(key, value)
+	return true
+}
+
+// CompareAndSwap stores new for key if its value equals old, comparing with ==.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return cm.CompareAndSwapFunc(key, old, new, equalAny[V])
+}
+
+// CompareAndSwapFunc stores new for key if eq reports its value equal to old.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) (swapped bool) {
+	cm.checkMutable("CompareAndSwapFunc")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	current := cm.current.Load()
+	if value, ok := current.Load(key); !ok || !eq(value, old) {
+		return false
+	}
+	next := current.Clone().(*UnorderedMap[K, V])
+	next.Store(key, new)
+	cm.current.Store(next)
+	cm.stored(key, new)
+	return true
+}
+
+// Delete removes key from the map if present. Deleting a missing key does
+// not copy the map.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) Delete(key K) {
+	cm.LoadAndDelete(key)
+}
+
+// Len returns the number of entries in the map.
+// Time complexity: O(1)
+func (cm *CopyOnWriteMap[K, V]) Len() int {
+	return cm.current.Load().Len()
+}
+
+// Load returns the value stored for key without locking.
+// Time complexity: O(1)
+func (cm *CopyOnWriteMap[K, V]) Load(key K) (value V, ok bool) {
+	return cm.current.Load().Load(key)
+}
+
+// LoadAndDelete removes key and returns its previous value, if any.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	cm.checkMutable("LoadAndDelete")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	current := cm.current.Load()
+	if value, loaded = current.Load(key); !loaded {
+		return value, false
+	}
+	next := current.Clone().(*UnorderedMap[K, V])
+	next.Delete(key)
+	cm.current.Store(next)
+	cm.deleted(key, value)
+	return value, true
+}
+
+// LoadOrCompute runs f under the writer lock, so concurrent callers for a
+// missing key compute its value only once.
+// Time complexity: O(n) if a value is computed, O(1) otherwise
+func (cm *CopyOnWriteMap[K, V]) LoadOrCompute(key K, f func() V) (actual V, loaded bool) {
+	cm.checkMutable("LoadOrCompute")
+	return loadOrCompute(key, f, cm.AtomicUpdate)
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise it
+// stores and returns value.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	cm.checkMutable("LoadOrStore")
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	current := cm.current.Load()
+	if actual, loaded = current.Load(key); loaded {
+		return actual, true
+	}
+	next := current.Clone().(*UnorderedMap[K, V])
+	next.Store(key, value)
+	cm.current.Store(next)
+	cm.stored(key, value)
+	return value, false
+}
+
+// Range calls f for each entry of the contents current when Range was
+// called, until f returns false. Mutations made during iteration, including
+// by f, are not observed.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) Range(f func(key K, value V) bool) {
+	cm.current.Load().Range(f)
+}
+
+// Store sets the value for key.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) Store(key K, value V) {
+	cm.checkMutable("Store")
+	cm.update(func(next *UnorderedMap[K, V]) {
+		next.Store(key, value)
+	})
+}
+
+// Swap stores value for key and returns the previous value, if any.
+// Time complexity: O(n)
+func (cm *CopyOnWriteMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	cm.checkMutable("Swap")
+	cm.update(func(next *UnorderedMap[K, V]) {
+		previous, loaded = next.Swap(key, value)
+	})
+	return previous, loaded
+}
+
+// Update calls fn with a staging map that reflects the current contents and
+// records the changes made through it. If fn returns nil, the changes are
+// published as a single new version, so readers observe either none or all
+// of them; otherwise the map is left untouched and fn's error is returned.
+// Reads made by fn are not isolated: changes published by other goroutines
+// while fn runs may be overwritten.
+// Time complexity: O(n + k) to commit, where k is the number of keys changed
+func (cm *CopyOnWriteMap[K, V]) Update(fn func(tx AbstractMap[K, V]) error) error {
+	cm.checkMutable("Update")
+	tx := newTxMap[K, V](cm)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	cm.update(func(next *UnorderedMap[K, V]) {
+		tx.commit(next.Store, next.Delete, next.Clear)
+	})
+	return nil
+}
@@ -0,0 +1,147 @@
+package maps_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestCopyOnWriteMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewCopyOnWriteMap[string, string]()
+	}
+
+	testData := []TestCase[string, string]{
+		{"alpha", "first"},
+		{"beta", "second"},
+		{"gamma", "third"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestCopyOnWriteMapSnapshot(t *testing.T) {
+	cm := maps.NewCopyOnWriteMap[string, int]()
+	cm.Store("a", 1)
+	cm.Store("b", 2)
+
+	snapshot := cm.Snapshot()
+
+	cm.Store("a", 10)
+	cm.Store("c", 3)
+	cm.Delete("b")
+	cm.Swap("d", 4)
+	cm.LoadOrStore("e", 5)
+
+	expected := map[string]int{"a": 1, "b": 2}
+	if snapshot.Len() != len(expected) {
+		t.Errorf("Expected snapshot length %d, got %d", len(expected), snapshot.Len())
+	}
+	for key, value := range expected {
+		if got, ok := snapshot.Load(key); !ok || got != value {
+			t.Errorf("Expected snapshot %s=%d, got %d (ok=%v)", key, value, got, ok)
+		}
+	}
+
+	t.Run("ClearKeepsSnapshot", func(t *testing.T) {
+		before := cm.Snapshot()
+		cm.Clear()
+		if before.Len() != 4 {
+			t.Errorf("Expected snapshot length 4 after Clear, got %d", before.Len())
+		}
+		if cm.Len() != 0 {
+			t.Errorf("Expected map to be empty, got length %d", cm.Len())
+		}
+	})
+
+	t.Run("SnapshotIsReadOnly", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected Store on a snapshot to panic")
+			}
+		}()
+		snapshot.Store("x", 1)
+	})
+}
+
+func TestCopyOnWriteMapClone(t *testing.T) {
+	cm := maps.NewCopyOnWriteMap[string, int]()
+	cm.Store("a", 1)
+
+	clone := cm.Clone()
+	clone.Store("b", 2)
+	cm.Store("c", 3)
+
+	if _, ok := cm.Load("b"); ok {
+		t.Errorf("Expected original not to see clone's writes")
+	}
+	if _, ok := clone.Load("c"); ok {
+		t.Errorf("Expected clone not to see original's writes")
+	}
+}
+
+func TestCopyOnWriteMapConcurrent(t *testing.T) {
+	cm := maps.NewCopyOnWriteMap[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				cm.Store(w*100+i, i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				snapshot := cm.Snapshot()
+				n := snapshot.Len()
+				count := 0
+				snapshot.Range(func(int, int) bool {
+					count++
+					return true
+				})
+				if count != n {
+					t.Errorf("Expected snapshot Range to visit %d entries, got %d", n, count)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if cm.Len() != 400 {
+		t.Errorf("Expected length 400, got %d", cm.Len())
+	}
+}
+
+// BenchmarkReadHeavy compares a read-mostly workload (one write per 100
+// reads) on CopyOnWriteMap and ConcurrentMap.
+func BenchmarkReadHeavy(b *testing.B) {
+	const size = 1000
+	factories := map[string]func() maps.AbstractMap[int, int]{
+		"ConcurrentMap":  func() maps.AbstractMap[int, int] { return maps.NewConcurrentMap[int, int]() },
+		"CopyOnWriteMap": func() maps.AbstractMap[int, int] { return maps.NewCopyOnWriteMap[int, int]() },
+	}
+	for name, factory := range factories {
+		b.Run(name, func(b *testing.B) {
+			m := factory()
+			for i := 0; i < size; i++ {
+				m.Store(i, i)
+			}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if i%100 == 0 {
+						m.Store(i%size, i)
+					} else {
+						m.Load(i % size)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package maps
+
+import (
+	"cmp"
+	"slices"
+)
+
+// CountEntry is a key and its count, as returned by Counter.MostCommon.
+type CountEntry[K any] struct {
+	Key   K
+	Count int
+}
+
+// Counter counts occurrences of keys. It is backed by an OrderedMap so that
+// keys with equal counts are reported in the order they were first counted.
+// The zero value is not usable; create counters with NewCounter.
+type Counter[K comparable] struct {
+	m            *OrderedMap[K, int]
+	total        int
+	deleteOnZero bool
+}
+
+// NewCounter creates an empty Counter.
+func NewCounter[K comparable]() *Counter[K] {
+	return &Counter[K]{
+		m: NewOrderedMap[K, int](),
+	}
+}
+
+// SetDeleteOnZero controls whether a key whose count drops to exactly zero
+// is removed, so it no longer appears in Len or MostCommon. It is off by
+// default.
+func (c *Counter[K]) SetDeleteOnZero(enabled bool) {
+	c.deleteOnZero = enabled
+}
+
+// Inc adds one to the count for key.
+// Time complexity: O(1)
+func (c *Counter[K]) Inc(key K) {
+	c.Add(key, 1)
+}
+
+// Add adds n, which may be negative, to the count for key.
+// Time complexity: O(1)
+func (c *Counter[K]) Add(key K, n int) {
+	count := c.Count(key) + n
+	c.total += n
+	if count == 0 && c.deleteOnZero {
+		c.m.Delete(key)
+		return
+	}
+	c.m.Store(key, count)
+}
+
+// Count returns the count for key, or zero if it has never been counted.
+// Time complexity: O(1)
+func (c *Counter[K]) Count(key K) int {
+	count, _ := c.m.Load(key)
+	return count
+}
+
+// Total returns the sum of all counts.
+// Time complexity: O(1)
+func (c *Counter[K]) Total() int {
+	return c.total
+}
+
+// Len returns the number of distinct keys being counted.
+// Time complexity: O(1)
+func (c *Counter[K]) Len() int {
+	return c.m.Len()
+}
+
+// MostCommon returns the n keys with the highest counts in descending order
+// of count. Keys with equal counts keep the order in which they were first
+// counted. If n is negative or exceeds Len, all keys are returned.
+// Time complexity: O(m log m) where m is Len
+func (c *Counter[K]) MostCommon(n int) []CountEntry[K] {
+	entries := make([]CountEntry[K], 0, c.m.Len())
+	for k, count := range c.m.Range {
+		entries = append(entries, CountEntry[K]{Key: k, Count: count})
+	}
+	slices.SortStableFunc(entries, func(a, b CountEntry[K]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	if n >= 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+	return entries
+}
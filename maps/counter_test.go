@@ -0,0 +1,70 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestCounter(t *testing.T) {
+	t.Run("Increments", func(t *testing.T) {
+		c := maps.NewCounter[string]()
+		for _, word := range []string{"a", "b", "a", "c", "a", "b"} {
+			c.Inc(word)
+		}
+		c.Add("d", 5)
+
+		expected := map[string]int{"a": 3, "b": 2, "c": 1, "d": 5, "missing": 0}
+		for key, count := range expected {
+			if got := c.Count(key); got != count {
+				t.Errorf("Expected count(%s)=%d, got %d", key, count, got)
+			}
+		}
+		if c.Total() != 11 {
+			t.Errorf("Expected total 11, got %d", c.Total())
+		}
+		if c.Len() != 4 {
+			t.Errorf("Expected 4 distinct keys, got %d", c.Len())
+		}
+	})
+
+	t.Run("MostCommonTies", func(t *testing.T) {
+		c := maps.NewCounter[string]()
+		c.Add("x", 2)
+		c.Add("y", 5)
+		c.Add("z", 2)
+		c.Add("w", 5)
+		c.Add("v", 1)
+
+		expected := []maps.CountEntry[string]{{"y", 5}, {"w", 5}, {"x", 2}, {"z", 2}}
+		if top := c.MostCommon(4); !slices.Equal(top, expected) {
+			t.Errorf("Expected %v, got %v", expected, top)
+		}
+		if all := c.MostCommon(-1); len(all) != 5 || all[4].Key != "v" {
+			t.Errorf("Expected all 5 keys ending with v, got %v", all)
+		}
+		if none := c.MostCommon(0); len(none) != 0 {
+			t.Errorf("Expected no keys, got %v", none)
+		}
+	})
+
+	t.Run("DeleteOnZero", func(t *testing.T) {
+		c := maps.NewCounter[string]()
+		c.Add("kept", 1)
+		c.Add("kept", -1)
+		if c.Len() != 1 {
+			t.Errorf("Expected zero counts to be kept by default, got %d keys", c.Len())
+		}
+
+		c.SetDeleteOnZero(true)
+		c.Add("gone", 2)
+		c.Add("gone", -2)
+		if c.Len() != 1 {
+			t.Errorf("Expected gone to be removed at zero, got %d keys", c.Len())
+		}
+		if c.Total() != 0 {
+			t.Errorf("Expected total 0, got %d", c.Total())
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package maps
+
+// CustomKeyMap implements AbstractMap for key types that are not comparable,
+// such as structs containing slices. Key identity is defined by the
+// user-supplied keyHash and keyEqual functions: keys that are equal must
+// hash to the same value. Entries are kept in buckets indexed by hash, so
+// operations are O(1) on average and degrade with the number of colliding keys.
+// Range visits entries in no particular order.
+type CustomKeyMap[K, V any] struct {
+	*DefaultAbstractMap[K, V]
+	buckets  map[uint64][]*entry[K, V]
+	keyHash  func(K) uint64
+	keyEqual func(a, b K) bool
+	len      int
+}
+
+// NewCustomKeyMap creates an empty CustomKeyMap using keyHash and keyEqual
+// to identify keys.
+func NewCustomKeyMap[K, V any](keyHash func(K) uint64, keyEqual func(a, b K) bool) *CustomKeyMap[K, V] {
+	cm := &CustomKeyMap[K, V]{
+		buckets:  make(map[uint64][]*entry[K, V]),
+		keyHash:  keyHash,
+		keyEqual: keyEqual,
+	}
+	cm.DefaultAbstractMap = NewDefaultAbstractMap[K, V](cm)
+	return cm
+}
+
+// find returns the bucket hash for key and the index of its entry within
+// the bucket, or -1 if the key is absent.
+func (cm *CustomKeyMap[K, V]) find(key K) (hash uint64, index int) {
+	hash = cm.keyHash(key)
+	for i, e := range cm.buckets[hash] {
+		if cm.keyEqual(e.key, key) {
+			return hash, i
+		}
+	}
+	return hash, -1
+}
+
+func (cm *CustomKeyMap[K, V]) Clear() {
+	cm.checkMutable("Clear")
+	cm.cleared(cm.Range, func() {
+		clear(cm.buckets)
+		cm.len = 0
+	})
+}
+
+func (cm *CustomKeyMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := NewCustomKeyMap[K, V](cm.keyHash, cm.keyEqual)
+	for hash, bucket := range cm.buckets {
+		copied := make([]*entry[K, V], len(bucket))
+		for i, e := range bucket {
+			copied[i] = &entry[K, V]{key: e.key, value: e.value}
+		}
+		clone.buckets[hash] = copied
+	}
+	clone.len = cm.len
+	return clone
+}
+
+func (cm *CustomKeyMap[K, V]) Delete(key K) {
+	cm.checkMutable("Delete")
+	hash, i := cm.find(key)
+	if i < 0 {
+		return
+	}
+	bucket := cm.buckets[hash]
+	removed := bucket[i]
+	if len(bucket) == 1 {
+		delete(cm.buckets, hash)
+	} else {
+		bucket[i] = bucket[len(bucket)-1]
+		bucket[len(bucket)-1] = nil
+		cm.buckets[hash] = bucket[:len(bucket)-1]
+	}
+	cm.len--
+	cm.deleted(removed.key, removed.value)
+}
+
+func (cm *CustomKeyMap[K, V]) Len() int {
+	return cm.len
+}
+
+func (cm *CustomKeyMap[K, V]) Load(key K) (value V, ok bool) {
+	hash, i := cm.find(key)
+	if i < 0 {
+		return value, false
+	}
+	return cm.buckets[hash][i].value, true
+}
+
+func (cm *CustomKeyMap[K, V]) Range(f func(key K, value V) bool) {
+	for _, bucket := range cm.buckets {
+		for _, e := range bucket {
+			if !f(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+func (cm *CustomKeyMap[K, V]) Store(key K, value V) {
+	cm.checkMutable("Store")
+	hash, i := cm.find(key)
+	if i >= 0 {
+		cm.buckets[hash][i].value = value
+	} else {
+		cm.buckets[hash] = append(cm.buckets[hash], &entry[K, V]{key: key, value: value})
+		cm.len++
+	}
+	cm.stored(key, value)
+}
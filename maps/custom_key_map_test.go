@@ -0,0 +1,97 @@
+package maps_test
+
+import (
+	"hash/fnv"
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+// pathKey is not comparable because it contains a slice.
+type pathKey struct {
+	Root  string
+	Parts []string
+}
+
+func hashPathKey(k pathKey) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(k.Root))
+	for _, part := range k.Parts {
+		h.Write([]byte{0})
+		h.Write([]byte(part))
+	}
+	return h.Sum64()
+}
+
+func equalPathKey(a, b pathKey) bool {
+	return a.Root == b.Root && slices.Equal(a.Parts, b.Parts)
+}
+
+func TestCustomKeyMapInt(t *testing.T) {
+	factory := func() maps.AbstractMap[int, int] {
+		// A deliberately poor hash forces every key into the same bucket.
+		return maps.NewCustomKeyMap[int, int](
+			func(int) uint64 { return 0 },
+			func(a, b int) bool { return a == b },
+		)
+	}
+
+	testData := []TestCase[int, int]{
+		{1, 10},
+		{2, 20},
+		{3, 30},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestCustomKeyMapSliceKeys(t *testing.T) {
+	newMap := func() *maps.CustomKeyMap[pathKey, string] {
+		return maps.NewCustomKeyMap[pathKey, string](hashPathKey, equalPathKey)
+	}
+
+	t.Run("Lookup", func(t *testing.T) {
+		m := newMap()
+		m.Store(pathKey{"/", []string{"usr", "bin"}}, "binaries")
+		m.Store(pathKey{"/", []string{"usr", "lib"}}, "libraries")
+
+		// A distinct but equal key value must find the entry.
+		if value, ok := m.Load(pathKey{"/", []string{"usr", "bin"}}); !ok || value != "binaries" {
+			t.Errorf("Expected binaries, got %q (ok=%v)", value, ok)
+		}
+		if _, ok := m.Load(pathKey{"/", []string{"usr"}}); ok {
+			t.Error("Expected prefix key to be absent")
+		}
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		m := newMap()
+		m.Store(pathKey{"/", []string{"etc"}}, "old")
+		m.Store(pathKey{"/", []string{"etc"}}, "new")
+
+		if length := m.Len(); length != 1 {
+			t.Errorf("Expected length 1 after overwrite, got %d", length)
+		}
+		if value, _ := m.Load(pathKey{"/", []string{"etc"}}); value != "new" {
+			t.Errorf("Expected overwritten value new, got %q", value)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		m := newMap()
+		m.Store(pathKey{"/", []string{"tmp"}}, "scratch")
+		m.Store(pathKey{"/", []string{"var"}}, "state")
+
+		m.Delete(pathKey{"/", []string{"tmp"}})
+		if _, ok := m.Load(pathKey{"/", []string{"tmp"}}); ok {
+			t.Error("Expected deleted key to be absent")
+		}
+		if value, ok := m.Load(pathKey{"/", []string{"var"}}); !ok || value != "state" {
+			t.Errorf("Expected remaining key to survive, got %q (ok=%v)", value, ok)
+		}
+		if length := m.Len(); length != 1 {
+			t.Errorf("Expected length 1 after delete, got %d", length)
+		}
+	})
+}
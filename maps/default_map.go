@@ -0,0 +1,52 @@
+package maps
+
+// DefaultMap is an UnorderedMap that fills in missing keys on demand, like
+// Python's defaultdict: Load of a missing key stores factory(key) and returns
+// it with ok=true. Peek looks a key up without that side effect.
+//
+// Only Load invokes the factory. Range, Len and the inherited helpers
+// (LoadOrStore, CompareAndSwap and friends) see just the keys that have
+// actually been stored.
+type DefaultMap[K comparable, V any] struct {
+	*UnorderedMap[K, V]
+	factory func(key K) V
+}
+
+// NewDefaultMap creates an empty DefaultMap that produces missing values
+// with factory.
+func NewDefaultMap[K comparable, V any](factory func(key K) V) *DefaultMap[K, V] {
+	return &DefaultMap[K, V]{
+		UnorderedMap: NewUnorderedMap[K, V](),
+		factory:      factory,
+	}
+}
+
+// Clone returns an independent DefaultMap with the same factory and entries.
+func (dm *DefaultMap[K, V]) Clone() AbstractMap[K, V] {
+	return &DefaultMap[K, V]{
+		UnorderedMap: dm.UnorderedMap.Clone().(*UnorderedMap[K, V]),
+		factory:      dm.factory,
+	}
+}
+
+// readSafe overrides the embedded UnorderedMap: Load stores on a miss.
+func (dm *DefaultMap[K, V]) readSafe() bool { return false }
+
+// Load returns the value for key, storing and returning factory(key) if the
+// key is missing. ok is always true. A frozen DefaultMap returns
+// factory(key) without storing it.
+func (dm *DefaultMap[K, V]) Load(key K) (value V, ok bool) {
+	if value, ok := dm.UnorderedMap.Load(key); ok {
+		return value, true
+	}
+	value = dm.factory(key)
+	if !dm.IsFrozen() {
+		dm.UnorderedMap.Store(key, value)
+	}
+	return value, true
+}
+
+// Peek returns the value stored for key without invoking the factory.
+func (dm *DefaultMap[K, V]) Peek(key K) (value V, ok bool) {
+	return dm.UnorderedMap.Load(key)
+}
@@ -0,0 +1,103 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestDefaultMap(t *testing.T) {
+	t.Run("LoadInsertsDefault", func(t *testing.T) {
+		var requested []string
+		dm := maps.NewDefaultMap(func(key string) int {
+			requested = append(requested, key)
+			return len(key)
+		})
+
+		value, ok := dm.Load("hello")
+		if !ok || value != 5 {
+			t.Errorf("Expected (5, true), got (%d, %v)", value, ok)
+		}
+		if !slices.Equal(requested, []string{"hello"}) {
+			t.Errorf("Expected factory to receive [hello], got %v", requested)
+		}
+		if value, ok := dm.Peek("hello"); !ok || value != 5 {
+			t.Errorf("Expected default to be stored, Peek got (%d, %v)", value, ok)
+		}
+
+		// A second Load finds the stored value without calling the factory.
+		dm.Load("hello")
+		if len(requested) != 1 {
+			t.Errorf("Expected factory to run once, ran %d times", len(requested))
+		}
+	})
+
+	t.Run("PeekDoesNotInsert", func(t *testing.T) {
+		calls := 0
+		dm := maps.NewDefaultMap(func(key string) []string {
+			calls++
+			return nil
+		})
+
+		if _, ok := dm.Peek("missing"); ok {
+			t.Error("Expected Peek of a missing key to report false")
+		}
+		if calls != 0 || dm.Len() != 0 {
+			t.Errorf("Expected Peek to have no side effects, got %d calls and length %d", calls, dm.Len())
+		}
+	})
+
+	t.Run("IterationDoesNotTriggerFactory", func(t *testing.T) {
+		calls := 0
+		dm := maps.NewDefaultMap(func(key int) int {
+			calls++
+			return 0
+		})
+		dm.Store(1, 10)
+		dm.Store(2, 20)
+
+		visited := 0
+		dm.Range(func(key, value int) bool {
+			visited++
+			return true
+		})
+		if visited != 2 || dm.Len() != 2 {
+			t.Errorf("Expected 2 stored entries, Range saw %d and Len is %d", visited, dm.Len())
+		}
+		if calls != 0 {
+			t.Errorf("Expected no factory calls, got %d", calls)
+		}
+	})
+
+	t.Run("FrozenLoadDoesNotStore", func(t *testing.T) {
+		dm := maps.NewDefaultMap(func(key string) int { return len(key) })
+		dm.Store("a", 10)
+		dm.Freeze()
+
+		if value, ok := dm.Load("abc"); !ok || value != 3 {
+			t.Errorf("Expected Load on a frozen map to return 3, true; got %d, %v", value, ok)
+		}
+		if value, _ := dm.Load("a"); value != 10 {
+			t.Errorf("Expected stored value 10, got %d", value)
+		}
+		if _, ok := dm.Peek("abc"); ok || dm.Len() != 1 {
+			t.Errorf("Expected the default not to be stored, Len is %d", dm.Len())
+		}
+	})
+
+	t.Run("GroupingIdiom", func(t *testing.T) {
+		dm := maps.NewDefaultMap(func(key int) *[]string { return new([]string) })
+		for _, word := range []string{"go", "map", "set", "list"} {
+			bucket, _ := dm.Load(len(word))
+			*bucket = append(*bucket, word)
+		}
+
+		if bucket, _ := dm.Peek(3); !slices.Equal(*bucket, []string{"map", "set"}) {
+			t.Errorf("Expected [map set] for length 3, got %v", *bucket)
+		}
+		if dm.Len() != 3 {
+			t.Errorf("Expected 3 buckets, got %d", dm.Len())
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package maps
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// DiffString returns a human-readable, unified-diff-style description of the
+// differences between a and b, intended for test failure messages.
+// Entries only in a are prefixed with "-", entries only in b with "+", and
+// changed entries produce a "-" line for the old value followed by a "+" line
+// for the new one. Values are compared with reflect.DeepEqual and the output
+// is sorted by key so it is stable across runs.
+// Returns the empty string when both maps hold the same entries.
+func DiffString[K comparable, V any](a, b AbstractMap[K, V]) string {
+	type line struct {
+		key  K
+		text string
+	}
+	var lines []line
+	a.Range(func(key K, old V) bool {
+		if new, ok := b.Load(key); !ok {
+			lines = append(lines, line{key, fmt.Sprintf("- %v: %v\n", key, old)})
+		} else if !reflect.DeepEqual(old, new) {
+			lines = append(lines, line{key, fmt.Sprintf("- %v: %v\n+ %v: %v\n", key, old, key, new)})
+		}
+		return true
+	})
+	b.Range(func(key K, new V) bool {
+		if _, ok := a.Load(key); !ok {
+			lines = append(lines, line{key, fmt.Sprintf("+ %v: %v\n", key, new)})
+		}
+		return true
+	})
+
+	slices.SortStableFunc(lines, func(x, y line) int {
+		return compareAny(x.key, y.key)
+	})
+	var sb strings.Builder
+	for _, l := range lines {
+		sb.WriteString(l.text)
+	}
+	return sb.String()
+}
+
+// Diff reports how new differs from old: added holds the entries only in
+// new, removed the entries only in old, and changed maps each key present in
+// both with different values to its old and new value, in that order.
+// Values are compared with ==; use DiffFunc for non-comparable values.
+func Diff[K, V comparable](old, new AbstractMap[K, V]) (added, removed map[K]V, changed map[K][2]V) {
+	return DiffFunc(old, new, func(a, b V) bool { return a == b })
+}
+
+// DiffFunc is like Diff but compares values with eq.
+func DiffFunc[K comparable, V any](old, new AbstractMap[K, V], eq func(a, b V) bool) (added, removed map[K]V, changed map[K][2]V) {
+	added, removed, changed = map[K]V{}, map[K]V{}, map[K][2]V{}
+	old.Range(func(key K, before V) bool {
+		if after, ok := new.Load(key); !ok {
+			removed[key] = before
+		} else if !eq(before, after) {
+			changed[key] = [2]V{before, after}
+		}
+		return true
+	})
+	new.Range(func(key K, after V) bool {
+		if _, ok := old.Load(key); !ok {
+			added[key] = after
+		}
+		return true
+	})
+	return added, removed, changed
+}
+
+// compareAny orders two arbitrary values, comparing numbers and strings by
+// their natural order and falling back to their formatted representation.
+func compareAny(a, b any) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsValid() && vb.IsValid() && va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(va.Int(), vb.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return cmp.Compare(va.Uint(), vb.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(va.Float(), vb.Float())
+		case reflect.String:
+			return cmp.Compare(va.String(), vb.String())
+		}
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,113 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestDiffString(t *testing.T) {
+	t.Run("IdenticalMaps", func(t *testing.T) {
+		a := maps.NewUnorderedMap[string, int]()
+		b := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"x", "y", "z"} {
+			a.Store(key, i)
+			b.Store(key, i)
+		}
+
+		if diff := maps.DiffString[string, int](a, b); diff != "" {
+			t.Errorf("Expected empty diff for identical maps, got:\n%s", diff)
+		}
+	})
+
+	t.Run("EmptyMaps", func(t *testing.T) {
+		a := maps.NewUnorderedMap[int, int]()
+		b := maps.NewUnorderedMap[int, int]()
+
+		if diff := maps.DiffString[int, int](a, b); diff != "" {
+			t.Errorf("Expected empty diff for empty maps, got:\n%s", diff)
+		}
+	})
+
+	t.Run("AddedRemovedChanged", func(t *testing.T) {
+		a := maps.NewUnorderedMap[string, int]()
+		a.Store("kept", 1)
+		a.Store("changed", 2)
+		a.Store("removed", 3)
+
+		b := maps.NewUnorderedMap[string, int]()
+		b.Store("kept", 1)
+		b.Store("changed", 20)
+		b.Store("added", 4)
+
+		expected := "+ added: 4\n" +
+			"- changed: 2\n" +
+			"+ changed: 20\n" +
+			"- removed: 3\n"
+		if diff := maps.DiffString[string, int](a, b); diff != expected {
+			t.Errorf("Unexpected diff.\nExpected:\n%s\nGot:\n%s", expected, diff)
+		}
+	})
+
+	t.Run("NumericKeysSortNaturally", func(t *testing.T) {
+		a := maps.NewUnorderedMap[int, string]()
+		b := maps.NewUnorderedMap[int, string]()
+		b.Store(10, "ten")
+		b.Store(2, "two")
+
+		expected := "+ 2: two\n+ 10: ten\n"
+		if diff := maps.DiffString[int, string](a, b); diff != expected {
+			t.Errorf("Unexpected diff.\nExpected:\n%s\nGot:\n%s", expected, diff)
+		}
+	})
+
+	t.Run("SliceValues", func(t *testing.T) {
+		a := maps.NewUnorderedMap[string, []int]()
+		a.Store("same", []int{1, 2})
+		a.Store("diff", []int{1})
+
+		b := maps.NewUnorderedMap[string, []int]()
+		b.Store("same", []int{1, 2})
+		b.Store("diff", []int{1, 2, 3})
+
+		expected := "- diff: [1]\n+ diff: [1 2 3]\n"
+		if diff := maps.DiffString[string, []int](a, b); diff != expected {
+			t.Errorf("Unexpected diff.\nExpected:\n%s\nGot:\n%s", expected, diff)
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	old := maps.Of(maps.P("host", "a"), maps.P("port", "80"), maps.P("debug", "off"))
+	new := maps.Of(maps.P("host", "a"), maps.P("port", "8080"), maps.P("tls", "on"))
+
+	added, removed, changed := maps.Diff[string, string](old, new)
+	if len(added) != 1 || added["tls"] != "on" {
+		t.Errorf("Expected added {tls:on}, got %v", added)
+	}
+	if len(removed) != 1 || removed["debug"] != "off" {
+		t.Errorf("Expected removed {debug:off}, got %v", removed)
+	}
+	if len(changed) != 1 || changed["port"] != [2]string{"80", "8080"} {
+		t.Errorf("Expected changed {port:[80 8080]}, got %v", changed)
+	}
+
+	added, removed, changed = maps.Diff[string, string](old, old)
+	if len(added)+len(removed)+len(changed) != 0 {
+		t.Errorf("Expected no differences, got %v %v %v", added, removed, changed)
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	old := maps.Of(maps.P("a", []int{1, 2}), maps.P("b", []int{3}))
+	new := maps.Of(maps.P("a", []int{1, 2}), maps.P("b", []int{3, 4}))
+
+	added, removed, changed := maps.DiffFunc[string, []int](old, new, slices.Equal[[]int])
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("Expected no added or removed keys, got %v %v", added, removed)
+	}
+	if c, ok := changed["b"]; len(changed) != 1 || !ok || !slices.Equal(c[1], []int{3, 4}) {
+		t.Errorf("Expected only b to change, got %v", changed)
+	}
+}
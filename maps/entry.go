@@ -0,0 +1,113 @@
+package maps
+
+import "container/list"
+
+// MapEntry is a handle on a single key of a live map, returned by Entry. It
+// lets callers read and then update or delete a key without naming it again.
+// The handle always reflects the map's current state: if the key is deleted
+// through another path, Exists reports false and Set stores it anew.
+type MapEntry[K, V any] struct {
+	key K
+	b   entryBackend[V]
+}
+
+// entryBackend performs the operations of a MapEntry on a particular map.
+type entryBackend[V any] interface {
+	load() (V, bool)
+	store(value V)
+	delete()
+}
+
+// Key returns the key the handle refers to.
+func (e *MapEntry[K, V]) Key() K {
+	return e.key
+}
+
+// Value returns the value currently stored for the key.
+func (e *MapEntry[K, V]) Value() (V, bool) {
+	return e.b.load()
+}
+
+// Exists reports whether the key is currently present.
+func (e *MapEntry[K, V]) Exists() bool {
+	_, ok := e.b.load()
+	return ok
+}
+
+// Set stores value for the key.
+func (e *MapEntry[K, V]) Set(value V) {
+	e.b.store(value)
+}
+
+// Delete removes the key from the map if present.
+func (e *MapEntry[K, V]) Delete() {
+	e.b.delete()
+}
+
+// Entry returns a handle on key in the map. The key does not need to be
+// present. Each operation on the handle looks the key up again; OrderedMap
+// overrides Entry to avoid that.
+func (m *DefaultAbstractMap[K, V]) Entry(key K) *MapEntry[K, V] {
+	return &MapEntry[K, V]{key: key, b: &lookupEntry[K, V]{m: m.impl, key: key}}
+}
+
+// lookupEntry backs a MapEntry with plain map operations.
+type lookupEntry[K, V any] struct {
+	m   AbstractMap[K, V]
+	key K
+}
+
+func (e *lookupEntry[K, V]) load() (V, bool) { return e.m.Load(e.key) }
+func (e *lookupEntry[K, V]) store(value V)   { e.m.Store(e.key, value) }
+func (e *lookupEntry[K, V]) delete()         { e.m.Delete(e.key) }
+
+// Entry returns a handle on key in the map. The key does not need to be
+// present. The handle caches the key's list element, so Value, Exists and
+// Set on a present key are O(1) without a hash lookup until an entry is
+// removed from the map, after which the element is looked up again.
+// Setting a key that is not present appends it like Store. Reading through
+// the handle does not count as an access for access order.
+func (om *OrderedMap[K, V]) Entry(key K) *MapEntry[K, V] {
+	return &MapEntry[K, V]{key: key, b: &orderedEntry[K, V]{om: om, key: key}}
+}
+
+// orderedEntry backs a MapEntry on an OrderedMap. element is valid while gen
+// matches the map's generation; a nil element means the key was absent.
+type orderedEntry[K comparable, V any] struct {
+	om      *OrderedMap[K, V]
+	key     K
+	element *list.Element
+	gen     int
+}
+
+// lookup returns the key's list element, refreshing the cache if entries
+// were removed since it was taken.
+func (e *orderedEntry[K, V]) lookup() *list.Element {
+	if e.gen != e.om.gen || e.element == nil {
+		e.element = e.om.m[e.key]
+		e.gen = e.om.gen
+	}
+	return e.element
+}
+
+func (e *orderedEntry[K, V]) load() (value V, ok bool) {
+	if element := e.lookup(); element != nil {
+		return element.Value.(*entry[K, V]).value, true
+	}
+	return value, false
+}
+
+func (e *orderedEntry[K, V]) store(value V) {
+	e.om.checkMutable("Set")
+	element := e.lookup()
+	if element == nil {
+		e.om.Store(e.key, value)
+		return
+	}
+	element.Value.(*entry[K, V]).value = value
+	e.om.stored(e.key, value)
+}
+
+func (e *orderedEntry[K, V]) delete() {
+	e.om.Delete(e.key)
+}
@@ -0,0 +1,92 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestEntry(t *testing.T) {
+	type entryMap interface {
+		maps.AbstractMap[string, int]
+		Entry(key string) *maps.MapEntry[string, int]
+	}
+	factories := map[string]func() entryMap{
+		"OrderedMap":   func() entryMap { return maps.NewOrderedMap[string, int]() },
+		"UnorderedMap": func() entryMap { return maps.NewUnorderedMap[string, int]() },
+		"SortedMap": func() entryMap {
+			return maps.NewSortedMap[string, int](func(a, b string) bool { return a < b })
+		},
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			m.Store("hits", 1)
+
+			e := m.Entry("hits")
+			if e.Key() != "hits" {
+				t.Errorf("Expected key hits, got %q", e.Key())
+			}
+			for range 3 {
+				v, _ := e.Value()
+				e.Set(v + 1)
+			}
+			if v, _ := m.Load("hits"); v != 4 {
+				t.Errorf("Expected 4 after read-modify-write, got %d", v)
+			}
+
+			m.Delete("hits")
+			if e.Exists() {
+				t.Error("Expected Exists to be false after deleting through the map")
+			}
+			if _, ok := e.Value(); ok {
+				t.Error("Expected Value to report false after deleting through the map")
+			}
+
+			e.Set(10)
+			if v, ok := m.Load("hits"); !ok || v != 10 {
+				t.Errorf("Expected Set to store the key again, got %d, %v", v, ok)
+			}
+
+			m.Store("hits", 20)
+			if v, _ := e.Value(); v != 20 {
+				t.Errorf("Expected the handle to see a Store through the map, got %d", v)
+			}
+
+			e.Delete()
+			if _, ok := m.Load("hits"); ok {
+				t.Error("Expected Delete through the handle to remove the key")
+			}
+
+			missing := m.Entry("missing")
+			if missing.Exists() {
+				t.Error("Expected a handle on a missing key not to exist")
+			}
+			m.Store("missing", 1)
+			if !missing.Exists() {
+				t.Error("Expected the handle to see the key once stored")
+			}
+		})
+	}
+}
+
+func TestOrderedMapEntryKeepsPosition(t *testing.T) {
+	om := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 3))
+	e := om.Entry("b")
+	e.Set(20)
+	om.Delete("a")
+	e.Set(200)
+	if got := om.KeysSlice(); !slices.Equal(got, []string{"b", "c"}) {
+		t.Errorf("Expected keys [b c], got %v", got)
+	}
+	if v, _ := om.Load("b"); v != 200 {
+		t.Errorf("Expected 200, got %d", v)
+	}
+
+	om.Clear()
+	if e.Exists() {
+		t.Error("Expected Exists to be false after Clear")
+	}
+}
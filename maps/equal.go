@@ -0,0 +1,55 @@
+package maps
+
+import "iter"
+
+// Equal reports whether a and b contain the same key-value pairs.
+// Iteration order is ignored, so an OrderedMap equals any map holding the
+// same entries regardless of insertion order.
+func Equal[K comparable, V comparable](a, b AbstractMap[K, V]) bool {
+	return EqualFunc(a, b, func(x, y V) bool { return x == y })
+}
+
+// EqualFunc is like Equal but compares values using eq, for value types
+// that are not comparable. Both maps are only ranged over, never probed with
+// Load, so maps that change on Load, such as DefaultMap or LRUMap, are left
+// as they were.
+func EqualFunc[K comparable, V any](a, b AbstractMap[K, V], eq func(x, y V) bool) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	others := make(map[K]V, b.Len())
+	b.Range(func(key K, y V) bool {
+		others[key] = y
+		return true
+	})
+	equal := true
+	a.Range(func(key K, x V) bool {
+		y, ok := others[key]
+		equal = ok && eq(x, y)
+		return equal
+	})
+	return equal
+}
+
+// EqualOrdered reports whether a and b yield the same key-value pairs in the
+// same Range order. It is only meaningful for order-preserving
+// implementations such as OrderedMap or SortedMap; for an UnorderedMap the
+// result depends on its unspecified iteration order.
+func EqualOrdered[K comparable, V comparable](a, b AbstractMap[K, V]) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	next, stop := iter.Pull2(iter.Seq2[K, V](b.Range))
+	defer stop()
+	equal := true
+	a.Range(func(key K, x V) bool {
+		k, y, ok := next()
+		equal = ok && k == key && x == y
+		return equal
+	})
+	if !equal {
+		return false
+	}
+	_, _, more := next()
+	return !more
+}
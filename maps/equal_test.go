@@ -0,0 +1,143 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestEqual(t *testing.T) {
+	newMap := func(pairs ...TestCase[string, int]) maps.AbstractMap[string, int] {
+		m := maps.NewUnorderedMap[string, int]()
+		for _, p := range pairs {
+			m.Store(p.Key, p.Value)
+		}
+		return m
+	}
+
+	t.Run("EqualMaps", func(t *testing.T) {
+		a := newMap(TestCase[string, int]{"a", 1}, TestCase[string, int]{"b", 2})
+		b := newMap(TestCase[string, int]{"b", 2}, TestCase[string, int]{"a", 1})
+		if !maps.Equal(a, b) {
+			t.Error("Expected maps with the same entries to be equal")
+		}
+	})
+
+	t.Run("EmptyMaps", func(t *testing.T) {
+		if !maps.Equal(newMap(), newMap()) {
+			t.Error("Expected empty maps to be equal")
+		}
+	})
+
+	t.Run("DifferentValue", func(t *testing.T) {
+		a := newMap(TestCase[string, int]{"a", 1}, TestCase[string, int]{"b", 2})
+		b := newMap(TestCase[string, int]{"a", 1}, TestCase[string, int]{"b", 3})
+		if maps.Equal(a, b) {
+			t.Error("Expected maps differing in one value to be unequal")
+		}
+	})
+
+	t.Run("DifferentKeys", func(t *testing.T) {
+		a := newMap(TestCase[string, int]{"a", 1})
+		b := newMap(TestCase[string, int]{"b", 1})
+		if maps.Equal(a, b) {
+			t.Error("Expected maps with different keys to be unequal")
+		}
+	})
+
+	t.Run("DifferentLengths", func(t *testing.T) {
+		a := newMap(TestCase[string, int]{"a", 1})
+		b := newMap(TestCase[string, int]{"a", 1}, TestCase[string, int]{"b", 2})
+		if maps.Equal(a, b) || maps.Equal(b, a) {
+			t.Error("Expected maps with different lengths to be unequal")
+		}
+	})
+
+	t.Run("OrderIgnored", func(t *testing.T) {
+		a := maps.NewOrderedMap[string, int]()
+		a.Store("x", 1)
+		a.Store("y", 2)
+		b := maps.NewOrderedMap[string, int]()
+		b.Store("y", 2)
+		b.Store("x", 1)
+		if !maps.Equal[string, int](a, b) {
+			t.Error("Expected OrderedMaps with different insertion order to be equal")
+		}
+	})
+
+	t.Run("DefaultMapNotFilled", func(t *testing.T) {
+		a := newMap(TestCase[string, int]{"x", 0})
+		b := maps.NewDefaultMap(func(key string) int { return 0 })
+		b.Store("y", 0)
+		if maps.Equal[string, int](a, b) {
+			t.Error("Expected maps with different keys to be unequal")
+		}
+		if b.Len() != 1 {
+			t.Errorf("Expected Equal not to store defaults in b, Len is %d", b.Len())
+		}
+	})
+
+	t.Run("LRUMapOrderKept", func(t *testing.T) {
+		a := maps.Of(maps.P("b", 2), maps.P("a", 1))
+		b := maps.NewLRUMap[string, int](2)
+		b.Store("a", 1)
+		b.Store("b", 2)
+		before := slices.Collect(b.Keys)
+		if !maps.Equal[string, int](a, b) {
+			t.Error("Expected maps with the same entries to be equal")
+		}
+		if after := slices.Collect(b.Keys); !slices.Equal(before, after) {
+			t.Errorf("Expected Equal to keep the recency order %v, got %v", before, after)
+		}
+	})
+
+	t.Run("EqualFuncSliceValues", func(t *testing.T) {
+		a := maps.NewUnorderedMap[string, []int]()
+		a.Store("evens", []int{2, 4})
+		b := maps.NewOrderedMap[string, []int]()
+		b.Store("evens", []int{2, 4})
+
+		if !maps.EqualFunc[string, []int](a, b, slices.Equal[[]int]) {
+			t.Error("Expected slice-valued maps with equal contents to be equal")
+		}
+
+		b.Store("evens", []int{2, 4, 6})
+		if maps.EqualFunc[string, []int](a, b, slices.Equal[[]int]) {
+			t.Error("Expected slice-valued maps with different contents to be unequal")
+		}
+	})
+}
+
+func TestEqualOrdered(t *testing.T) {
+	ab := maps.NewOrderedMap[string, int]()
+	ab.Store("a", 1)
+	ab.Store("b", 2)
+
+	ba := maps.NewOrderedMap[string, int]()
+	ba.Store("b", 2)
+	ba.Store("a", 1)
+
+	if !maps.Equal[string, int](ab, ba) {
+		t.Error("Expected Equal to ignore insertion order")
+	}
+	if maps.EqualOrdered[string, int](ab, ba) {
+		t.Error("Expected EqualOrdered to detect different insertion order")
+	}
+
+	same := ab.Clone()
+	if !maps.EqualOrdered(ab, same) {
+		t.Error("Expected EqualOrdered to hold for a clone")
+	}
+
+	same.Store("b", 3)
+	if maps.EqualOrdered(ab, same) {
+		t.Error("Expected EqualOrdered to detect a different value")
+	}
+
+	longer := ab.Clone()
+	longer.Store("c", 3)
+	if maps.EqualOrdered(ab, longer) || maps.EqualOrdered(longer, ab) {
+		t.Error("Expected EqualOrdered to detect a different length")
+	}
+}
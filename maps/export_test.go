@@ -0,0 +1,19 @@
+package maps
+
+// OrderedMapListLen exposes the length of om's order list, so tests can
+// check that it always matches the key index.
+func OrderedMapListLen[K comparable, V any](om *OrderedMap[K, V]) int {
+	return om.l.Len()
+}
+
+// CacheWaiters exposes the number of callers waiting for c's load of key,
+// so tests can release a blocked loader once every caller has joined.
+func CacheWaiters[K comparable, V any](c *Cache[K, V], key K) int {
+	return c.flight.waiting(key)
+}
+
+// CachingMapWaiters exposes the number of callers waiting for cm's load of
+// key, like CacheWaiters.
+func CachingMapWaiters[K comparable, V any](cm *CachingMap[K, V], key K) int {
+	return cm.flight.waiting(key)
+}
@@ -0,0 +1,132 @@
+package maps
+
+import "container/list"
+
+// FIFOMap implements AbstractMap with a fixed capacity, evicting the
+// oldest-inserted entry when a Store would exceed it. Unlike LRUMap, neither
+// Load nor updating an existing key changes eviction order, which makes it
+// suitable as a simple deduplication window.
+// The list is kept in insertion order with the oldest entry at the front, and
+// Range iterates from oldest to newest.
+type FIFOMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, V]
+	m        map[K]*list.Element // Maps keys to their corresponding list elements
+	l        *list.List          // Oldest entry at the front
+	capacity int
+	onEvict  func(key K, value V)
+}
+
+// NewFIFOMap creates an empty FIFOMap holding at most capacity entries.
+// It panics if capacity is less than one.
+func NewFIFOMap[K comparable, V any](capacity int) *FIFOMap[K, V] {
+	if capacity < 1 {
+		panic("maps: NewFIFOMap capacity must be at least 1")
+	}
+	fm := &FIFOMap[K, V]{
+		m:        make(map[K]*list.Element),
+		l:        list.New(),
+		capacity: capacity,
+	}
+	fm.DefaultAbstractMap = NewDefaultAbstractMap(fm)
+	return fm
+}
+
+// OnEvict registers f to be called with each entry evicted to make room for
+// a new one. Explicit Delete and Clear calls do not trigger it.
+// Passing nil removes a previously registered callback.
+func (fm *FIFOMap[K, V]) OnEvict(f func(key K, value V)) {
+	fm.onEvict = f
+}
+
+// Cap returns the maximum number of entries the map retains.
+func (fm *FIFOMap[K, V]) Cap() int {
+	return fm.capacity
+}
+
+// Clear removes all entries without invoking the eviction callback.
+func (fm *FIFOMap[K, V]) Clear() {
+	fm.checkMutable("Clear")
+	fm.cleared(fm.Range, func() {
+		clear(fm.m)
+		fm.l.Init()
+	})
+}
+
+// Clone returns an independent FIFOMap with the same capacity, eviction
+// callback, entries and insertion order.
+// Time complexity: O(n)
+func (fm *FIFOMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := NewFIFOMap[K, V](fm.capacity)
+	clone.onEvict = fm.onEvict
+	for element := fm.l.Front(); element != nil; element = element.Next() {
+		e := *element.Value.(*entry[K, V])
+		clone.m[e.key] = clone.l.PushBack(&e)
+	}
+	return clone
+}
+
+// Delete removes key from the map if present.
+// Time complexity: O(1)
+func (fm *FIFOMap[K, V]) Delete(key K) {
+	fm.checkMutable("Delete")
+	if element, exists := fm.m[key]; exists {
+		delete(fm.m, key)
+		fm.l.Remove(element)
+		fm.deleted(key, element.Value.(*entry[K, V]).value)
+	}
+}
+
+// Len returns the number of entries in the map.
+// Time complexity: O(1)
+func (fm *FIFOMap[K, V]) Len() int {
+	return len(fm.m)
+}
+
+// Load returns the value stored for key. It does not affect eviction order.
+// Time complexity: O(1)
+func (fm *FIFOMap[K, V]) Load(key K) (value V, ok bool) {
+	if element, exists := fm.m[key]; exists {
+		return element.Value.(*entry[K, V]).value, true
+	}
+	return value, false
+}
+
+// Range calls f for each entry from oldest to newest until f returns false.
+// Time complexity: O(n)
+func (fm *FIFOMap[K, V]) Range(f func(key K, value V) bool) {
+	for element := fm.l.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*entry[K, V])
+		if !f(entry.key, entry.value) {
+			break
+		}
+	}
+}
+
+// Store sets the value for key. Updating an existing key keeps its original
+// insertion position and never evicts; inserting a new key into a full map
+// evicts the oldest entry first.
+// Time complexity: O(1)
+func (fm *FIFOMap[K, V]) Store(key K, value V) {
+	fm.checkMutable("Store")
+	if element, exists := fm.m[key]; exists {
+		element.Value.(*entry[K, V]).value = value
+		fm.stored(key, value)
+		return
+	}
+	fm.m[key] = fm.l.PushBack(&entry[K, V]{key: key, value: value})
+	fm.stored(key, value)
+	if len(fm.m) > fm.capacity {
+		fm.evict()
+	}
+}
+
+// evict removes the oldest entry and reports it to onEvict.
+func (fm *FIFOMap[K, V]) evict() {
+	element := fm.l.Front()
+	entry := fm.l.Remove(element).(*entry[K, V])
+	delete(fm.m, entry.key)
+	fm.deleted(entry.key, entry.value)
+	if fm.onEvict != nil {
+		fm.onEvict(entry.key, entry.value)
+	}
+}
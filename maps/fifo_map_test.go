@@ -0,0 +1,110 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestFIFOMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewFIFOMap[string, string](10)
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestFIFOMapEviction(t *testing.T) {
+	t.Run("EvictsFirstInserted", func(t *testing.T) {
+		const capacity = 4
+		fm := maps.NewFIFOMap[int, string](capacity)
+
+		var evicted []int
+		fm.OnEvict(func(key int, value string) {
+			evicted = append(evicted, key)
+		})
+
+		for i := 0; i < capacity+2; i++ {
+			fm.Store(i, "value")
+		}
+
+		if !slices.Equal(evicted, []int{0, 1}) {
+			t.Errorf("Expected keys [0 1] to be evicted, got %v", evicted)
+		}
+		for _, key := range []int{0, 1} {
+			if _, ok := fm.Load(key); ok {
+				t.Errorf("Expected key %d to be evicted", key)
+			}
+		}
+		if fm.Len() != capacity {
+			t.Errorf("Expected length %d, got %d", capacity, fm.Len())
+		}
+	})
+
+	t.Run("LoadDoesNotRefresh", func(t *testing.T) {
+		fm := maps.NewFIFOMap[string, int](3)
+		fm.Store("a", 1)
+		fm.Store("b", 2)
+		fm.Store("c", 3)
+
+		fm.Load("a")
+		fm.Store("d", 4)
+
+		if _, ok := fm.Load("a"); ok {
+			t.Error("Expected a to be evicted despite being loaded")
+		}
+	})
+
+	t.Run("UpdateKeepsPosition", func(t *testing.T) {
+		fm := maps.NewFIFOMap[string, int](2)
+		evictions := 0
+		fm.OnEvict(func(string, int) { evictions++ })
+
+		fm.Store("a", 1)
+		fm.Store("b", 2)
+		fm.Store("a", 10)
+		if evictions != 0 {
+			t.Errorf("Expected no evictions on update, got %d", evictions)
+		}
+
+		fm.Store("c", 3)
+		if _, ok := fm.Load("a"); ok {
+			t.Error("Expected updated key a to remain the oldest and be evicted")
+		}
+		if keys := fm.KeysSlice(); !slices.Equal(keys, []string{"b", "c"}) {
+			t.Errorf("Expected keys [b c], got %v", keys)
+		}
+	})
+
+	t.Run("InvalidCapacityPanics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected NewFIFOMap(0) to panic")
+			}
+		}()
+		maps.NewFIFOMap[string, int](0)
+	})
+}
+
+func TestFIFOMapClone(t *testing.T) {
+	fm := maps.NewFIFOMap[string, int](2)
+	fm.Store("a", 1)
+	fm.Store("b", 2)
+
+	clone := fm.Clone()
+	clone.Store("c", 3)
+
+	if _, ok := fm.Load("a"); !ok {
+		t.Error("Expected original to keep a after clone evicts")
+	}
+	if _, ok := clone.Load("a"); ok {
+		t.Error("Expected clone to evict its oldest entry a")
+	}
+}
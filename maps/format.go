@@ -0,0 +1,41 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatMap renders the entries yielded by seq as map[k1:v1 k2:v2], the
+// same shape fmt uses for builtin maps.
+func formatMap[K, V any](seq func(yield func(K, V) bool)) string {
+	var b strings.Builder
+	b.WriteString("map[")
+	first := true
+	for k, v := range seq {
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		fmt.Fprintf(&b, "%v:%v", k, v)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// goFormatMap renders the entries yielded by seq as a Go-syntax composite
+// literal prefixed with the dynamic type of m, for use by GoString.
+func goFormatMap[K, V any](m any, seq func(yield func(K, V) bool)) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimPrefix(fmt.Sprintf("%T", m), "*"))
+	b.WriteByte('{')
+	first := true
+	for k, v := range seq {
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+		fmt.Fprintf(&b, "%#v:%#v", k, v)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package maps_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestOrderedMapStringer(t *testing.T) {
+	om := maps.NewOrderedMap[string, int]()
+	om.Store("zeta", 1)
+	om.Store("alpha", 2)
+	om.Store("mid", 3)
+
+	t.Run("String", func(t *testing.T) {
+		expected := "map[zeta:1 alpha:2 mid:3]"
+		if got := om.String(); got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+		if got := fmt.Sprint(om); got != expected {
+			t.Errorf("Expected fmt.Sprint to use String, got %q", got)
+		}
+	})
+
+	t.Run("GoString", func(t *testing.T) {
+		expected := `maps.OrderedMap[string,int]{"zeta":1, "alpha":2, "mid":3}`
+		if got := fmt.Sprintf("%#v", om); got != expected {
+			t.Errorf("Expected %s, got %s", expected, got)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if got := maps.NewOrderedMap[string, int]().String(); got != "map[]" {
+			t.Errorf("Expected map[], got %q", got)
+		}
+	})
+}
+
+func TestUnorderedMapStringer(t *testing.T) {
+	um := maps.NewUnorderedMap[string, int]()
+	um.Store("only", 1)
+
+	if got := um.String(); got != "map[only:1]" {
+		t.Errorf("Expected map[only:1], got %q", got)
+	}
+	expected := `maps.UnorderedMap[string,int]{"only":1}`
+	if got := fmt.Sprintf("%#v", um); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
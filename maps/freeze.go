@@ -0,0 +1,29 @@
+package maps
+
+import "fmt"
+
+// Freeze makes the map immutable in place: from then on every method that
+// would change its entries or their order panics with an error wrapping
+// ErrReadOnly, while reads keep working without the indirection of a
+// ReadOnly view. Freezing cannot be undone, but Clone returns an unfrozen
+// copy. Freeze the map once it is fully built and before sharing it.
+//
+// Maps that reorder entries on Load, such as LRUMap, LFUMap and an
+// access-ordered OrderedMap, keep doing so when frozen, and a TTLMap still
+// drops expired entries.
+func (m *DefaultAbstractMap[K, V]) Freeze() {
+	m.frozen.Store(true)
+}
+
+// IsFrozen reports whether Freeze has been called.
+func (m *DefaultAbstractMap[K, V]) IsFrozen() bool {
+	return m != nil && m.frozen.Load()
+}
+
+// checkMutable panics if the map is frozen. Implementations call it at the
+// start of every mutating method, naming that method.
+func (m *DefaultAbstractMap[K, V]) checkMutable(method string) {
+	if m.IsFrozen() {
+		panic(fmt.Errorf("%w: %s called on a frozen map", ErrReadOnly, method))
+	}
+}
@@ -0,0 +1,143 @@
+package maps_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestFreeze(t *testing.T) {
+	type freezable interface {
+		maps.AbstractMap[string, int]
+		Freeze()
+		IsFrozen() bool
+		Clone() maps.AbstractMap[string, int]
+	}
+	factories := map[string]func() freezable{
+		"UnorderedMap": func() freezable { return maps.NewUnorderedMap[string, int]() },
+		"OrderedMap":   func() freezable { return maps.NewOrderedMap[string, int]() },
+		"SortedMap": func() freezable {
+			return maps.NewSortedMap[string, int](func(a, b string) bool { return a < b })
+		},
+		"TrieMap":        func() freezable { return maps.NewTrieMap[int]() },
+		"BiMap":          func() freezable { return maps.NewBiMap[string, int]() },
+		"LRUMap":         func() freezable { return maps.NewLRUMap[string, int](10) },
+		"ConcurrentMap":  func() freezable { return maps.NewConcurrentMap[string, int]() },
+		"ShardedMap":     func() freezable { return maps.NewShardedMap[string, int](4) },
+		"StripedMap":     func() freezable { return maps.NewStripedMap[string, int](4) },
+		"CopyOnWriteMap": func() freezable { return maps.NewCopyOnWriteMap[string, int]() },
+	}
+
+	mutators := map[string]func(m freezable){
+		"Store":            func(m freezable) { m.Store("b", 2) },
+		"Delete":           func(m freezable) { m.Delete("a") },
+		"DeleteMissing":    func(m freezable) { m.Delete("missing") },
+		"Clear":            func(m freezable) { m.Clear() },
+		"LoadOrStore":      func(m freezable) { m.LoadOrStore("a", 9) },
+		"LoadAndDelete":    func(m freezable) { m.LoadAndDelete("a") },
+		"Swap":             func(m freezable) { m.Swap("a", 9) },
+		"CompareAndSwap":   func(m freezable) { m.CompareAndSwap("a", 1, 9) },
+		"CompareAndDelete": func(m freezable) { m.CompareAndDelete("a", 1) },
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			m.Store("a", 1)
+			if m.IsFrozen() {
+				t.Fatal("Expected a new map not to be frozen")
+			}
+			m.Freeze()
+			if !m.IsFrozen() {
+				t.Fatal("Expected IsFrozen after Freeze")
+			}
+
+			if v, ok := m.Load("a"); !ok || v != 1 {
+				t.Errorf("Expected reads to keep working, got %d, %v", v, ok)
+			}
+			if m.Len() != 1 {
+				t.Errorf("Expected length 1, got %d", m.Len())
+			}
+
+			for op, mutate := range mutators {
+				func() {
+					defer func() {
+						err, _ := recover().(error)
+						if !errors.Is(err, maps.ErrReadOnly) {
+							t.Errorf("%s: expected a panic wrapping ErrReadOnly, got %v", op, err)
+						}
+					}()
+					mutate(m)
+				}()
+			}
+
+			if v, ok := m.Load("a"); !ok || v != 1 || m.Len() != 1 {
+				t.Errorf("Expected the map to be unchanged, got a=%d, %v with length %d", v, ok, m.Len())
+			}
+
+			clone := m.Clone()
+			clone.Store("b", 2)
+			if v, ok := clone.Load("b"); !ok || v != 2 {
+				t.Error("Expected a clone of a frozen map to be mutable")
+			}
+		})
+	}
+
+	t.Run("BiMapInverse", func(t *testing.T) {
+		bm := maps.NewBiMap[string, int]()
+		bm.Store("a", 1)
+		inverse := bm.Inverse()
+		bm.Freeze()
+		if !inverse.IsFrozen() {
+			t.Error("Expected freezing a BiMap to freeze its Inverse view")
+		}
+		for op, mutate := range map[string]func(){
+			"Store":  func() { inverse.Store(2, "z") },
+			"Delete": func() { inverse.Delete(1) },
+			"Clear":  func() { inverse.Clear() },
+		} {
+			func() {
+				defer func() {
+					err, _ := recover().(error)
+					if !errors.Is(err, maps.ErrReadOnly) {
+						t.Errorf("%s: expected a panic wrapping ErrReadOnly, got %v", op, err)
+					}
+				}()
+				mutate()
+			}()
+		}
+		if bm.Len() != 1 {
+			t.Errorf("Expected the frozen BiMap to keep length 1, got %d", bm.Len())
+		}
+
+		other := maps.NewBiMap[string, int]()
+		other.Inverse().Freeze()
+		if !other.IsFrozen() {
+			t.Error("Expected freezing an Inverse view to freeze the BiMap")
+		}
+	})
+
+	t.Run("OrderedMapExtras", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 1), maps.P("b", 2))
+		om.Freeze()
+		mutators := map[string]func(){
+			"MoveToFront":  func() { om.MoveToFront("b") },
+			"InsertBefore": func() { om.InsertBefore("a", "c", 3) },
+			"SortByKey":    func() { om.SortByKey(func(a, b string) bool { return a > b }) },
+			"EntrySet":     func() { om.Entry("a").Set(10) },
+			"AtomicUpdate": func() { om.AtomicUpdate("a", func(int, bool) (int, bool) { return 0, false }) },
+			"Pop":          func() { om.Pop() },
+		}
+		for op, mutate := range mutators {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s: expected a panic on a frozen map", op)
+					}
+				}()
+				mutate()
+			}()
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+)
+
+// gobEntries is the wire form shared by the gob encodings: keys and values
+// in parallel slices, in the map's iteration order.
+type gobEntries[K, V any] struct {
+	Keys   []K
+	Values []V
+}
+
+func encodeGob[K, V any](n int, seq func(yield func(K, V) bool)) ([]byte, error) {
+	wire := gobEntries[K, V]{
+		Keys:   make([]K, 0, n),
+		Values: make([]V, 0, n),
+	}
+	for k, v := range seq {
+		wire.Keys = append(wire.Keys, k)
+		wire.Values = append(wire.Values, v)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(wire); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeGob[K, V any](data []byte) (gobEntries[K, V], error) {
+	var wire gobEntries[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&wire); err != nil {
+		return wire, err
+	}
+	if len(wire.Keys) != len(wire.Values) {
+		return wire, fmt.Errorf("maps: gob data has %d keys but %d values", len(wire.Keys), len(wire.Values))
+	}
+	return wire, nil
+}
+
+// GobEncode implements gob.GobEncoder, encoding the entries in insertion
+// order so that GobDecode restores it.
+func (om *OrderedMap[K, V]) GobEncode() ([]byte, error) {
+	return encodeGob(om.Len(), om.Range)
+}
+
+// GobDecode implements gob.GobDecoder, replacing the map's contents with the
+// decoded entries in their encoded order. If data is invalid the map is left
+// untouched and an error is returned.
+func (om *OrderedMap[K, V]) GobDecode(data []byte) error {
+	om.checkMutable("GobDecode")
+	wire, err := decodeGob[K, V](data)
+	if err != nil {
+		return err
+	}
+	if om.l == nil {
+		om.init()
+	} else {
+		om.Clear()
+	}
+	om.Grow(len(wire.Keys))
+	for i, key := range wire.Keys {
+		om.Store(key, wire.Values[i])
+	}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (um *UnorderedMap[Key, Value]) GobEncode() ([]byte, error) {
+	return encodeGob(um.Len(), um.Range)
+}
+
+// GobDecode implements gob.GobDecoder, replacing the map's contents with the
+// decoded entries. If data is invalid the map is left untouched and an error
+// is returned.
+func (um *UnorderedMap[Key, Value]) GobDecode(data []byte) error {
+	um.checkMutable("GobDecode")
+	wire, err := decodeGob[Key, Value](data)
+	if err != nil {
+		return err
+	}
+	if um.DefaultAbstractMap == nil {
+		um.DefaultAbstractMap = NewDefaultAbstractMap(um)
+	}
+	if um.m == nil {
+		um.m = map[Key]Value{}
+	} else {
+		um.Clear()
+	}
+	um.Grow(len(wire.Keys))
+	for i, key := range wire.Keys {
+		um.Store(key, wire.Values[i])
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package maps_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestOrderedMapGobRoundTrip(t *testing.T) {
+	src := maps.NewOrderedMap[string, int]()
+	for i := 999; i >= 0; i-- {
+		src.Store(fmt.Sprintf("key-%d", i), i)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+		t.Fatalf("Expected encode to succeed, got %v", err)
+	}
+
+	dst := maps.NewOrderedMap[string, int]()
+	if err := gob.NewDecoder(&buf).Decode(dst); err != nil {
+		t.Fatalf("Expected decode to succeed, got %v", err)
+	}
+
+	if !maps.Equal[string, int](src, dst) {
+		t.Errorf("Expected decoded map to equal the original")
+	}
+	srcKeys, dstKeys := src.KeysSlice(), dst.KeysSlice()
+	for i := range srcKeys {
+		if srcKeys[i] != dstKeys[i] {
+			t.Fatalf("Position %d: expected %s, got %s", i, srcKeys[i], dstKeys[i])
+		}
+	}
+}
+
+func TestOrderedMapGobDecodeResets(t *testing.T) {
+	src := maps.NewOrderedMap[string, int]()
+	src.Store("a", 1)
+	data, err := src.GobEncode()
+	if err != nil {
+		t.Fatalf("Expected encode to succeed, got %v", err)
+	}
+
+	dst := maps.NewOrderedMap[string, int]()
+	dst.Store("stale", 99)
+	if err := dst.GobDecode(data); err != nil {
+		t.Fatalf("Expected decode to succeed, got %v", err)
+	}
+	if _, ok := dst.Load("stale"); ok {
+		t.Errorf("Expected stale entry to be removed by decode")
+	}
+	if dst.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", dst.Len())
+	}
+}
+
+func TestUnorderedMapGobRoundTrip(t *testing.T) {
+	type snapshot struct {
+		Scores *maps.UnorderedMap[string, int]
+	}
+
+	src := snapshot{Scores: maps.NewUnorderedMap[string, int]()}
+	src.Scores.Store("alice", 3)
+	src.Scores.Store("bob", 5)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+		t.Fatalf("Expected encode to succeed, got %v", err)
+	}
+
+	var dst snapshot
+	if err := gob.NewDecoder(&buf).Decode(&dst); err != nil {
+		t.Fatalf("Expected decode to succeed, got %v", err)
+	}
+	if !maps.Equal[string, int](src.Scores, dst.Scores) {
+		t.Errorf("Expected %v, got %v", src.Scores, dst.Scores)
+	}
+
+	// Methods inherited from DefaultAbstractMap must work on a decoded zero value.
+	if _, loaded := dst.Scores.LoadOrStore("carol", 7); loaded {
+		t.Errorf("Expected carol to be absent after decode")
+	}
+}
+
+func TestUnorderedMapGobDecodeNotifiesObservers(t *testing.T) {
+	src := maps.NewUnorderedMap[string, int]()
+	src.Store("a", 1)
+	data, err := src.GobEncode()
+	if err != nil {
+		t.Fatalf("Expected encode to succeed, got %v", err)
+	}
+
+	dst := maps.NewUnorderedMap[string, int]()
+	dst.Store("stale", 99)
+	var stored, deleted []string
+	dst.Observe(
+		func(key string, value int) { stored = append(stored, key) },
+		func(key string, value int) { deleted = append(deleted, key) },
+	)
+	if err := dst.GobDecode(data); err != nil {
+		t.Fatalf("Expected decode to succeed, got %v", err)
+	}
+	if !slices.Equal(deleted, []string{"stale"}) {
+		t.Errorf("Expected OnDelete for [stale], got %v", deleted)
+	}
+	if !slices.Equal(stored, []string{"a"}) {
+		t.Errorf("Expected OnStore for [a], got %v", stored)
+	}
+	if dst.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", dst.Len())
+	}
+}
+
+func TestGobDecodeInvalid(t *testing.T) {
+	om := maps.NewOrderedMap[string, int]()
+	om.Store("keep", 1)
+	if err := om.GobDecode([]byte("garbage")); err == nil {
+		t.Errorf("Expected an error decoding invalid data")
+	}
+	if om.Len() != 1 {
+		t.Errorf("Expected map to be untouched after failed decode, got length %d", om.Len())
+	}
+}
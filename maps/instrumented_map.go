@@ -0,0 +1,391 @@
+package maps
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// MapStats is a snapshot of the counters kept by an InstrumentedMap.
+type MapStats struct {
+	Hits    uint64 // Lookups that found the key
+	Misses  uint64 // Lookups that did not find the key
+	Stores  uint64 // Values stored
+	Deletes uint64 // Entries removed by a delete operation
+}
+
+// HitRatio returns the fraction of lookups that found their key, or 0 if
+// there were no lookups.
+func (s MapStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// InstrumentedMap wraps an AbstractMap and counts lookup hits and misses,
+// stores and deletes, for example to measure a cache's hit rate. Every
+// operation is forwarded to the inner map once, so an InstrumentedMap
+// around a concurrency-safe map is itself concurrency-safe. AtomicUpdate,
+// LoadOrCompute and the ...Func compare methods are forwarded to the inner
+// map's own methods, so they keep its atomicity. When the inner map's
+// AtomicUpdate holds a lock, ComputeIfAbsent and StoreIfPresent go through
+// it and are atomic too. Compute and ComputeIfPresent, which may delete,
+// are forwarded as they are; no map in this package runs them under a lock,
+// so another writer can interleave between their load and their store or
+// delete. The counters are updated atomically.
+//
+// Load counts a hit or a miss, and so do LoadOrStore, LoadOrCompute,
+// LoadAndDelete and the read-modify-write methods, which also count the
+// store or delete they make. CompareAndSwap and CompareAndDelete count a hit
+// and a store or delete when they succeed and a miss otherwise. Store and
+// Swap count a store, and Delete counts a delete when the key was present.
+// Clear, Range and the other bulk operations are not counted.
+type InstrumentedMap[K, V any] struct {
+	*DefaultAbstractMap[K, V]
+	inner   AbstractMap[K, V]
+	hits    atomic.Uint64
+	misses  atomic.Uint64
+	stores  atomic.Uint64
+	deletes atomic.Uint64
+}
+
+// NewInstrumentedMap creates an InstrumentedMap around inner.
+// The caller must not access inner directly afterwards.
+func NewInstrumentedMap[K, V any](inner AbstractMap[K, V]) *InstrumentedMap[K, V] {
+	im := &InstrumentedMap[K, V]{
+		inner: inner,
+	}
+	im.DefaultAbstractMap = NewDefaultAbstractMap[K, V](im)
+	return im
+}
+
+// Stats returns the current counter values.
+func (im *InstrumentedMap[K, V]) Stats() MapStats {
+	return MapStats{
+		Hits:    im.hits.Load(),
+		Misses:  im.misses.Load(),
+		Stores:  im.stores.Load(),
+		Deletes: im.deletes.Load(),
+	}
+}
+
+// ResetStats sets all counters back to zero.
+func (im *InstrumentedMap[K, V]) ResetStats() {
+	im.hits.Store(0)
+	im.misses.Store(0)
+	im.stores.Store(0)
+	im.deletes.Store(0)
+}
+
+// lookup counts a lookup that found the key if found, or a miss otherwise.
+func (im *InstrumentedMap[K, V]) lookup(found bool) {
+	if found {
+		im.hits.Add(1)
+	} else {
+		im.misses.Add(1)
+	}
+}
+
+func (im *InstrumentedMap[K, V]) Clear() {
+	im.checkMutable("Clear")
+	im.cleared(im.inner.Range, im.inner.Clear)
+}
+
+// Clone returns a new InstrumentedMap, with zeroed counters, around a clone
+// of the inner map. It panics if the inner map does not implement Cloner.
+func (im *InstrumentedMap[K, V]) Clone() AbstractMap[K, V] {
+	c, ok := im.inner.(Cloner[K, V])
+	if !ok {
+		panic(fmt.Sprintf("maps: cannot clone InstrumentedMap, inner %T does not implement Cloner", im.inner))
+	}
+	return NewInstrumentedMap(c.Clone())
+}
+
+func (im *InstrumentedMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	im.checkMutable("CompareAndDelete")
+	if deleted = im.inner.CompareAndDelete(key, old); deleted {
+		im.hits.Add(1)
+		im.deletes.Add(1)
+		im.deleted(key, old)
+	} else {
+		im.misses.Add(1)
+	}
+	return deleted
+}
+
+func (im *InstrumentedMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	im.checkMutable("CompareAndSwap")
+	if swapped = im.inner.CompareAndSwap(key, old, new); swapped {
+		im.hits.Add(1)
+		im.stores.Add(1)
+		im.stored(key, new)
+	} else {
+		im.misses.Add(1)
+	}
+	return swapped
+}
+
+func (im *InstrumentedMap[K, V]) Delete(key K) {
+	im.checkMutable("Delete")
+	if value, loaded := im.inner.LoadAndDelete(key); loaded {
+		im.deletes.Add(1)
+		im.deleted(key, value)
+	}
+}
+
+func (im *InstrumentedMap[K, V]) Keys(f func(key K) bool) {
+	im.inner.Keys(f)
+}
+
+func (im *InstrumentedMap[K, V]) Len() int {
+	return im.inner.Len()
+}
+
+func (im *InstrumentedMap[K, V]) Load(key K) (value V, ok bool) {
+	value, ok = im.inner.Load(key)
+	im.lookup(ok)
+	return value, ok
+}
+
+func (im *InstrumentedMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	im.checkMutable("LoadAndDelete")
+	value, loaded = im.inner.LoadAndDelete(key)
+	im.lookup(loaded)
+	if loaded {
+		im.deletes.Add(1)
+		im.deleted(key, value)
+	}
+	return value, loaded
+}
+
+func (im *InstrumentedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	im.checkMutable("LoadOrStore")
+	actual, loaded = im.inner.LoadOrStore(key, value)
+	im.lookup(loaded)
+	if !loaded {
+		im.stores.Add(1)
+		im.stored(key, value)
+	}
+	return actual, loaded
+}
+
+func (im *InstrumentedMap[K, V]) Range(f func(key K, value V) bool) {
+	im.inner.Range(f)
+}
+
+func (im *InstrumentedMap[K, V]) Store(key K, value V) {
+	im.checkMutable("Store")
+	im.inner.Store(key, value)
+	im.stores.Add(1)
+	im.stored(key, value)
+}
+
+func (im *InstrumentedMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	im.checkMutable("Swap")
+	previous, loaded = im.inner.Swap(key, value)
+	im.stores.Add(1)
+	im.stored(key, value)
+	return previous, loaded
+}
+
+func (im *InstrumentedMap[K, V]) Values(f func(value V) bool) {
+	im.inner.Values(f)
+}
+
+// AtomicUpdate forwards to the inner map's AtomicUpdate.
+func (im *InstrumentedMap[K, V]) AtomicUpdate(key K, f func(old V, loaded bool) (new V, store bool)) (V, bool) {
+	im.checkMutable("AtomicUpdate")
+	u, ok := im.inner.(atomicUpdater[K, V])
+	if !ok {
+		return im.DefaultAbstractMap.AtomicUpdate(key, f)
+	}
+	var found, stored bool
+	value, present := u.AtomicUpdate(key, func(old V, loaded bool) (V, bool) {
+		found = loaded
+		value, store := f(old, loaded)
+		stored = store
+		return value, store
+	})
+	im.lookup(found)
+	if stored {
+		im.stores.Add(1)
+		im.stored(key, value)
+	}
+	return value, present
+}
+
+// updatesUnderLock reports whether the inner map's AtomicUpdate, which
+// AtomicUpdate forwards to, holds a lock.
+func (im *InstrumentedMap[K, V]) updatesUnderLock() bool {
+	l, ok := im.inner.(lockedUpdater)
+	return ok && l.updatesUnderLock()
+}
+
+// Compute forwards to the inner map's Compute.
+func (im *InstrumentedMap[K, V]) Compute(key K, f func(key K, value V, loaded bool) (V, bool)) (V, bool) {
+	im.checkMutable("Compute")
+	c, ok := im.inner.(interface {
+		Compute(key K, f func(key K, value V, loaded bool) (V, bool)) (V, bool)
+	})
+	if !ok {
+		return im.DefaultAbstractMap.Compute(key, f)
+	}
+	var old V
+	var found bool
+	value, keep := c.Compute(key, func(key K, value V, loaded bool) (V, bool) {
+		old, found = value, loaded
+		return f(key, value, loaded)
+	})
+	im.lookup(found)
+	im.recordUpdate(key, old, found, value, keep)
+	return value, keep
+}
+
+// ComputeIfAbsent forwards to the inner map's ComputeIfAbsent, or to its
+// AtomicUpdate if that holds a lock.
+func (im *InstrumentedMap[K, V]) ComputeIfAbsent(key K, f func(key K) V) V {
+	im.checkMutable("ComputeIfAbsent")
+	if im.updatesUnderLock() {
+		value, _ := im.AtomicUpdate(key, func(old V, loaded bool) (V, bool) {
+			if loaded {
+				return old, false
+			}
+			return f(key), true
+		})
+		return value
+	}
+	c, ok := im.inner.(interface {
+		ComputeIfAbsent(key K, f func(key K) V) V
+	})
+	if !ok {
+		return im.DefaultAbstractMap.ComputeIfAbsent(key, f)
+	}
+	computed := false
+	value := c.ComputeIfAbsent(key, func(key K) V {
+		computed = true
+		return f(key)
+	})
+	im.lookup(!computed)
+	if computed {
+		im.stores.Add(1)
+		im.stored(key, value)
+	}
+	return value
+}
+
+// ComputeIfPresent forwards to the inner map's ComputeIfPresent.
+func (im *InstrumentedMap[K, V]) ComputeIfPresent(key K, f func(key K, value V) (V, bool)) (V, bool) {
+	im.checkMutable("ComputeIfPresent")
+	c, ok := im.inner.(interface {
+		ComputeIfPresent(key K, f func(key K, value V) (V, bool)) (V, bool)
+	})
+	if !ok {
+		return im.DefaultAbstractMap.ComputeIfPresent(key, f)
+	}
+	var old V
+	found := false
+	value, keep := c.ComputeIfPresent(key, func(key K, value V) (V, bool) {
+		old, found = value, true
+		return f(key, value)
+	})
+	im.lookup(found)
+	if found {
+		im.recordUpdate(key, old, true, value, keep)
+	}
+	return value, keep
+}
+
+// CompareAndDeleteFunc forwards to the inner map's CompareAndDeleteFunc.
+func (im *InstrumentedMap[K, V]) CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) (deleted bool) {
+	im.checkMutable("CompareAndDeleteFunc")
+	c, ok := im.inner.(interface {
+		CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) bool
+	})
+	if !ok {
+		return im.DefaultAbstractMap.CompareAndDeleteFunc(key, old, eq)
+	}
+	if deleted = c.CompareAndDeleteFunc(key, old, eq); deleted {
+		im.hits.Add(1)
+		im.deletes.Add(1)
+		im.deleted(key, old)
+	} else {
+		im.misses.Add(1)
+	}
+	return deleted
+}
+
+// CompareAndSwapFunc forwards to the inner map's CompareAndSwapFunc.
+func (im *InstrumentedMap[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) (swapped bool) {
+	im.checkMutable("CompareAndSwapFunc")
+	c, ok := im.inner.(interface {
+		CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) bool
+	})
+	if !ok {
+		return im.DefaultAbstractMap.CompareAndSwapFunc(key, old, new, eq)
+	}
+	if swapped = c.CompareAndSwapFunc(key, old, new, eq); swapped {
+		im.hits.Add(1)
+		im.stores.Add(1)
+		im.stored(key, new)
+	} else {
+		im.misses.Add(1)
+	}
+	return swapped
+}
+
+// LoadOrCompute forwards to the inner map's LoadOrCompute.
+func (im *InstrumentedMap[K, V]) LoadOrCompute(key K, f func() V) (actual V, loaded bool) {
+	im.checkMutable("LoadOrCompute")
+	c, ok := im.inner.(interface {
+		LoadOrCompute(key K, f func() V) (V, bool)
+	})
+	if !ok {
+		return im.DefaultAbstractMap.LoadOrCompute(key, f)
+	}
+	actual, loaded = c.LoadOrCompute(key, f)
+	im.lookup(loaded)
+	if !loaded {
+		im.stores.Add(1)
+		im.stored(key, actual)
+	}
+	return actual, loaded
+}
+
+// StoreIfPresent forwards to the inner map's StoreIfPresent, or to its
+// AtomicUpdate if that holds a lock.
+func (im *InstrumentedMap[K, V]) StoreIfPresent(key K, value V) bool {
+	im.checkMutable("StoreIfPresent")
+	if im.updatesUnderLock() {
+		_, stored := im.AtomicUpdate(key, func(_ V, loaded bool) (V, bool) {
+			return value, loaded
+		})
+		return stored
+	}
+	c, ok := im.inner.(interface {
+		StoreIfPresent(key K, value V) bool
+	})
+	if !ok {
+		return im.DefaultAbstractMap.StoreIfPresent(key, value)
+	}
+	stored := c.StoreIfPresent(key, value)
+	im.lookup(stored)
+	if stored {
+		im.stores.Add(1)
+		im.stored(key, value)
+	}
+	return stored
+}
+
+// recordUpdate counts and reports the outcome of a Compute-style update:
+// a store if the entry was kept, or a delete if a present entry was dropped.
+func (im *InstrumentedMap[K, V]) recordUpdate(key K, old V, found bool, value V, keep bool) {
+	switch {
+	case keep:
+		im.stores.Add(1)
+		im.stored(key, value)
+	case found:
+		im.deletes.Add(1)
+		im.deleted(key, old)
+	}
+}
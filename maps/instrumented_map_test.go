@@ -0,0 +1,150 @@
+package maps_test
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestInstrumentedMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewInstrumentedMap[string, string](maps.NewOrderedMap[string, string]())
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestInstrumentedMapStats(t *testing.T) {
+	im := maps.NewInstrumentedMap[string, int](maps.NewUnorderedMap[string, int]())
+	im.Store("a", 1)
+	im.Store("b", 2)
+	im.Load("a")
+	im.Load("b")
+	im.Load("a")
+	im.Load("missing")
+	im.Delete("b")
+	im.Delete("missing")
+	im.LoadOrStore("a", 9) // hit
+	im.LoadOrStore("c", 3) // miss and store
+
+	expected := maps.MapStats{Hits: 4, Misses: 2, Stores: 3, Deletes: 1}
+	if got := im.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+	if ratio := im.Stats().HitRatio(); ratio != 4.0/6.0 {
+		t.Errorf("Expected hit ratio 4/6, got %v", ratio)
+	}
+
+	im.ResetStats()
+	if got := im.Stats(); got != (maps.MapStats{}) || got.HitRatio() != 0 {
+		t.Errorf("Expected zeroed stats, got %+v", got)
+	}
+}
+
+func TestInstrumentedMapConcurrent(t *testing.T) {
+	im := maps.NewInstrumentedMap[string, int](maps.NewConcurrentMap[string, int]())
+	const goroutines, ops = 8, 250
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ops {
+				key := strconv.Itoa(g) + "/" + strconv.Itoa(i)
+				im.Store(key, i)
+				im.Load(key)
+				im.Load(key + "/missing")
+			}
+		}()
+	}
+	wg.Wait()
+
+	expected := maps.MapStats{Hits: goroutines * ops, Misses: goroutines * ops, Stores: goroutines * ops}
+	if got := im.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestInstrumentedMapAtomicUpdate(t *testing.T) {
+	im := maps.NewInstrumentedMap[string, int](maps.NewConcurrentMap[string, int]())
+	const goroutines, ops = 8, 500
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range ops {
+				im.AtomicUpdate("n", func(old int, _ bool) (int, bool) {
+					runtime.Gosched() // Invite a lost update if the load and store are not atomic
+					return old + 1, true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if v, _ := im.Load("n"); v != goroutines*ops {
+		t.Errorf("Expected %d, got %d", goroutines*ops, v)
+	}
+	// Every update but the first found the key, plus the Load above.
+	expected := maps.MapStats{Hits: goroutines * ops, Misses: 1, Stores: goroutines * ops}
+	if got := im.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestInstrumentedMapCompute(t *testing.T) {
+	im := maps.NewInstrumentedMap[string, int](maps.NewUnorderedMap[string, int]())
+	im.ComputeIfAbsent("a", func(string) int { return 1 })                            // miss and store
+	im.ComputeIfAbsent("a", func(string) int { return 2 })                            // hit
+	im.Compute("a", func(_ string, v int, _ bool) (int, bool) { return v + 1, true }) // hit and store
+	im.ComputeIfPresent("a", func(string, int) (int, bool) { return 0, false })       // hit and delete
+	im.ComputeIfPresent("a", func(string, int) (int, bool) { return 0, true })        // miss
+	im.LoadOrCompute("b", func() int { return 2 })                                    // miss and store
+	im.StoreIfPresent("b", 3)                                                         // hit and store
+
+	expected := maps.MapStats{Hits: 4, Misses: 3, Stores: 4, Deletes: 1}
+	if got := im.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestInstrumentedMapComputeIfAbsentConcurrent(t *testing.T) {
+	im := maps.NewInstrumentedMap[string, int](maps.NewConcurrentMap[string, int]())
+	const goroutines = 8
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			im.ComputeIfAbsent("key", func(string) int {
+				calls.Add(1)
+				runtime.Gosched() // Invite a second call if the load and store are not atomic
+				return g
+			})
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected f to run once, ran %d times", n)
+	}
+	expected := maps.MapStats{Hits: goroutines - 1, Misses: 1, Stores: 1}
+	if got := im.Stats(); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
@@ -0,0 +1,32 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// FromJSONArray streams a top-level JSON array from r, storing each element
+// in m under keyFn(element) as soon as it has been decoded, so the whole
+// document is never held in memory.
+// If the input is malformed, an error is returned and m keeps every element
+// stored before the one that failed; no partially decoded element is stored.
+func FromJSONArray[V any](m AbstractMap[string, V], r io.Reader, keyFn func(V) string) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("maps: cannot import %v, expected JSON array", tok)
+	}
+	for dec.More() {
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Store(keyFn(value), value)
+	}
+	_, err = dec.Token()
+	return err
+}
@@ -0,0 +1,73 @@
+package maps_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+type user struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func userID(u user) string { return u.ID }
+
+func TestFromJSONArray(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		input := `[{"id":"u2","name":"Bo"},{"id":"u1","name":"Al"},{"id":"u2","name":"Bea"}]`
+		om := maps.NewOrderedMap[string, user]()
+		if err := maps.FromJSONArray[user](om, strings.NewReader(input), userID); err != nil {
+			t.Fatalf("Expected import to succeed, got %v", err)
+		}
+		if keys := om.KeysSlice(); len(keys) != 2 || keys[0] != "u2" || keys[1] != "u1" {
+			t.Errorf("Expected keys [u2 u1], got %v", keys)
+		}
+		if u, _ := om.Load("u2"); u.Name != "Bea" {
+			t.Errorf("Expected later element to win for u2, got %s", u.Name)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		um := maps.NewUnorderedMap[string, user]()
+		if err := maps.FromJSONArray[user](um, strings.NewReader(` [ ] `), userID); err != nil {
+			t.Fatalf("Expected empty array to succeed, got %v", err)
+		}
+		if um.Len() != 0 {
+			t.Errorf("Expected empty map, got length %d", um.Len())
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		input := `[{"id":"u1","name":"Al"},{"id":"u2","name":"Bo"},{"id":"u3","na`
+		um := maps.NewUnorderedMap[string, user]()
+		um.Store("existing", user{ID: "existing"})
+
+		err := maps.FromJSONArray[user](um, strings.NewReader(input), userID)
+		if err == nil {
+			t.Fatal("Expected an error for a truncated stream")
+		}
+		if um.Len() != 3 {
+			t.Errorf("Expected existing entry plus 2 imported, got length %d", um.Len())
+		}
+		for _, key := range []string{"existing", "u1", "u2"} {
+			if _, ok := um.Load(key); !ok {
+				t.Errorf("Expected %s to be kept", key)
+			}
+		}
+		if _, ok := um.Load("u3"); ok {
+			t.Error("Expected the partial element not to be stored")
+		}
+	})
+
+	t.Run("NotAnArray", func(t *testing.T) {
+		um := maps.NewUnorderedMap[string, user]()
+		if err := maps.FromJSONArray[user](um, strings.NewReader(`{"id":"u1"}`), userID); err == nil {
+			t.Error("Expected an error for a JSON object")
+		}
+		if err := maps.FromJSONArray[user](um, strings.NewReader(`[{"id":"u1"} {"id":"u2"}]`), userID); err == nil {
+			t.Error("Expected an error for a missing comma")
+		}
+	})
+}
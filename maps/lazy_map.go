@@ -0,0 +1,65 @@
+package maps
+
+import "sync"
+
+// LazyMap memoizes an expensive function of its key. Each key is computed at
+// most once while its result is cached: concurrent Get calls for a key that
+// is not cached yet share a single computation. Errors are returned to every
+// caller sharing that computation but are not cached, so a later Get retries.
+type LazyMap[K comparable, V any] struct {
+	mu      sync.RWMutex
+	values  map[K]V
+	flight  flightGroup[K, V]
+	compute func(K) (V, error)
+}
+
+// NewLazyMap creates an empty LazyMap that fills itself with compute.
+func NewLazyMap[K comparable, V any](compute func(K) (V, error)) *LazyMap[K, V] {
+	return &LazyMap[K, V]{
+		values:  make(map[K]V),
+		compute: compute,
+	}
+}
+
+// Get returns the cached value for key, computing and caching it first if
+// needed. If compute fails, Get returns its error and nothing is cached.
+func (lm *LazyMap[K, V]) Get(key K) (V, error) {
+	if value, ok := lm.lookup(key); ok {
+		return value, nil
+	}
+	return lm.flight.do(key, func() (V, error) {
+		// A flight that finished just before ours may already have cached the value.
+		if value, ok := lm.lookup(key); ok {
+			return value, nil
+		}
+		value, err := lm.compute(key)
+		if err == nil {
+			lm.mu.Lock()
+			lm.values[key] = value
+			lm.mu.Unlock()
+		}
+		return value, err
+	})
+}
+
+// Forget drops the cached value for key, so the next Get recomputes it.
+func (lm *LazyMap[K, V]) Forget(key K) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	delete(lm.values, key)
+}
+
+// Len returns the number of cached values.
+func (lm *LazyMap[K, V]) Len() int {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	return len(lm.values)
+}
+
+// lookup returns the cached value for key, if any.
+func (lm *LazyMap[K, V]) lookup(key K) (V, bool) {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	value, ok := lm.values[key]
+	return value, ok
+}
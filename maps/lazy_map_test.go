@@ -0,0 +1,91 @@
+package maps_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestLazyMap(t *testing.T) {
+	t.Run("ConcurrentGetComputesOnce", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		lm := maps.NewLazyMap(func(key string) (int, error) {
+			calls.Add(1)
+			<-release
+			return len(key), nil
+		})
+
+		const workers = 64
+		var wg sync.WaitGroup
+		var ready sync.WaitGroup
+		ready.Add(workers)
+		results := make([]int, workers)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				ready.Done()
+				value, err := lm.Get("hello")
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				results[w] = value
+			}(w)
+		}
+
+		ready.Wait()
+		close(release)
+		wg.Wait()
+
+		if n := calls.Load(); n != 1 {
+			t.Errorf("Expected compute to run once, ran %d times", n)
+		}
+		for w, value := range results {
+			if value != 5 {
+				t.Errorf("Worker %d: expected value 5, got %d", w, value)
+			}
+		}
+		if lm.Len() != 1 {
+			t.Errorf("Expected 1 cached value, got %d", lm.Len())
+		}
+	})
+
+	t.Run("ErrorsNotCached", func(t *testing.T) {
+		fail := errors.New("backend down")
+		calls := 0
+		lm := maps.NewLazyMap(func(key string) (int, error) {
+			calls++
+			if calls == 1 {
+				return 0, fail
+			}
+			return calls, nil
+		})
+
+		if _, err := lm.Get("key"); !errors.Is(err, fail) {
+			t.Errorf("Expected first Get to fail, got %v", err)
+		}
+		if value, err := lm.Get("key"); err != nil || value != 2 {
+			t.Errorf("Expected retry to compute 2, got %d (err=%v)", value, err)
+		}
+		if value, _ := lm.Get("key"); value != 2 || calls != 2 {
+			t.Errorf("Expected cached 2 without recomputing, got %d after %d calls", value, calls)
+		}
+	})
+
+	t.Run("Forget", func(t *testing.T) {
+		calls := 0
+		lm := maps.NewLazyMap(func(key int) (int, error) {
+			calls++
+			return key * calls, nil
+		})
+		lm.Get(3)
+		lm.Forget(3)
+		if value, _ := lm.Get(3); value != 6 {
+			t.Errorf("Expected recomputed value 6 after Forget, got %d", value)
+		}
+	})
+}
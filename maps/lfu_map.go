@@ -0,0 +1,202 @@
+package maps
+
+import "container/list"
+
+// lfuEntry is an entry of an LFUMap, linked into the entry list of the
+// bucket for its current frequency.
+type lfuEntry[K, V any] struct {
+	key    K
+	value  V
+	bucket *list.Element // Element of LFUMap.buckets holding this entry
+}
+
+// lfuBucket groups all entries with the same access frequency, most recently
+// used first.
+type lfuBucket struct {
+	freq    int
+	entries *list.List
+}
+
+// LFUMap implements AbstractMap with a fixed capacity, evicting the
+// least-frequently-used entry when a Store would exceed it and breaking ties
+// by evicting the least recently used of those entries.
+// Entries are grouped in frequency buckets kept in ascending frequency order,
+// each a recency-ordered list, so every operation is O(1).
+// Load and Store both count as a use; a newly stored key starts at
+// frequency 1. Range iterates from the most to the least frequently used
+// entry without affecting frequencies.
+type LFUMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, V]
+	m        map[K]*list.Element // Maps keys to their elements in a bucket's entry list
+	buckets  *list.List          // Lowest frequency at the front
+	capacity int
+	onEvict  func(key K, value V)
+}
+
+// NewLFUMap creates an empty LFUMap holding at most capacity entries.
+// It panics if capacity is less than one.
+func NewLFUMap[K comparable, V any](capacity int) *LFUMap[K, V] {
+	if capacity < 1 {
+		panic("maps: NewLFUMap capacity must be at least 1")
+	}
+	lm := &LFUMap[K, V]{
+		m:        make(map[K]*list.Element),
+		buckets:  list.New(),
+		capacity: capacity,
+	}
+	lm.DefaultAbstractMap = NewDefaultAbstractMap(lm)
+	return lm
+}
+
+// OnEvict registers f to be called with each entry evicted to make room for
+// a new one. Explicit Delete and Clear calls do not trigger it.
+// Passing nil removes a previously registered callback.
+func (lm *LFUMap[K, V]) OnEvict(f func(key K, value V)) {
+	lm.onEvict = f
+}
+
+// Cap returns the maximum number of entries the map retains.
+func (lm *LFUMap[K, V]) Cap() int {
+	return lm.capacity
+}
+
+// Frequency returns how many times key has been used, without counting
+// this call as a use.
+// Time complexity: O(1)
+func (lm *LFUMap[K, V]) Frequency(key K) (int, bool) {
+	if element, exists := lm.m[key]; exists {
+		return element.Value.(*lfuEntry[K, V]).bucket.Value.(*lfuBucket).freq, true
+	}
+	return 0, false
+}
+
+// Clear removes all entries without invoking the eviction callback.
+func (lm *LFUMap[K, V]) Clear() {
+	lm.checkMutable("Clear")
+	lm.cleared(lm.Range, func() {
+		clear(lm.m)
+		lm.buckets.Init()
+	})
+}
+
+// Clone returns an independent LFUMap with the same capacity, eviction
+// callback, entries, frequencies and recency order.
+// Time complexity: O(n)
+func (lm *LFUMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := NewLFUMap[K, V](lm.capacity)
+	clone.onEvict = lm.onEvict
+	for b := lm.buckets.Front(); b != nil; b = b.Next() {
+		bucket := b.Value.(*lfuBucket)
+		cb := clone.buckets.PushBack(&lfuBucket{freq: bucket.freq, entries: list.New()})
+		for element := bucket.entries.Front(); element != nil; element = element.Next() {
+			e := *element.Value.(*lfuEntry[K, V])
+			e.bucket = cb
+			clone.m[e.key] = cb.Value.(*lfuBucket).entries.PushBack(&e)
+		}
+	}
+	return clone
+}
+
+// Delete removes key from the map if present.
+// Time complexity: O(1)
+func (lm *LFUMap[K, V]) Delete(key K) {
+	lm.checkMutable("Delete")
+	if element, exists := lm.m[key]; exists {
+		delete(lm.m, key)
+		lm.unlink(element)
+		lm.deleted(key, element.Value.(*lfuEntry[K, V]).value)
+	}
+}
+
+// Len returns the number of entries in the map.
+// Time complexity: O(1)
+func (lm *LFUMap[K, V]) Len() int {
+	return len(lm.m)
+}
+
+// Load returns the value stored for key and increments its frequency.
+// Time complexity: O(1)
+func (lm *LFUMap[K, V]) Load(key K) (value V, ok bool) {
+	if element, exists := lm.m[key]; exists {
+		lm.touch(element)
+		return lm.m[key].Value.(*lfuEntry[K, V]).value, true
+	}
+	return value, false
+}
+
+// Range calls f for each entry from most to least frequently used, and from
+// most to least recently used within a frequency, until f returns false.
+// Iteration does not change frequencies.
+// Time complexity: O(n)
+func (lm *LFUMap[K, V]) Range(f func(key K, value V) bool) {
+	for b := lm.buckets.Back(); b != nil; b = b.Prev() {
+		for element := b.Value.(*lfuBucket).entries.Front(); element != nil; element = element.Next() {
+			e := element.Value.(*lfuEntry[K, V])
+			if !f(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Store sets the value for key. Updating an existing key increments its
+// frequency and never evicts; inserting a new key into a full map first
+// evicts the least frequently used entry.
+// Time complexity: O(1)
+func (lm *LFUMap[K, V]) Store(key K, value V) {
+	lm.checkMutable("Store")
+	if element, exists := lm.m[key]; exists {
+		element.Value.(*lfuEntry[K, V]).value = value
+		lm.touch(element)
+		lm.stored(key, value)
+		return
+	}
+	if len(lm.m) >= lm.capacity {
+		lm.evict()
+	}
+	first := lm.buckets.Front()
+	if first == nil || first.Value.(*lfuBucket).freq != 1 {
+		first = lm.buckets.PushFront(&lfuBucket{freq: 1, entries: list.New()})
+	}
+	e := &lfuEntry[K, V]{key: key, value: value, bucket: first}
+	lm.m[key] = first.Value.(*lfuBucket).entries.PushFront(e)
+	lm.stored(key, value)
+}
+
+// touch moves the entry at element into the bucket for the next frequency,
+// as its most recently used entry.
+func (lm *LFUMap[K, V]) touch(element *list.Element) {
+	e := element.Value.(*lfuEntry[K, V])
+	current := e.bucket
+	freq := current.Value.(*lfuBucket).freq
+	next := current.Next()
+	if next == nil || next.Value.(*lfuBucket).freq != freq+1 {
+		next = lm.buckets.InsertAfter(&lfuBucket{freq: freq + 1, entries: list.New()}, current)
+	}
+	lm.unlink(element)
+	e.bucket = next
+	lm.m[e.key] = next.Value.(*lfuBucket).entries.PushFront(e)
+}
+
+// unlink removes element from its bucket, dropping the bucket once empty.
+func (lm *LFUMap[K, V]) unlink(element *list.Element) {
+	b := element.Value.(*lfuEntry[K, V]).bucket
+	entries := b.Value.(*lfuBucket).entries
+	entries.Remove(element)
+	if entries.Len() == 0 {
+		lm.buckets.Remove(b)
+	}
+}
+
+// evict removes the least recently used entry of the lowest frequency and
+// reports it to onEvict.
+func (lm *LFUMap[K, V]) evict() {
+	element := lm.buckets.Front().Value.(*lfuBucket).entries.Back()
+	e := element.Value.(*lfuEntry[K, V])
+	delete(lm.m, e.key)
+	lm.unlink(element)
+	lm.deleted(e.key, e.value)
+	if lm.onEvict != nil {
+		lm.onEvict(e.key, e.value)
+	}
+}
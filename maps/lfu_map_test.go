@@ -0,0 +1,104 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestLFUMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewLFUMap[string, string](10)
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestLFUMapEviction(t *testing.T) {
+	t.Run("EvictsLeastFrequent", func(t *testing.T) {
+		lm := maps.NewLFUMap[string, int](3)
+		var evicted []string
+		lm.OnEvict(func(key string, _ int) { evicted = append(evicted, key) })
+
+		lm.Store("a", 1)
+		lm.Store("b", 2)
+		lm.Store("c", 3)
+		lm.Load("a")
+		lm.Load("a")
+		lm.Load("c")
+
+		lm.Store("d", 4)
+		if !slices.Equal(evicted, []string{"b"}) {
+			t.Errorf("Expected b to be evicted, got %v", evicted)
+		}
+
+		// d now has frequency 1, the lowest, so it goes next.
+		lm.Store("e", 5)
+		if !slices.Equal(evicted, []string{"b", "d"}) {
+			t.Errorf("Expected d to be evicted next, got %v", evicted)
+		}
+	})
+
+	t.Run("TieBreaksByRecency", func(t *testing.T) {
+		lm := maps.NewLFUMap[string, int](3)
+		var evicted []string
+		lm.OnEvict(func(key string, _ int) { evicted = append(evicted, key) })
+
+		lm.Store("a", 1)
+		lm.Store("b", 2)
+		lm.Store("c", 3)
+		lm.Load("b")
+		lm.Load("a")
+		lm.Load("c")
+
+		// All at frequency 2; b was used least recently.
+		lm.Store("d", 4)
+		if !slices.Equal(evicted, []string{"b"}) {
+			t.Errorf("Expected b to be evicted on a tie, got %v", evicted)
+		}
+	})
+
+	t.Run("Frequency", func(t *testing.T) {
+		lm := maps.NewLFUMap[string, int](2)
+		lm.Store("a", 1)
+		if freq, ok := lm.Frequency("a"); !ok || freq != 1 {
+			t.Errorf("Expected frequency 1 after Store, got %d (ok=%v)", freq, ok)
+		}
+		lm.Load("a")
+		lm.Store("a", 2)
+		if freq, _ := lm.Frequency("a"); freq != 3 {
+			t.Errorf("Expected frequency 3 after Load and update, got %d", freq)
+		}
+		if _, ok := lm.Frequency("missing"); ok {
+			t.Error("Expected missing key to have no frequency")
+		}
+	})
+
+	t.Run("RangeByFrequency", func(t *testing.T) {
+		lm := maps.NewLFUMap[string, int](3)
+		lm.Store("a", 1)
+		lm.Store("b", 2)
+		lm.Store("c", 3)
+		lm.Load("b")
+		lm.Load("b")
+		lm.Load("a")
+
+		if keys := lm.KeysSlice(); !slices.Equal(keys, []string{"b", "a", "c"}) {
+			t.Errorf("Expected keys [b a c], got %v", keys)
+		}
+		clone := lm.Clone().(*maps.LFUMap[string, int])
+		if keys := clone.KeysSlice(); !slices.Equal(keys, []string{"b", "a", "c"}) {
+			t.Errorf("Expected clone keys [b a c], got %v", keys)
+		}
+		if freq, _ := clone.Frequency("b"); freq != 3 {
+			t.Errorf("Expected clone to keep frequency 3, got %d", freq)
+		}
+	})
+}
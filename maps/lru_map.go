@@ -0,0 +1,137 @@
+package maps
+
+import "container/list"
+
+// LRUMap implements AbstractMap with a fixed capacity, evicting the
+// least-recently-used entry when a Store would exceed it.
+// Like OrderedMap it pairs a Go map with a doubly-linked list; here the list
+// is kept in recency order, with the most recently used entry at the front.
+// Both Load and Store mark a key as most recently used, and Range iterates
+// from the most recently used entry to the least recently used one without
+// affecting recency.
+type LRUMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, V]
+	m        map[K]*list.Element // Maps keys to their corresponding list elements
+	l        *list.List          // Most recently used entry at the front
+	capacity int
+	onEvict  func(key K, value V)
+}
+
+// NewLRUMap creates an empty LRUMap holding at most capacity entries.
+// It panics if capacity is less than one, since such a map could never
+// retain anything it stores.
+func NewLRUMap[K comparable, V any](capacity int) *LRUMap[K, V] {
+	if capacity < 1 {
+		panic("maps: NewLRUMap capacity must be at least 1")
+	}
+	lm := &LRUMap[K, V]{
+		m:        make(map[K]*list.Element),
+		l:        list.New(),
+		capacity: capacity,
+	}
+	lm.DefaultAbstractMap = NewDefaultAbstractMap(lm)
+	return lm
+}
+
+// OnEvict registers f to be called with each entry evicted to make room for
+// a new one. Explicit Delete and Clear calls do not trigger it.
+// Passing nil removes a previously registered callback.
+func (lm *LRUMap[K, V]) OnEvict(f func(key K, value V)) {
+	lm.onEvict = f
+}
+
+// Cap returns the maximum number of entries the map retains.
+func (lm *LRUMap[K, V]) Cap() int {
+	return lm.capacity
+}
+
+// Clear removes all entries without invoking the eviction callback.
+func (lm *LRUMap[K, V]) Clear() {
+	lm.checkMutable("Clear")
+	lm.cleared(lm.Range, func() {
+		clear(lm.m)
+		lm.l.Init()
+	})
+}
+
+// Clone returns an independent LRUMap with the same capacity, eviction
+// callback, entries and recency order.
+// Time complexity: O(n)
+func (lm *LRUMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := NewLRUMap[K, V](lm.capacity)
+	clone.onEvict = lm.onEvict
+	for element := lm.l.Front(); element != nil; element = element.Next() {
+		e := *element.Value.(*entry[K, V])
+		clone.m[e.key] = clone.l.PushBack(&e)
+	}
+	return clone
+}
+
+// Delete removes key from the map if present.
+// Time complexity: O(1)
+func (lm *LRUMap[K, V]) Delete(key K) {
+	lm.checkMutable("Delete")
+	if element, exists := lm.m[key]; exists {
+		delete(lm.m, key)
+		lm.l.Remove(element)
+		lm.deleted(key, element.Value.(*entry[K, V]).value)
+	}
+}
+
+// Len returns the number of entries in the map.
+// Time complexity: O(1)
+func (lm *LRUMap[K, V]) Len() int {
+	return len(lm.m)
+}
+
+// Load returns the value stored for key and marks it most recently used.
+// Time complexity: O(1)
+func (lm *LRUMap[K, V]) Load(key K) (value V, ok bool) {
+	if element, exists := lm.m[key]; exists {
+		lm.l.MoveToFront(element)
+		return element.Value.(*entry[K, V]).value, true
+	}
+	return value, false
+}
+
+// Range calls f for each entry from most to least recently used until f
+// returns false. Iteration does not change recency.
+// Time complexity: O(n)
+func (lm *LRUMap[K, V]) Range(f func(key K, value V) bool) {
+	for element := lm.l.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*entry[K, V])
+		if !f(entry.key, entry.value) {
+			break
+		}
+	}
+}
+
+// Store sets the value for key and marks it most recently used.
+// Updating an existing key never evicts; inserting a new key into a full
+// map evicts the least recently used entry first.
+// Time complexity: O(1)
+func (lm *LRUMap[K, V]) Store(key K, value V) {
+	lm.checkMutable("Store")
+	if element, exists := lm.m[key]; exists {
+		element.Value.(*entry[K, V]).value = value
+		lm.l.MoveToFront(element)
+		lm.stored(key, value)
+		return
+	}
+	lm.m[key] = lm.l.PushFront(&entry[K, V]{key: key, value: value})
+	lm.stored(key, value)
+	if len(lm.m) > lm.capacity {
+		lm.evict()
+	}
+}
+
+// evict removes the least recently used entry and reports it to onEvict.
+func (lm *LRUMap[K, V]) evict() {
+	element := lm.l.Back()
+	entry := lm.l.Remove(element).(*entry[K, V])
+	delete(lm.m, entry.key)
+	lm.deleted(entry.key, entry.value)
+	if lm.onEvict != nil {
+		lm.onEvict(entry.key, entry.value)
+	}
+}
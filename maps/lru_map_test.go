@@ -0,0 +1,120 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestLRUMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewLRUMap[string, string](10)
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestLRUMapEviction(t *testing.T) {
+	t.Run("EvictsOldestInOrder", func(t *testing.T) {
+		const capacity = 5
+		lm := maps.NewLRUMap[int, string](capacity)
+
+		var evicted []int
+		lm.OnEvict(func(key int, value string) {
+			evicted = append(evicted, key)
+		})
+
+		for i := 0; i < capacity+3; i++ {
+			lm.Store(i, "value")
+		}
+
+		expected := []int{0, 1, 2}
+		if len(evicted) != len(expected) {
+			t.Fatalf("Expected %d evictions, got %d: %v", len(expected), len(evicted), evicted)
+		}
+		for i, key := range expected {
+			if evicted[i] != key {
+				t.Errorf("Eviction %d: expected key %d, got %d", i, key, evicted[i])
+			}
+		}
+		if lm.Len() != capacity {
+			t.Errorf("Expected length %d, got %d", capacity, lm.Len())
+		}
+	})
+
+	t.Run("LoadRefreshesRecency", func(t *testing.T) {
+		lm := maps.NewLRUMap[string, int](3)
+		lm.Store("a", 1)
+		lm.Store("b", 2)
+		lm.Store("c", 3)
+
+		lm.Load("a")
+		lm.Store("d", 4)
+
+		if _, ok := lm.Load("b"); ok {
+			t.Error("Expected b to be evicted as least recently used")
+		}
+		if _, ok := lm.Load("a"); !ok {
+			t.Error("Expected a to survive after being loaded")
+		}
+	})
+
+	t.Run("UpdateDoesNotGrow", func(t *testing.T) {
+		lm := maps.NewLRUMap[string, int](2)
+		evictions := 0
+		lm.OnEvict(func(string, int) { evictions++ })
+
+		lm.Store("a", 1)
+		lm.Store("b", 2)
+		lm.Store("a", 10)
+
+		if lm.Len() != 2 {
+			t.Errorf("Expected length 2 after update, got %d", lm.Len())
+		}
+		if evictions != 0 {
+			t.Errorf("Expected no evictions on update, got %d", evictions)
+		}
+		if value, _ := lm.Load("a"); value != 10 {
+			t.Errorf("Expected updated value 10, got %d", value)
+		}
+	})
+
+	t.Run("RangeMostRecentFirst", func(t *testing.T) {
+		lm := maps.NewLRUMap[string, int](4)
+		for i, key := range []string{"w", "x", "y", "z"} {
+			lm.Store(key, i)
+		}
+		lm.Load("x")
+
+		expected := []string{"x", "z", "y", "w"}
+		var actual []string
+		lm.Keys(func(key string) bool {
+			actual = append(actual, key)
+			return true
+		})
+		for i, key := range expected {
+			if actual[i] != key {
+				t.Errorf("Position %d: expected %s, got %s", i, key, actual[i])
+			}
+		}
+	})
+
+	t.Run("InvalidCapacityPanics", func(t *testing.T) {
+		for _, capacity := range []int{0, -1} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("Expected NewLRUMap(%d) to panic", capacity)
+					}
+				}()
+				maps.NewLRUMap[string, int](capacity)
+			}()
+		}
+	})
+}
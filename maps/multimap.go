@@ -0,0 +1,146 @@
+package maps
+
+import "slices"
+
+// MultiMap associates each key with one or more values.
+// It implements AbstractMap[K, []V], where each key's value is the slice of
+// all values added under it; Range therefore visits each key once with its
+// full slice, while RangeFlat visits every individual key-value pair.
+// A key is present only while it has at least one value: storing an empty
+// slice or removing a key's last value deletes the key.
+//
+// Slices returned by Load, LoadAll and Range share storage with the map and
+// must not be modified. The AbstractMap compare operations (CompareAndSwap,
+// CompareAndDelete) panic because slices are not comparable.
+type MultiMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, []V]
+	m map[K][]V
+}
+
+// NewMultiMap creates an empty MultiMap.
+func NewMultiMap[K comparable, V any]() *MultiMap[K, V] {
+	mm := &MultiMap[K, V]{
+		m: make(map[K][]V),
+	}
+	mm.DefaultAbstractMap = NewDefaultAbstractMap[K, []V](mm)
+	return mm
+}
+
+// GroupBy returns a MultiMap that buckets items by keyFn. Within each key
+// the items keep the order in which they appear in items.
+func GroupBy[T any, K comparable](items []T, keyFn func(item T) K) *MultiMap[K, T] {
+	mm := NewMultiMap[K, T]()
+	for _, item := range items {
+		k := keyFn(item)
+		mm.m[k] = append(mm.m[k], item)
+	}
+	return mm
+}
+
+// Add appends value to the values stored under key.
+// Observers see the key's full slice of values after the addition.
+// The values are copied rather than appended in place, so slices returned
+// earlier are never overwritten.
+func (mm *MultiMap[K, V]) Add(key K, value V) {
+	mm.checkMutable("Add")
+	values := append(slices.Clip(mm.m[key]), value)
+	mm.m[key] = values
+	mm.stored(key, values)
+}
+
+func (mm *MultiMap[K, V]) Clear() {
+	mm.checkMutable("Clear")
+	mm.cleared(mm.Range, func() { clear(mm.m) })
+}
+
+// Clone returns an independent MultiMap. The per-key slices are copied,
+// while the values themselves are copied shallowly.
+func (mm *MultiMap[K, V]) Clone() AbstractMap[K, []V] {
+	clone := NewMultiMap[K, V]()
+	for k, values := range mm.m {
+		clone.m[k] = slices.Clone(values)
+	}
+	return clone
+}
+
+// CountValues returns the number of values stored under key.
+func (mm *MultiMap[K, V]) CountValues(key K) int {
+	return len(mm.m[key])
+}
+
+// Delete removes key together with all of its values.
+func (mm *MultiMap[K, V]) Delete(key K) {
+	mm.checkMutable("Delete")
+	if values, ok := mm.m[key]; ok {
+		delete(mm.m, key)
+		mm.deleted(key, values)
+	}
+}
+
+// Len returns the number of distinct keys.
+func (mm *MultiMap[K, V]) Len() int {
+	return len(mm.m)
+}
+
+func (mm *MultiMap[K, V]) Load(key K) (values []V, ok bool) {
+	values, ok = mm.m[key]
+	return values, ok
+}
+
+// LoadAll returns the values stored under key in the order they were added.
+func (mm *MultiMap[K, V]) LoadAll(key K) ([]V, bool) {
+	return mm.Load(key)
+}
+
+func (mm *MultiMap[K, V]) Range(f func(key K, values []V) bool) {
+	for k, values := range mm.m {
+		if !f(k, values) {
+			break
+		}
+	}
+}
+
+// RangeFlat calls f for every individual key-value pair until f returns
+// false. Values of the same key are visited in the order they were added.
+func (mm *MultiMap[K, V]) RangeFlat(f func(key K, value V) bool) {
+	for k, values := range mm.m {
+		for _, v := range values {
+			if !f(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// RemoveValue removes the first occurrence of value under key and reports
+// whether it was found. Removing a key's last value deletes the key.
+// Values are compared with ==, which panics if V is not comparable.
+func (mm *MultiMap[K, V]) RemoveValue(key K, value V) bool {
+	mm.checkMutable("RemoveValue")
+	values := mm.m[key]
+	i := slices.IndexFunc(values, func(v V) bool { return equalAny(v, value) })
+	if i < 0 {
+		return false
+	}
+	if len(values) == 1 {
+		delete(mm.m, key)
+		mm.deleted(key, values)
+	} else {
+		values = slices.Concat(values[:i], values[i+1:]) // Leave returned slices intact
+		mm.m[key] = values
+		mm.stored(key, values)
+	}
+	return true
+}
+
+// Store replaces all values under key with values.
+// Storing an empty slice deletes the key.
+func (mm *MultiMap[K, V]) Store(key K, values []V) {
+	mm.checkMutable("Store")
+	if len(values) == 0 {
+		mm.Delete(key)
+		return
+	}
+	mm.m[key] = values
+	mm.stored(key, values)
+}
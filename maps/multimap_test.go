@@ -0,0 +1,196 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestMultiMap(t *testing.T) {
+	t.Run("AddAndLoadAll", func(t *testing.T) {
+		mm := maps.NewMultiMap[string, int]()
+		mm.Add("primes", 2)
+		mm.Add("primes", 3)
+		mm.Add("primes", 5)
+		mm.Add("evens", 2)
+
+		if values, ok := mm.LoadAll("primes"); !ok || !slices.Equal(values, []int{2, 3, 5}) {
+			t.Errorf("Expected [2 3 5], got %v (ok=%v)", values, ok)
+		}
+		if n := mm.CountValues("primes"); n != 3 {
+			t.Errorf("Expected 3 values for primes, got %d", n)
+		}
+		if n := mm.CountValues("missing"); n != 0 {
+			t.Errorf("Expected 0 values for missing key, got %d", n)
+		}
+		if _, ok := mm.LoadAll("missing"); ok {
+			t.Error("Expected LoadAll of a missing key to report false")
+		}
+		if mm.Len() != 2 {
+			t.Errorf("Expected 2 keys, got %d", mm.Len())
+		}
+	})
+
+	t.Run("RangeAndRangeFlat", func(t *testing.T) {
+		mm := maps.NewMultiMap[string, int]()
+		mm.Add("a", 1)
+		mm.Add("a", 2)
+		mm.Add("b", 3)
+
+		keys := 0
+		mm.Range(func(key string, values []int) bool {
+			keys++
+			if key == "a" && !slices.Equal(values, []int{1, 2}) {
+				t.Errorf("Expected a=[1 2], got %v", values)
+			}
+			return true
+		})
+		if keys != 2 {
+			t.Errorf("Expected Range to visit 2 keys, got %d", keys)
+		}
+
+		var pairs []int
+		mm.RangeFlat(func(key string, value int) bool {
+			pairs = append(pairs, value)
+			return true
+		})
+		slices.Sort(pairs)
+		if !slices.Equal(pairs, []int{1, 2, 3}) {
+			t.Errorf("Expected RangeFlat to visit [1 2 3], got %v", pairs)
+		}
+
+		visited := 0
+		mm.RangeFlat(func(key string, value int) bool {
+			visited++
+			return false
+		})
+		if visited != 1 {
+			t.Errorf("Expected RangeFlat to stop after 1 pair, got %d", visited)
+		}
+	})
+
+	t.Run("DeleteDropsAllValues", func(t *testing.T) {
+		mm := maps.NewMultiMap[string, int]()
+		mm.Add("a", 1)
+		mm.Add("a", 2)
+
+		mm.Delete("a")
+		if _, ok := mm.LoadAll("a"); ok {
+			t.Error("Expected key to be gone after Delete")
+		}
+		if mm.CountValues("a") != 0 {
+			t.Error("Expected no values after Delete")
+		}
+	})
+
+	t.Run("RemoveValue", func(t *testing.T) {
+		mm := maps.NewMultiMap[string, string]()
+		mm.Add("tags", "go")
+		mm.Add("tags", "maps")
+		mm.Add("tags", "go")
+
+		if !mm.RemoveValue("tags", "go") {
+			t.Error("Expected go to be removed")
+		}
+		if values, _ := mm.LoadAll("tags"); !slices.Equal(values, []string{"maps", "go"}) {
+			t.Errorf("Expected only the first occurrence removed, got %v", values)
+		}
+		if mm.RemoveValue("tags", "rust") {
+			t.Error("Expected removing an absent value to report false")
+		}
+		if mm.RemoveValue("missing", "go") {
+			t.Error("Expected removing from an absent key to report false")
+		}
+	})
+
+	t.Run("RemoveLastValueDeletesKey", func(t *testing.T) {
+		mm := maps.NewMultiMap[string, int]()
+		mm.Add("solo", 1)
+
+		mm.RemoveValue("solo", 1)
+		if _, ok := mm.Load("solo"); ok {
+			t.Error("Expected key to be removed with its last value")
+		}
+		if mm.Len() != 0 {
+			t.Errorf("Expected empty map, got length %d", mm.Len())
+		}
+	})
+
+	t.Run("StoreEmptyDeletes", func(t *testing.T) {
+		mm := maps.NewMultiMap[string, int]()
+		mm.Store("a", []int{1, 2})
+		mm.Store("a", nil)
+
+		if _, ok := mm.Load("a"); ok {
+			t.Error("Expected storing an empty slice to delete the key")
+		}
+	})
+
+	t.Run("CloneIsIndependent", func(t *testing.T) {
+		mm := maps.NewMultiMap[string, int]()
+		mm.Add("a", 1)
+
+		clone := maps.Clone[string, []int](mm).(*maps.MultiMap[string, int])
+		clone.Add("a", 2)
+		if n := mm.CountValues("a"); n != 1 {
+			t.Errorf("Expected source to keep 1 value, got %d", n)
+		}
+	})
+
+	t.Run("LoadedSlicesStayIntact", func(t *testing.T) {
+		mm := maps.NewMultiMap[string, int]()
+		for _, v := range []int{1, 2, 3, 4} {
+			mm.Add("a", v)
+		}
+		held, _ := mm.Load("a")
+		held = held[:2] // Spare capacity that an in-place append would reuse
+
+		mm.RemoveValue("a", 1)
+		mm.Add("a", 5)
+		if !slices.Equal(held, []int{1, 2}) || !slices.Equal(held[:4], []int{1, 2, 3, 4}) {
+			t.Errorf("Expected the loaded slice to be unchanged, got %v", held[:4])
+		}
+		if got, _ := mm.Load("a"); !slices.Equal(got, []int{2, 3, 4, 5}) {
+			t.Errorf("Expected [2 3 4 5], got %v", got)
+		}
+	})
+}
+
+func TestGroupBy(t *testing.T) {
+	type employee struct {
+		Name string
+		Dept string
+	}
+	staff := []employee{
+		{"Ada", "eng"},
+		{"Grace", "eng"},
+		{"Linus", "ops"},
+		{"Barbara", "eng"},
+		{"Ken", "ops"},
+	}
+
+	groups := maps.GroupBy(staff, func(e employee) string { return e.Dept })
+	if groups.Len() != 2 {
+		t.Errorf("Expected 2 groups, got %d", groups.Len())
+	}
+
+	names := func(dept string) []string {
+		var out []string
+		members, _ := groups.LoadAll(dept)
+		for _, e := range members {
+			out = append(out, e.Name)
+		}
+		return out
+	}
+	if got := names("eng"); !slices.Equal(got, []string{"Ada", "Grace", "Barbara"}) {
+		t.Errorf("Expected eng [Ada Grace Barbara], got %v", got)
+	}
+	if got := names("ops"); !slices.Equal(got, []string{"Linus", "Ken"}) {
+		t.Errorf("Expected ops [Linus Ken], got %v", got)
+	}
+
+	if empty := maps.GroupBy([]int(nil), func(i int) int { return i }); empty.Len() != 0 {
+		t.Errorf("Expected no groups for no items, got %d", empty.Len())
+	}
+}
@@ -0,0 +1,92 @@
+package maps
+
+// NestedMap is a two-level map from an outer key A and an inner key B to a
+// value, the equivalent of map[A]map[B]V without the bookkeeping: inner maps
+// are created on the first Store under an outer key and removed as soon as
+// their last entry is deleted, so an outer key is present exactly while it
+// has at least one inner entry.
+type NestedMap[A, B comparable, V any] struct {
+	outer *UnorderedMap[A, *UnorderedMap[B, V]]
+	len   int
+}
+
+// NewNestedMap creates an empty NestedMap.
+func NewNestedMap[A, B comparable, V any]() *NestedMap[A, B, V] {
+	return &NestedMap[A, B, V]{
+		outer: NewUnorderedMap[A, *UnorderedMap[B, V]](),
+	}
+}
+
+// Store sets the value for the pair (a, b), creating the inner map for a if
+// needed.
+func (nm *NestedMap[A, B, V]) Store(a A, b B, value V) {
+	inner, ok := nm.outer.Load(a)
+	if !ok {
+		inner = NewUnorderedMap[B, V]()
+		nm.outer.Store(a, inner)
+	}
+	if _, loaded := inner.Swap(b, value); !loaded {
+		nm.len++
+	}
+}
+
+// Load returns the value stored for the pair (a, b).
+func (nm *NestedMap[A, B, V]) Load(a A, b B) (value V, ok bool) {
+	inner, ok := nm.outer.Load(a)
+	if !ok {
+		return value, false
+	}
+	return inner.Load(b)
+}
+
+// Delete removes the pair (a, b) if present, and the inner map for a if it
+// becomes empty.
+func (nm *NestedMap[A, B, V]) Delete(a A, b B) {
+	inner, ok := nm.outer.Load(a)
+	if !ok {
+		return
+	}
+	if _, loaded := inner.LoadAndDelete(b); loaded {
+		nm.len--
+		if inner.Len() == 0 {
+			nm.outer.Delete(a)
+		}
+	}
+}
+
+// DeleteOuter removes every pair whose outer key is a.
+func (nm *NestedMap[A, B, V]) DeleteOuter(a A) {
+	if inner, loaded := nm.outer.LoadAndDelete(a); loaded {
+		nm.len -= inner.Len()
+	}
+}
+
+// Len returns the total number of (a, b) pairs.
+func (nm *NestedMap[A, B, V]) Len() int {
+	return nm.len
+}
+
+// OuterLen returns the number of outer keys.
+func (nm *NestedMap[A, B, V]) OuterLen() int {
+	return nm.outer.Len()
+}
+
+// Range calls f for every pair until f returns false.
+func (nm *NestedMap[A, B, V]) Range(f func(a A, b B, value V) bool) {
+	more := true
+	nm.outer.Range(func(a A, inner *UnorderedMap[B, V]) bool {
+		inner.Range(func(b B, value V) bool {
+			more = f(a, b, value)
+			return more
+		})
+		return more
+	})
+}
+
+// RangeInner calls f for every inner key and value under a until f returns
+// false.
+func (nm *NestedMap[A, B, V]) RangeInner(a A, f func(b B, value V) bool) {
+	if inner, ok := nm.outer.Load(a); ok {
+		inner.Range(f)
+	}
+}
@@ -0,0 +1,79 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestNestedMap(t *testing.T) {
+	t.Run("StoreAndLoad", func(t *testing.T) {
+		nm := maps.NewNestedMap[string, string, int]()
+		nm.Store("alice", "math", 90)
+		nm.Store("alice", "art", 75)
+		nm.Store("bob", "math", 60)
+		nm.Store("alice", "math", 95)
+
+		if v, ok := nm.Load("alice", "math"); !ok || v != 95 {
+			t.Errorf("Expected 95, got %d (ok=%v)", v, ok)
+		}
+		if _, ok := nm.Load("bob", "art"); ok {
+			t.Error("Expected missing inner key to report false")
+		}
+		if _, ok := nm.Load("carol", "math"); ok {
+			t.Error("Expected missing outer key to report false")
+		}
+		if nm.Len() != 3 || nm.OuterLen() != 2 {
+			t.Errorf("Expected 3 pairs under 2 outer keys, got %d under %d", nm.Len(), nm.OuterLen())
+		}
+
+		grades := map[string]int{}
+		nm.RangeInner("alice", func(subject string, grade int) bool {
+			grades[subject] = grade
+			return true
+		})
+		if !equalGoMaps(grades, map[string]int{"math": 95, "art": 75}) {
+			t.Errorf("Expected alice's grades, got %v", grades)
+		}
+	})
+
+	t.Run("EmptyInnerCleanup", func(t *testing.T) {
+		nm := maps.NewNestedMap[string, string, int]()
+		nm.Store("a", "x", 1)
+		nm.Store("a", "y", 2)
+		nm.Delete("a", "x")
+		if nm.OuterLen() != 1 {
+			t.Errorf("Expected a to remain while it has entries, got %d outer keys", nm.OuterLen())
+		}
+		nm.Delete("a", "y")
+		nm.Delete("a", "missing")
+		if nm.OuterLen() != 0 || nm.Len() != 0 {
+			t.Errorf("Expected an empty map, got %d pairs under %d outer keys", nm.Len(), nm.OuterLen())
+		}
+	})
+
+	t.Run("DeleteOuter", func(t *testing.T) {
+		nm := maps.NewNestedMap[int, int, int]()
+		for a := range 3 {
+			for b := range 4 {
+				nm.Store(a, b, a*b)
+			}
+		}
+		nm.DeleteOuter(1)
+		nm.DeleteOuter(7)
+		if nm.Len() != 8 || nm.OuterLen() != 2 {
+			t.Errorf("Expected 8 pairs under 2 outer keys, got %d under %d", nm.Len(), nm.OuterLen())
+		}
+		count := 0
+		nm.Range(func(a, b, v int) bool {
+			if a == 1 {
+				t.Errorf("Expected no pairs under 1, got (%d, %d)", a, b)
+			}
+			count++
+			return true
+		})
+		if count != 8 {
+			t.Errorf("Expected Range to visit 8 pairs, got %d", count)
+		}
+	})
+}
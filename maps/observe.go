@@ -0,0 +1,75 @@
+package maps
+
+// observers holds the callbacks registered with Observe. It is replaced as a
+// whole, so a change is reported to a matching pair of callbacks.
+type observers[K, V any] struct {
+	onStore  func(key K, value V)
+	onDelete func(key K, value V)
+}
+
+// Observe registers callbacks that the map invokes after every change:
+// onStore with the key and new value whenever a value is stored, and
+// onDelete with the key and removed value whenever an existing entry is
+// removed, whether by Delete, Clear, eviction or expiry. Operations that do
+// not change the map, such as deleting a missing key or a LoadOrStore that
+// finds the key present, invoke nothing. Either callback may be nil, and a
+// later call to Observe replaces both. Observe may be called while other
+// goroutines use a concurrency-safe map; changes made concurrently with it
+// are reported to either the old or the new callbacks.
+//
+// Callbacks run synchronously after the change is made. For the
+// concurrency-safe maps they run while the map's lock is held, so they must
+// not call back into the map, and ShardedMap may invoke them concurrently
+// for keys in different shards. CopyOnWriteMap invokes them after the new
+// version is published but before its writer lock is released, so they may
+// read the map and see the change, but must not modify it.
+func (m *DefaultAbstractMap[K, V]) Observe(onStore, onDelete func(key K, value V)) {
+	if onStore == nil && onDelete == nil {
+		m.observers.Store(nil)
+		return
+	}
+	m.observers.Store(&observers[K, V]{onStore: onStore, onDelete: onDelete})
+}
+
+// stored reports a stored entry to the onStore observer, if any.
+// Implementations call it after every successful store.
+func (m *DefaultAbstractMap[K, V]) stored(key K, value V) {
+	if o := m.loadObservers(); o != nil && o.onStore != nil {
+		o.onStore(key, value)
+	}
+}
+
+// deleted reports a removed entry to the onDelete observer, if any.
+// Implementations call it after removing an entry that existed.
+func (m *DefaultAbstractMap[K, V]) deleted(key K, value V) {
+	if o := m.loadObservers(); o != nil && o.onDelete != nil {
+		o.onDelete(key, value)
+	}
+}
+
+// cleared runs clear, which must remove every entry yielded by entries, and
+// reports each removed entry to the onDelete observer. The entries are only
+// collected when an observer is registered.
+func (m *DefaultAbstractMap[K, V]) cleared(entries func(yield func(K, V) bool), clear func()) {
+	o := m.loadObservers()
+	if o == nil || o.onDelete == nil {
+		clear()
+		return
+	}
+	var removed []entry[K, V]
+	for k, v := range entries {
+		removed = append(removed, entry[K, V]{key: k, value: v})
+	}
+	clear()
+	for _, e := range removed {
+		o.onDelete(e.key, e.value)
+	}
+}
+
+// loadObservers returns the registered callbacks, or nil if there are none.
+func (m *DefaultAbstractMap[K, V]) loadObservers() *observers[K, V] {
+	if m == nil {
+		return nil
+	}
+	return m.observers.Load()
+}
@@ -0,0 +1,201 @@
+package maps_test
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/13770129/containers/maps"
+)
+
+// observable is an AbstractMap that supports Observe.
+type observable interface {
+	maps.AbstractMap[string, int]
+	Observe(onStore, onDelete func(key string, value int))
+}
+
+// record registers observers on m that append each event to a log.
+func record(m observable) *[]string {
+	var events []string
+	m.Observe(
+		func(key string, value int) { events = append(events, fmt.Sprintf("store %s=%d", key, value)) },
+		func(key string, value int) { events = append(events, fmt.Sprintf("delete %s=%d", key, value)) },
+	)
+	return &events
+}
+
+func TestObserve(t *testing.T) {
+	factories := map[string]func() observable{
+		"UnorderedMap": func() observable { return maps.NewUnorderedMap[string, int]() },
+		"OrderedMap":   func() observable { return maps.NewOrderedMap[string, int]() },
+		"SortedMap": func() observable {
+			return maps.NewSortedMap[string, int](func(a, b string) bool { return a < b })
+		},
+		"CustomKeyMap": func() observable {
+			return maps.NewCustomKeyMap[string, int](func(s string) uint64 { return uint64(len(s)) }, func(a, b string) bool { return a == b })
+		},
+		"LRUMap":  func() observable { return maps.NewLRUMap[string, int](10) },
+		"FIFOMap": func() observable { return maps.NewFIFOMap[string, int](10) },
+		"LFUMap":  func() observable { return maps.NewLFUMap[string, int](10) },
+		"TTLMap":  func() observable { return maps.NewTTLMap[string, int](time.Hour) },
+		"TrieMap": func() observable { return maps.NewTrieMap[int]() },
+		"SkipListMap": func() observable {
+			return maps.NewSkipListMap[string, int](func(a, b string) bool { return a < b })
+		},
+		"BiMap":          func() observable { return maps.NewBiMap[string, int]() },
+		"ShardedMap":     func() observable { return maps.NewShardedMap[string, int](4) },
+		"StripedMap":     func() observable { return maps.NewStripedMap[string, int](4) },
+		"ConcurrentMap":  func() observable { return maps.NewConcurrentMap[string, int]() },
+		"CopyOnWriteMap": func() observable { return maps.NewCopyOnWriteMap[string, int]() },
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			events := record(m)
+
+			steps := []struct {
+				op       func()
+				expected []string
+			}{
+				{func() { m.Store("a", 1) }, []string{"store a=1"}},
+				{func() { m.Store("a", 2) }, []string{"store a=2"}},
+				{func() { m.LoadOrStore("a", 9) }, nil},
+				{func() { m.LoadOrStore("b", 3) }, []string{"store b=3"}},
+				{func() { m.Swap("b", 4) }, []string{"store b=4"}},
+				{func() { m.Delete("missing") }, nil},
+				{func() { m.LoadAndDelete("a") }, []string{"delete a=2"}},
+				{func() { m.LoadAndDelete("a") }, nil},
+				{func() { m.CompareAndSwap("b", 4, 5) }, []string{"store b=5"}},
+				{func() { m.CompareAndSwap("b", 4, 6) }, nil},
+				{func() { m.CompareAndDelete("b", 9) }, nil},
+				{func() { m.CompareAndDelete("b", 5) }, []string{"delete b=5"}},
+				{func() { m.Store("c", 7) }, []string{"store c=7"}},
+				{func() { m.Delete("c") }, []string{"delete c=7"}},
+				{func() { m.Store("d", 8) }, []string{"store d=8"}},
+				{func() { m.Clear() }, []string{"delete d=8"}},
+			}
+			for i, step := range steps {
+				*events = nil
+				step.op()
+				if !slices.Equal(*events, step.expected) {
+					t.Errorf("Step %d: expected %v, got %v", i, step.expected, *events)
+				}
+			}
+		})
+	}
+}
+
+func TestObserveRemovals(t *testing.T) {
+	t.Run("Eviction", func(t *testing.T) {
+		lm := maps.NewLRUMap[string, int](1)
+		events := record(lm)
+		lm.Store("a", 1)
+		lm.Store("b", 2)
+		expected := []string{"store a=1", "store b=2", "delete a=1"}
+		if !slices.Equal(*events, expected) {
+			t.Errorf("Expected %v, got %v", expected, *events)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		events := record(tm)
+		tm.Store("a", 1)
+		tm.Store("b", 2)
+		clock.Advance(2 * time.Minute)
+		*events = nil
+
+		tm.Load("a")
+		tm.DeleteExpired()
+		slices.Sort(*events)
+		expected := []string{"delete a=1", "delete b=2"}
+		if !slices.Equal(*events, expected) {
+			t.Errorf("Expected %v, got %v", expected, *events)
+		}
+	})
+
+	t.Run("BiMapRebinding", func(t *testing.T) {
+		bm := maps.NewBiMap[string, int]()
+		events := record(bm)
+		bm.Store("a", 1)
+		bm.Store("b", 1)
+		expected := []string{"store a=1", "delete a=1", "store b=1"}
+		if !slices.Equal(*events, expected) {
+			t.Errorf("Expected %v, got %v", expected, *events)
+		}
+	})
+
+	t.Run("ReplacingObservers", func(t *testing.T) {
+		um := maps.NewUnorderedMap[string, int]()
+		events := record(um)
+		um.Observe(nil, nil)
+		um.Store("a", 1)
+		um.Delete("a")
+		if len(*events) != 0 {
+			t.Errorf("Expected no events after removing observers, got %v", *events)
+		}
+	})
+
+	t.Run("CopyOnWriteMapPublishesFirst", func(t *testing.T) {
+		cm := maps.NewCopyOnWriteMap[string, int]()
+		var seen []int
+		cm.Observe(func(key string, _ int) {
+			value, _ := cm.Load(key)
+			seen = append(seen, value)
+		}, nil)
+		cm.Store("a", 1)
+		cm.Swap("a", 2)
+		cm.Update(func(tx maps.AbstractMap[string, int]) error {
+			tx.Store("a", 3)
+			tx.Store("b", 4)
+			return nil
+		})
+		if !slices.Equal(seen, []int{1, 2, 3, 4}) {
+			t.Errorf("Expected observers to see the published values [1 2 3 4], got %v", seen)
+		}
+
+		var gone []bool
+		cm.Observe(nil, func(key string, _ int) {
+			_, ok := cm.Load(key)
+			gone = append(gone, !ok)
+		})
+		cm.Update(func(tx maps.AbstractMap[string, int]) error {
+			tx.Delete("a")
+			tx.Clear()
+			return nil
+		})
+		if !slices.Equal(gone, []bool{true, true}) {
+			t.Errorf("Expected deleted keys to be gone when reported, got %v", gone)
+		}
+	})
+
+	t.Run("ConcurrentObserve", func(t *testing.T) {
+		cm := maps.NewConcurrentMap[string, int]()
+		var stores atomic.Int64
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				cm.Store(strconv.Itoa(i), i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				cm.Observe(func(string, int) { stores.Add(1) }, nil)
+				cm.Observe(nil, nil)
+			}
+		}()
+		wg.Wait()
+		if n := stores.Load(); n > 1000 {
+			t.Errorf("Expected at most 1000 notifications, got %d", n)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package maps
 
-import "container/list"
+import (
+	"container/list"
+	"fmt"
+	"slices"
+)
 
 // entry represents a key-value pair stored in the linked list.
 // This structure allows us to store both key and value together,
@@ -19,20 +23,51 @@ type OrderedMap[K comparable, V any] struct {
 	*DefaultAbstractMap[K, V]
 	m map[K]*list.Element // Maps keys to their corresponding list elements
 	l *list.List          // Doubly-linked list maintaining insertion order
+
+	accessOrder bool // Load moves entries to the back of the list
+	gen         int  // Incremented whenever entries are removed; see Entry
+	peak        int  // Largest size since the last shrink; see Cap
 }
 
 // NewOrderedMap creates a new OrderedMap instance.
 // The map is initialized empty with no memory pre-allocation,
 // allowing it to grow dynamically as items are added.
 func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
-	om := &OrderedMap[K, V]{
-		m: make(map[K]*list.Element),
-		l: list.New(),
-	}
+	om := &OrderedMap[K, V]{}
+	om.init()
+	return om
+}
+
+// NewOrderedMapWithCapacity creates a new OrderedMap whose key index is
+// pre-sized for about n entries, avoiding rehashing while it is filled up
+// to that size.
+func NewOrderedMapWithCapacity[K comparable, V any](n int) *OrderedMap[K, V] {
+	om := NewOrderedMap[K, V]()
+	om.m = make(map[K]*list.Element, n)
+	om.peak = n
+	return om
+}
+
+// NewOrderedMapAccessOrdered creates a new OrderedMap in access-order mode:
+// every successful Load moves the key to the end of the iteration order, so
+// Range visits entries from least to most recently accessed. This makes the
+// map behave like an LRU-ordered structure without a capacity bound.
+func NewOrderedMapAccessOrdered[K comparable, V any]() *OrderedMap[K, V] {
+	om := NewOrderedMap[K, V]()
+	om.accessOrder = true
+	return om
+}
+
+// init prepares an empty map. It is also used to make a zero OrderedMap,
+// such as one allocated by a decoder, ready for use.
+func (om *OrderedMap[K, V]) init() {
+	om.m = make(map[K]*list.Element)
+	om.l = list.New()
+	om.gen++
+	om.peak = 0
 	// Embed DefaultAbstractMap to inherit common functionality
 	// like CompareAndSwap, LoadOrStore, etc.
 	om.DefaultAbstractMap = NewDefaultAbstractMap(om)
-	return om
 }
 
 // Store adds or updates a key-value pair in the map.
@@ -41,6 +76,7 @@ func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
 // If the key is new, it's appended to the end of the order.
 // Time complexity: O(1)
 func (om *OrderedMap[K, V]) Store(key K, value V) {
+	om.checkMutable("Store")
 	if element, exists := om.m[key]; exists {
 		// Key exists: update value in-place, preserving order position
 		element.Value.(*entry[K, V]).value = value
@@ -49,15 +85,21 @@ func (om *OrderedMap[K, V]) Store(key K, value V) {
 		newEntry := &entry[K, V]{key: key, value: value}
 		element := om.l.PushBack(newEntry)
 		om.m[key] = element
+		om.peak = max(om.peak, len(om.m))
 	}
+	om.stored(key, value)
 }
 
 // Load retrieves the value associated with a key.
 // Returns the value and true if the key exists,
 // or the zero value and false if the key doesn't exist.
+// In access-order mode a found key is also moved to the end of the order.
 // Time complexity: O(1)
 func (om *OrderedMap[K, V]) Load(key K) (value V, ok bool) {
 	if element, exists := om.m[key]; exists {
+		if om.accessOrder {
+			om.l.MoveToBack(element)
+		}
 		return element.Value.(*entry[K, V]).value, true
 	}
 	// Return zero value for type V when key not found
@@ -70,11 +112,78 @@ func (om *OrderedMap[K, V]) Load(key K) (value V, ok bool) {
 // If the key doesn't exist, this operation is a no-op.
 // Time complexity: O(1)
 func (om *OrderedMap[K, V]) Delete(key K) {
+	om.checkMutable("Delete")
 	if element, exists := om.m[key]; exists {
 		// Remove from both data structures atomically
 		delete(om.m, key)
 		om.l.Remove(element)
+		om.gen++
+		om.deleted(key, element.Value.(*entry[K, V]).value)
+	}
+}
+
+// Clear removes all key-value pairs in one step by resetting both the map
+// and the list, instead of deleting entries one at a time.
+// Time complexity: O(n) for clearing the map, with no extra allocation
+func (om *OrderedMap[K, V]) Clear() {
+	om.checkMutable("Clear")
+	om.cleared(om.Range, func() {
+		clear(om.m)
+		om.l.Init()
+		om.gen++
+	})
+}
+
+// ClearAndShrink removes all key-value pairs and releases the key index's
+// backing storage, which Clear keeps because Go maps never shrink.
+// Time complexity: O(1)
+func (om *OrderedMap[K, V]) ClearAndShrink() {
+	om.checkMutable("ClearAndShrink")
+	om.cleared(om.Range, func() {
+		om.m = make(map[K]*list.Element)
+		om.l.Init()
+		om.gen++
+		om.peak = 0
+	})
+}
+
+// Clone returns an independent OrderedMap with the same entries in the same
+// order and the same ordering mode. Values are copied shallowly.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := NewOrderedMap[K, V]()
+	clone.accessOrder = om.accessOrder
+	for element := om.l.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*entry[K, V])
+		clone.Store(entry.key, entry.value)
 	}
+	return clone
+}
+
+// Grow makes room for at least n more entries in the key index.
+// Go maps cannot reserve space in place, so a non-empty index is copied
+// into a larger one unless Cap already covers them; the list needs no
+// reservation.
+// Time complexity: O(len) if the index is copied, O(1) otherwise
+func (om *OrderedMap[K, V]) Grow(n int) {
+	if n <= 0 || om.peak >= len(om.m)+n {
+		return
+	}
+	grown := make(map[K]*list.Element, len(om.m)+n)
+	for k, element := range om.m {
+		grown[k] = element
+	}
+	om.m = grown
+	om.peak = max(om.peak, len(om.m)+n)
+}
+
+// Cap returns the number of entries the key index has room for, as far as
+// it can be known. Go does not expose map capacity, so this is the largest
+// of the size reached and the capacity requested since the last
+// ClearAndShrink; like the index itself it never shrinks otherwise.
+// Time complexity: O(1)
+func (om *OrderedMap[K, V]) Cap() int {
+	return om.peak
 }
 
 // Len returns the number of key-value pairs in the map.
@@ -100,3 +209,300 @@ func (om *OrderedMap[K, V]) Range(f func(key K, value V) bool) {
 		}
 	}
 }
+
+// MoveToFront moves key to the start of the iteration order without changing
+// its value. It returns false if the key is not present.
+// Time complexity: O(1)
+func (om *OrderedMap[K, V]) MoveToFront(key K) bool {
+	om.checkMutable("MoveToFront")
+	element, exists := om.m[key]
+	if exists {
+		om.l.MoveToFront(element)
+	}
+	return exists
+}
+
+// MoveToBack moves key to the end of the iteration order without changing
+// its value. It returns false if the key is not present.
+// Time complexity: O(1)
+func (om *OrderedMap[K, V]) MoveToBack(key K) bool {
+	om.checkMutable("MoveToBack")
+	element, exists := om.m[key]
+	if exists {
+		om.l.MoveToBack(element)
+	}
+	return exists
+}
+
+// InsertBefore stores a new entry for key immediately before pivot in the
+// iteration order. It returns false, leaving the map unchanged, if pivot is
+// not present or key already is.
+// Time complexity: O(1)
+func (om *OrderedMap[K, V]) InsertBefore(pivot K, key K, value V) bool {
+	om.checkMutable("InsertBefore")
+	mark, exists := om.m[pivot]
+	if !exists {
+		return false
+	}
+	if _, exists := om.m[key]; exists {
+		return false
+	}
+	om.m[key] = om.l.InsertBefore(&entry[K, V]{key: key, value: value}, mark)
+	om.peak = max(om.peak, len(om.m))
+	om.stored(key, value)
+	return true
+}
+
+// InsertAfter stores a new entry for key immediately after pivot in the
+// iteration order. It returns false, leaving the map unchanged, if pivot is
+// not present or key already is.
+// Time complexity: O(1)
+func (om *OrderedMap[K, V]) InsertAfter(pivot K, key K, value V) bool {
+	om.checkMutable("InsertAfter")
+	mark, exists := om.m[pivot]
+	if !exists {
+		return false
+	}
+	if _, exists := om.m[key]; exists {
+		return false
+	}
+	om.m[key] = om.l.InsertAfter(&entry[K, V]{key: key, value: value}, mark)
+	om.peak = max(om.peak, len(om.m))
+	om.stored(key, value)
+	return true
+}
+
+// Rename moves the value stored for oldKey to newKey, keeping the entry's
+// position in the iteration order. It returns false, leaving the map
+// unchanged, if oldKey is absent or newKey is already present.
+// Time complexity: O(1)
+func (om *OrderedMap[K, V]) Rename(oldKey, newKey K) bool {
+	om.checkMutable("Rename")
+	element, exists := om.m[oldKey]
+	if !exists {
+		return false
+	}
+	if _, exists := om.m[newKey]; exists {
+		return false
+	}
+	e := element.Value.(*entry[K, V])
+	delete(om.m, oldKey)
+	e.key = newKey
+	om.m[newKey] = element
+	om.gen++
+	om.deleted(oldKey, e.value)
+	om.stored(newKey, e.value)
+	return true
+}
+
+// SortByKey reorders the entries so that iteration follows ascending key
+// order according to less. The sort is stable, so entries whose keys are
+// equivalent keep their relative order. Stored values are not changed.
+// Time complexity: O(n log n)
+func (om *OrderedMap[K, V]) SortByKey(less func(a, b K) bool) {
+	om.checkMutable("SortByKey")
+	om.sortElements(func(a, b *entry[K, V]) bool { return less(a.key, b.key) })
+}
+
+// SortByValue reorders the entries so that iteration follows ascending value
+// order according to less. The sort is stable, so entries with equivalent
+// values keep their relative order.
+// Time complexity: O(n log n)
+func (om *OrderedMap[K, V]) SortByValue(less func(a, b V) bool) {
+	om.checkMutable("SortByValue")
+	om.sortElements(func(a, b *entry[K, V]) bool { return less(a.value, b.value) })
+}
+
+// sortElements stably sorts the list elements by less and relinks them in
+// that order. The elements themselves are reused, so om.m stays valid.
+func (om *OrderedMap[K, V]) sortElements(less func(a, b *entry[K, V]) bool) {
+	elements := make([]*list.Element, 0, om.l.Len())
+	for element := om.l.Front(); element != nil; element = element.Next() {
+		elements = append(elements, element)
+	}
+	slices.SortStableFunc(elements, func(a, b *list.Element) int {
+		x, y := a.Value.(*entry[K, V]), b.Value.(*entry[K, V])
+		switch {
+		case less(x, y):
+			return -1
+		case less(y, x):
+			return 1
+		default:
+			return 0
+		}
+	})
+	for _, element := range elements {
+		om.l.MoveToBack(element)
+	}
+}
+
+// CompactByValue removes every entry whose value equals, according to eq,
+// the value of the entry retained just before it, so each run of equal
+// values is reduced to its first entry. Like slices.CompactFunc it only
+// looks at neighbours, so it removes all duplicates after SortByValue.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) CompactByValue(eq func(a, b V) bool) {
+	om.checkMutable("CompactByValue")
+	kept := om.l.Front()
+	if kept == nil {
+		return
+	}
+	for element := kept.Next(); element != nil; {
+		next := element.Next()
+		e := element.Value.(*entry[K, V])
+		if eq(kept.Value.(*entry[K, V]).value, e.value) {
+			delete(om.m, e.key)
+			om.l.Remove(element)
+			om.gen++
+			om.deleted(e.key, e.value)
+		} else {
+			kept = element
+		}
+		element = next
+	}
+}
+
+// OrderSnapshot returns the keys in iteration order, for a later
+// RestoreOrder.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) OrderSnapshot() []K {
+	return om.KeysSlice()
+}
+
+// RestoreOrder reorders the entries to match keys, typically taken from
+// OrderSnapshot. Values are untouched. It returns an error, leaving the map
+// unchanged, unless keys holds every key of the map exactly once.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) RestoreOrder(keys []K) error {
+	om.checkMutable("RestoreOrder")
+	if len(keys) != len(om.m) {
+		return fmt.Errorf("maps: cannot restore order of %d keys from %d keys", len(om.m), len(keys))
+	}
+	elements := make([]*list.Element, len(keys))
+	seen := make(map[K]struct{}, len(keys))
+	for i, key := range keys {
+		element, exists := om.m[key]
+		if !exists {
+			return fmt.Errorf("maps: cannot restore order, key %v is not in the map", key)
+		}
+		if _, dup := seen[key]; dup {
+			return fmt.Errorf("maps: cannot restore order, key %v is repeated", key)
+		}
+		seen[key] = struct{}{}
+		elements[i] = element
+	}
+	for _, element := range elements {
+		om.l.MoveToBack(element)
+	}
+	return nil
+}
+
+// GetAt returns the entry at the given zero-based position in iteration
+// order. It returns false if index is negative or not less than Len.
+// The list is walked from whichever end is closer to index.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) GetAt(index int) (key K, value V, ok bool) {
+	n := om.l.Len()
+	if index < 0 || index >= n {
+		return key, value, false
+	}
+	var element *list.Element
+	if index < n/2 {
+		element = om.l.Front()
+		for i := 0; i < index; i++ {
+			element = element.Next()
+		}
+	} else {
+		element = om.l.Back()
+		for i := n - 1; i > index; i-- {
+			element = element.Prev()
+		}
+	}
+	entry := element.Value.(*entry[K, V])
+	return entry.key, entry.value, true
+}
+
+// IndexOf returns the zero-based position of key in iteration order, or
+// false if the key is not present.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) IndexOf(key K) (int, bool) {
+	target, exists := om.m[key]
+	if !exists {
+		return -1, false
+	}
+	index := 0
+	for element := om.l.Front(); element != target; element = element.Next() {
+		index++
+	}
+	return index, true
+}
+
+// RangeReverse calls the provided function for each key-value pair in reverse
+// insertion order, from the newest entry to the oldest.
+// The iteration stops early if the function returns false.
+// Time complexity: O(n) where n is the number of elements
+func (om *OrderedMap[K, V]) RangeReverse(f func(key K, value V) bool) {
+	for element := om.l.Back(); element != nil; element = element.Prev() {
+		entry := element.Value.(*entry[K, V])
+		if !f(entry.key, entry.value) {
+			break
+		}
+	}
+}
+
+// KeysReverse calls the provided function for each key from newest to oldest.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) KeysReverse(f func(key K) bool) {
+	om.RangeReverse(func(key K, _ V) bool {
+		return f(key)
+	})
+}
+
+// ValuesReverse calls the provided function for each value from newest to oldest.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) ValuesReverse(f func(value V) bool) {
+	om.RangeReverse(func(_ K, value V) bool {
+		return f(value)
+	})
+}
+
+// ToGoMap copies the entries into a new builtin map.
+// The returned map does not preserve insertion order.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) ToGoMap() map[K]V {
+	gm := make(map[K]V, len(om.m))
+	for k, element := range om.m {
+		gm[k] = element.Value.(*entry[K, V]).value
+	}
+	return gm
+}
+
+// String formats the map as map[k1:v1 k2:v2] in iteration order,
+// implementing fmt.Stringer.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) String() string {
+	return formatMap(om.Range)
+}
+
+// GoString formats the map in Go syntax for the %#v verb, keeping
+// iteration order.
+// Time complexity: O(n)
+func (om *OrderedMap[K, V]) GoString() string {
+	return goFormatMap(om, om.Range)
+}
+
+// Update calls fn with a staging map that reflects the current contents and
+// records the changes made through it. If fn returns nil, the changes are
+// applied to om; otherwise om is left untouched and fn's error is returned.
+// Keys first stored by the transaction are appended in the order they were
+// stored, as if fn had stored them on om directly.
+// Time complexity: O(k) to commit, where k is the number of keys changed
+func (om *OrderedMap[K, V]) Update(fn func(tx AbstractMap[K, V]) error) error {
+	om.checkMutable("Update")
+	tx := newTxMap[K, V](om)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	tx.commit(om.Store, om.Delete, om.Clear)
+	return nil
+}
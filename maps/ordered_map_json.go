@@ -0,0 +1,114 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// MarshalJSON implements json.Marshaler, encoding the map as a JSON object
+// whose members appear in insertion order.
+// Keys must have an underlying string type; any other key type is an error.
+func (om *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	if om == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for element := om.l.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*entry[K, V])
+		name, err := stringKey(entry.key)
+		if err != nil {
+			return nil, err
+		}
+		keyJSON, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		valueJSON, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+		if element != om.l.Front() {
+			buf.WriteByte(',')
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(valueJSON)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the map's contents
+// with the members of a JSON object stored in the order they appear in data.
+// A JSON null leaves the map unchanged. If data is invalid the map is left
+// untouched and an error is returned.
+// Keys must have an underlying string type; any other key type is an error.
+func (om *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	om.checkMutable("UnmarshalJSON")
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("maps: cannot unmarshal %v into OrderedMap, expected JSON object", tok)
+	}
+
+	// Decode everything before touching the receiver so that a malformed
+	// document does not leave the map half-populated.
+	var entries []entry[K, V]
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := parseStringKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		entries = append(entries, entry[K, V]{key: key, value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	if om.l == nil {
+		om.init()
+	} else {
+		om.Clear()
+	}
+	for _, e := range entries {
+		om.Store(e.key, e.value)
+	}
+	return nil
+}
+
+// stringKey converts a key with an underlying string type to a string.
+func stringKey[K any](key K) (string, error) {
+	v := reflect.ValueOf(key)
+	if v.Kind() != reflect.String {
+		return "", fmt.Errorf("maps: key type %T is not a string type", key)
+	}
+	return v.String(), nil
+}
+
+// parseStringKey converts s to a key with an underlying string type.
+func parseStringKey[K any](s string) (K, error) {
+	var key K
+	v := reflect.ValueOf(&key).Elem()
+	if v.Kind() != reflect.String {
+		return key, fmt.Errorf("maps: key type %T is not a string type", key)
+	}
+	v.SetString(s)
+	return key, nil
+}
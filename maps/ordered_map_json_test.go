@@ -0,0 +1,113 @@
+package maps_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestOrderedMapJSON(t *testing.T) {
+	t.Run("MarshalInsertionOrder", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.Store("zebra", 1)
+		om.Store("apple", 2)
+		om.Store("mango", 3)
+
+		data, err := json.Marshal(om)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if expected := `{"zebra":1,"apple":2,"mango":3}`; string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("MarshalEmpty", func(t *testing.T) {
+		data, err := json.Marshal(maps.NewOrderedMap[string, int]())
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(data) != "{}" {
+			t.Errorf("Expected {}, got %s", data)
+		}
+	})
+
+	t.Run("NestedRoundTrip", func(t *testing.T) {
+		input := `{"zulu":{"b":1,"a":2},"alpha":{"y":3,"x":4,"w":5},"empty":{}}`
+
+		om := maps.NewOrderedMap[string, *maps.OrderedMap[string, int]]()
+		if err := json.Unmarshal([]byte(input), om); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		var keys []string
+		om.Keys(func(key string) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if len(keys) != 3 || keys[0] != "zulu" || keys[1] != "alpha" || keys[2] != "empty" {
+			t.Errorf("Expected keys [zulu alpha empty], got %v", keys)
+		}
+
+		output, err := json.Marshal(om)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(output) != input {
+			t.Errorf("Round trip changed order.\nExpected: %s\nGot:      %s", input, output)
+		}
+	})
+
+	t.Run("UnmarshalReplacesContents", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.Store("stale", 0)
+
+		if err := json.Unmarshal([]byte(`{"b":2,"a":1}`), om); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if _, ok := om.Load("stale"); ok {
+			t.Error("Expected existing entries to be replaced")
+		}
+		if om.Len() != 2 {
+			t.Errorf("Expected length 2, got %d", om.Len())
+		}
+	})
+
+	t.Run("UnmarshalInvalidLeavesMapUntouched", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.Store("kept", 1)
+
+		for _, input := range []string{`[1,2]`, `{"a":1,`, `{"a":"not a number"}`} {
+			if err := json.Unmarshal([]byte(input), om); err == nil {
+				t.Errorf("Expected error unmarshalling %s", input)
+			}
+		}
+		if value, ok := om.Load("kept"); !ok || value != 1 || om.Len() != 1 {
+			t.Error("Expected map to be unchanged after failed unmarshal")
+		}
+	})
+
+	t.Run("NonStringKeys", func(t *testing.T) {
+		om := maps.NewOrderedMap[int, int]()
+		om.Store(1, 1)
+
+		if _, err := json.Marshal(om); err == nil {
+			t.Error("Expected error marshalling int-keyed map")
+		}
+		if err := json.Unmarshal([]byte(`{"1":1}`), om); err == nil {
+			t.Error("Expected error unmarshalling into int-keyed map")
+		}
+	})
+
+	t.Run("NamedStringKeys", func(t *testing.T) {
+		type field string
+		om := maps.NewOrderedMap[field, bool]()
+		if err := json.Unmarshal([]byte(`{"on":true,"off":false}`), om); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if value, ok := om.Load(field("on")); !ok || !value {
+			t.Error("Expected named string key to decode")
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package maps
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, storing the map in a database column as a
+// JSON object whose members appear in insertion order. A nil map is stored
+// as SQL NULL.
+// Keys must have an underlying string type; any other key type is an error.
+func (om *OrderedMap[K, V]) Value() (driver.Value, error) {
+	if om == nil {
+		return nil, nil
+	}
+	return om.MarshalJSON()
+}
+
+// Scan implements sql.Scanner, replacing the map's contents with a JSON
+// object read from a []byte or string column value, in document order.
+// SQL NULL leaves the map empty. If src is invalid the map is left untouched
+// and an error is returned.
+// Keys must have an underlying string type; any other key type is an error.
+func (om *OrderedMap[K, V]) Scan(src any) error {
+	om.checkMutable("Scan")
+	switch src := src.(type) {
+	case nil:
+		if om.l == nil {
+			om.init()
+		} else {
+			om.Clear()
+		}
+		return nil
+	case []byte:
+		return om.UnmarshalJSON(src)
+	case string:
+		return om.UnmarshalJSON([]byte(src))
+	default:
+		return fmt.Errorf("maps: cannot scan %T into OrderedMap", src)
+	}
+}
@@ -0,0 +1,80 @@
+package maps_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+var (
+	_ sql.Scanner   = (*maps.OrderedMap[string, int])(nil)
+	_ driver.Valuer = (*maps.OrderedMap[string, int])(nil)
+)
+
+func TestOrderedMapSQLRoundTrip(t *testing.T) {
+	src := maps.Of(maps.P("zulu", 1), maps.P("alpha", 2), maps.P("mike", 3))
+
+	value, err := src.Value()
+	if err != nil {
+		t.Fatalf("Expected Value to succeed, got %v", err)
+	}
+	if !driver.IsValue(value) {
+		t.Fatalf("Expected a valid driver.Value, got %T", value)
+	}
+
+	for name, column := range map[string]any{
+		"Bytes":  value,
+		"String": string(value.([]byte)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			dst := maps.Of(maps.P("stale", 99))
+			if err := dst.Scan(column); err != nil {
+				t.Fatalf("Expected Scan to succeed, got %v", err)
+			}
+			if keys := dst.KeysSlice(); !slices.Equal(keys, []string{"zulu", "alpha", "mike"}) {
+				t.Errorf("Expected keys [zulu alpha mike], got %v", keys)
+			}
+			if !maps.EqualOrdered[string, int](src, dst) {
+				t.Errorf("Expected %v, got %v", src, dst)
+			}
+		})
+	}
+}
+
+func TestOrderedMapSQLNull(t *testing.T) {
+	var om *maps.OrderedMap[string, int]
+	if value, err := om.Value(); value != nil || err != nil {
+		t.Errorf("Expected nil map to be NULL, got %v, %v", value, err)
+	}
+
+	dst := maps.Of(maps.P("stale", 1))
+	if err := dst.Scan(nil); err != nil {
+		t.Fatalf("Expected Scan(nil) to succeed, got %v", err)
+	}
+	if dst.Len() != 0 {
+		t.Errorf("Expected NULL to empty the map, got length %d", dst.Len())
+	}
+
+	var zero maps.OrderedMap[string, int]
+	if err := zero.Scan(`{"a":1}`); err != nil {
+		t.Fatalf("Expected Scan into a zero value to succeed, got %v", err)
+	}
+	if value, _ := zero.Load("a"); value != 1 {
+		t.Errorf("Expected a=1, got %d", value)
+	}
+}
+
+func TestOrderedMapSQLScanErrors(t *testing.T) {
+	dst := maps.Of(maps.P("keep", 1))
+	for _, src := range []any{42, `[1, 2]`, []byte(`{"a":`)} {
+		if err := dst.Scan(src); err == nil {
+			t.Errorf("Expected Scan(%v) to fail", src)
+		}
+	}
+	if dst.Len() != 1 {
+		t.Errorf("Expected failed scans to leave the map untouched, got length %d", dst.Len())
+	}
+}
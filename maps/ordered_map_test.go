@@ -2,6 +2,7 @@ package maps_test
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/13770129/containers/maps"
@@ -461,3 +462,602 @@ func TestOrderedMapEdgeCases(t *testing.T) {
 		})
 	})
 }
+
+// Test reverse iteration mirrors forward iteration
+func TestOrderedMapReverseIteration(t *testing.T) {
+	t.Run("MirrorsForwardOrder", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"charlie", "alpha", "delta", "bravo"} {
+			om.Store(key, i)
+		}
+		om.Delete("delta")
+		om.Store("echo", 4)
+
+		var forward, reverse []string
+		om.Keys(func(key string) bool {
+			forward = append(forward, key)
+			return true
+		})
+		om.KeysReverse(func(key string) bool {
+			reverse = append(reverse, key)
+			return true
+		})
+
+		if len(forward) != len(reverse) {
+			t.Fatalf("Expected %d reversed keys, got %d", len(forward), len(reverse))
+		}
+		for i := range forward {
+			if reverse[i] != forward[len(forward)-1-i] {
+				t.Errorf("Position %d: expected %s, got %s", i, forward[len(forward)-1-i], reverse[i])
+			}
+		}
+	})
+
+	t.Run("ValuesReverse", func(t *testing.T) {
+		om := maps.NewOrderedMap[int, string]()
+		om.Store(1, "one")
+		om.Store(2, "two")
+		om.Store(3, "three")
+
+		var values []string
+		om.ValuesReverse(func(value string) bool {
+			values = append(values, value)
+			return true
+		})
+
+		expected := []string{"three", "two", "one"}
+		for i, value := range expected {
+			if values[i] != value {
+				t.Errorf("Position %d: expected %s, got %s", i, value, values[i])
+			}
+		}
+	})
+
+	t.Run("EarlyTermination", func(t *testing.T) {
+		om := maps.NewOrderedMap[int, int]()
+		for i := 1; i <= 10; i++ {
+			om.Store(i, i)
+		}
+
+		var visited []int
+		om.RangeReverse(func(key, value int) bool {
+			visited = append(visited, key)
+			return len(visited) < 3
+		})
+
+		expected := []int{10, 9, 8}
+		if len(visited) != len(expected) {
+			t.Fatalf("Expected %d visited keys, got %d", len(expected), len(visited))
+		}
+		for i, key := range expected {
+			if visited[i] != key {
+				t.Errorf("Position %d: expected %d, got %d", i, key, visited[i])
+			}
+		}
+	})
+}
+
+// Test explicit reordering of existing entries
+func TestOrderedMapMove(t *testing.T) {
+	collect := func(om *maps.OrderedMap[string, int]) []string {
+		var keys []string
+		om.Keys(func(key string) bool {
+			keys = append(keys, key)
+			return true
+		})
+		return keys
+	}
+
+	t.Run("MoveMiddleToBack", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"a", "b", "c", "d"} {
+			om.Store(key, i)
+		}
+
+		if !om.MoveToBack("b") {
+			t.Fatal("Expected MoveToBack to find b")
+		}
+
+		expected := []string{"a", "c", "d", "b"}
+		actual := collect(om)
+		for i, key := range expected {
+			if actual[i] != key {
+				t.Errorf("Position %d: expected %s, got %s", i, key, actual[i])
+			}
+		}
+		if value, _ := om.Load("b"); value != 1 {
+			t.Errorf("Expected moved value to stay 1, got %d", value)
+		}
+	})
+
+	t.Run("MoveMiddleToFront", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		for i, key := range []string{"a", "b", "c", "d"} {
+			om.Store(key, i)
+		}
+
+		if !om.MoveToFront("c") {
+			t.Fatal("Expected MoveToFront to find c")
+		}
+
+		expected := []string{"c", "a", "b", "d"}
+		actual := collect(om)
+		for i, key := range expected {
+			if actual[i] != key {
+				t.Errorf("Position %d: expected %s, got %s", i, key, actual[i])
+			}
+		}
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.Store("a", 1)
+
+		if om.MoveToFront("missing") || om.MoveToBack("missing") {
+			t.Error("Expected moves of a missing key to report false")
+		}
+		if om.Len() != 1 {
+			t.Errorf("Expected length 1, got %d", om.Len())
+		}
+	})
+}
+
+func TestOrderedMapAccessOrderedSuite(t *testing.T) {
+	factory := func() maps.AbstractMap[string, int] {
+		return maps.NewOrderedMapAccessOrdered[string, int]()
+	}
+
+	testData := []TestCase[string, int]{
+		{"first", 1},
+		{"second", 2},
+		{"third", 3},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+// Test access-order mode moves loaded keys to the end
+func TestOrderedMapAccessOrder(t *testing.T) {
+	collect := func(om *maps.OrderedMap[string, int]) []string {
+		var keys []string
+		om.Keys(func(key string) bool {
+			keys = append(keys, key)
+			return true
+		})
+		return keys
+	}
+
+	t.Run("LoadMovesToEnd", func(t *testing.T) {
+		om := maps.NewOrderedMapAccessOrdered[string, int]()
+		for i, key := range []string{"a", "b", "c", "d"} {
+			om.Store(key, i)
+		}
+
+		if value, ok := om.Load("b"); !ok || value != 1 {
+			t.Fatalf("Expected to load b=1, got %d (ok=%v)", value, ok)
+		}
+
+		expected := []string{"a", "c", "d", "b"}
+		actual := collect(om)
+		for i, key := range expected {
+			if actual[i] != key {
+				t.Errorf("Position %d: expected %s, got %s", i, key, actual[i])
+			}
+		}
+	})
+
+	t.Run("MissingLoadKeepsOrder", func(t *testing.T) {
+		om := maps.NewOrderedMapAccessOrdered[string, int]()
+		om.Store("a", 1)
+		om.Store("b", 2)
+		om.Load("missing")
+
+		actual := collect(om)
+		if actual[0] != "a" || actual[1] != "b" {
+			t.Errorf("Expected order [a b] after missed load, got %v", actual)
+		}
+	})
+
+	t.Run("InsertionOrderIsDefault", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.Store("a", 1)
+		om.Store("b", 2)
+		om.Load("a")
+
+		actual := collect(om)
+		if actual[0] != "a" || actual[1] != "b" {
+			t.Errorf("Expected Load not to reorder by default, got %v", actual)
+		}
+	})
+
+	t.Run("CloneKeepsMode", func(t *testing.T) {
+		om := maps.NewOrderedMapAccessOrdered[string, int]()
+		om.Store("a", 1)
+		om.Store("b", 2)
+
+		clone := om.Clone().(*maps.OrderedMap[string, int])
+		clone.Load("a")
+		actual := collect(clone)
+		if actual[0] != "b" || actual[1] != "a" {
+			t.Errorf("Expected clone to stay access-ordered, got %v", actual)
+		}
+	})
+}
+
+func TestOrderedMapWithCapacity(t *testing.T) {
+	factory := func() maps.AbstractMap[string, int] {
+		return maps.NewOrderedMapWithCapacity[string, int](16)
+	}
+
+	testData := []TestCase[string, int]{
+		{"first", 1},
+		{"second", 2},
+		{"third", 3},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestOrderedMapGrowPreservesOrder(t *testing.T) {
+	om := maps.NewOrderedMap[string, int]()
+	om.Store("b", 1)
+	om.Store("a", 2)
+	om.Grow(1000)
+	om.Store("c", 3)
+
+	keys := om.KeysSlice()
+	expected := []string{"b", "a", "c"}
+	for i, key := range expected {
+		if keys[i] != key {
+			t.Errorf("Position %d: expected %s, got %s", i, key, keys[i])
+		}
+	}
+}
+
+func TestOrderedMapPositionalAccess(t *testing.T) {
+	om := maps.NewOrderedMap[string, int]()
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		om.Store(key, i)
+	}
+	om.Delete("b")
+	om.Store("f", 5)
+	om.Delete("d")
+	om.Store("a", 10) // Overwrite keeps position
+
+	expected := []string{"a", "c", "e", "f"}
+
+	t.Run("GetAt", func(t *testing.T) {
+		for i, expectedKey := range expected {
+			key, value, ok := om.GetAt(i)
+			if !ok || key != expectedKey {
+				t.Errorf("GetAt(%d): expected %s, got %s (ok=%v)", i, expectedKey, key, ok)
+			}
+			if stored, _ := om.Load(key); value != stored {
+				t.Errorf("GetAt(%d): expected value %d, got %d", i, stored, value)
+			}
+		}
+		for _, index := range []int{-1, len(expected), 100} {
+			if _, _, ok := om.GetAt(index); ok {
+				t.Errorf("GetAt(%d): expected ok=false", index)
+			}
+		}
+	})
+
+	t.Run("IndexOf", func(t *testing.T) {
+		for i, key := range expected {
+			if index, ok := om.IndexOf(key); !ok || index != i {
+				t.Errorf("IndexOf(%s): expected %d, got %d (ok=%v)", key, i, index, ok)
+			}
+		}
+		for _, key := range []string{"b", "d", "missing"} {
+			if _, ok := om.IndexOf(key); ok {
+				t.Errorf("IndexOf(%s): expected ok=false", key)
+			}
+		}
+	})
+}
+
+func TestOrderedMapInsertRelative(t *testing.T) {
+	om := maps.Of(maps.P("a", 1), maps.P("c", 3), maps.P("e", 5))
+
+	if !om.InsertBefore("c", "b", 2) {
+		t.Error("Expected InsertBefore(c, b) to succeed")
+	}
+	if !om.InsertAfter("c", "d", 4) {
+		t.Error("Expected InsertAfter(c, d) to succeed")
+	}
+	if !om.InsertAfter("e", "f", 6) {
+		t.Error("Expected InsertAfter at the back to succeed")
+	}
+	if !om.InsertBefore("a", "start", 0) {
+		t.Error("Expected InsertBefore at the front to succeed")
+	}
+
+	expected := []string{"start", "a", "b", "c", "d", "e", "f"}
+	if keys := om.KeysSlice(); !slices.Equal(keys, expected) {
+		t.Errorf("Expected keys %v, got %v", expected, keys)
+	}
+
+	t.Run("Rejects", func(t *testing.T) {
+		if om.InsertBefore("missing", "x", 0) || om.InsertAfter("missing", "x", 0) {
+			t.Error("Expected insertion relative to a missing pivot to fail")
+		}
+		if om.InsertBefore("e", "a", 100) || om.InsertAfter("a", "a", 100) {
+			t.Error("Expected inserting an existing key to fail")
+		}
+		if value, _ := om.Load("a"); value != 1 {
+			t.Errorf("Expected a rejected insert to leave a=1, got %d", value)
+		}
+		if keys := om.KeysSlice(); !slices.Equal(keys, expected) {
+			t.Errorf("Expected order to be unchanged, got %v", keys)
+		}
+	})
+}
+
+func TestRename(t *testing.T) {
+	t.Run("OrderedMapKeepsPosition", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 3))
+		if !om.Rename("b", "B") {
+			t.Fatal("Expected Rename(b, B) to succeed")
+		}
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"a", "B", "c"}) {
+			t.Errorf("Expected keys [a B c], got %v", keys)
+		}
+		if value, ok := om.Load("B"); !ok || value != 2 {
+			t.Errorf("Expected B=2, got %d (ok=%v)", value, ok)
+		}
+		if _, ok := om.Load("b"); ok || om.Len() != 3 {
+			t.Errorf("Expected b to be gone and length 3, got length %d", om.Len())
+		}
+	})
+
+	t.Run("Rejects", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 1), maps.P("b", 2))
+		if om.Rename("missing", "x") {
+			t.Error("Expected renaming a missing key to fail")
+		}
+		if om.Rename("a", "b") {
+			t.Error("Expected renaming onto an existing key to fail")
+		}
+		if om.Rename("a", "a") {
+			t.Error("Expected renaming a key to itself to fail")
+		}
+		if keys, values := om.KeysSlice(), om.ValuesSlice(); !slices.Equal(keys, []string{"a", "b"}) || !slices.Equal(values, []int{1, 2}) {
+			t.Errorf("Expected map unchanged, got %v %v", keys, values)
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		sm := maps.NewSortedMap[string, int](func(a, b string) bool { return a < b })
+		sm.Store("a", 1)
+		sm.Store("b", 2)
+		if !sm.Rename("a", "c") {
+			t.Fatal("Expected Rename(a, c) to succeed")
+		}
+		if keys := sm.KeysSlice(); !slices.Equal(keys, []string{"b", "c"}) {
+			t.Errorf("Expected keys [b c], got %v", keys)
+		}
+		if sm.Rename("b", "c") {
+			t.Error("Expected renaming onto an existing key to fail")
+		}
+	})
+}
+
+func TestOrderedMapSort(t *testing.T) {
+	newScrambled := func() *maps.OrderedMap[string, int] {
+		return maps.Of(maps.P("d", 2), maps.P("a", 4), maps.P("e", 1), maps.P("c", 5), maps.P("b", 3))
+	}
+
+	t.Run("ByKeyAscending", func(t *testing.T) {
+		om := newScrambled()
+		om.SortByKey(func(a, b string) bool { return a < b })
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"a", "b", "c", "d", "e"}) {
+			t.Errorf("Expected keys [a b c d e], got %v", keys)
+		}
+		if value, ok := om.Load("c"); !ok || value != 5 {
+			t.Errorf("Expected lookups to survive sorting, got c=%d (ok=%v)", value, ok)
+		}
+	})
+
+	t.Run("ByKeyDescending", func(t *testing.T) {
+		om := newScrambled()
+		om.SortByKey(func(a, b string) bool { return a > b })
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"e", "d", "c", "b", "a"}) {
+			t.Errorf("Expected keys [e d c b a], got %v", keys)
+		}
+	})
+
+	t.Run("ByValue", func(t *testing.T) {
+		om := newScrambled()
+		om.SortByValue(func(a, b int) bool { return a < b })
+		if values := om.ValuesSlice(); !slices.Equal(values, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("Expected values [1 2 3 4 5], got %v", values)
+		}
+		om.SortByValue(func(a, b int) bool { return a > b })
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"c", "a", "b", "d", "e"}) {
+			t.Errorf("Expected keys [c a b d e], got %v", keys)
+		}
+
+		om.Delete("b")
+		om.Store("f", 0)
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"c", "a", "d", "e", "f"}) {
+			t.Errorf("Expected mutation after sorting to work, got %v", keys)
+		}
+	})
+
+	t.Run("Stable", func(t *testing.T) {
+		om := maps.Of(maps.P("x", 1), maps.P("y", 0), maps.P("z", 1), maps.P("w", 0))
+		om.SortByValue(func(a, b int) bool { return a < b })
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"y", "w", "x", "z"}) {
+			t.Errorf("Expected keys [y w x z], got %v", keys)
+		}
+	})
+}
+
+func TestOrderedMapCompactByValue(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	t.Run("Runs", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 1), maps.P("b", 1), maps.P("c", 2), maps.P("d", 2), maps.P("e", 2), maps.P("f", 1), maps.P("g", 3))
+		om.CompactByValue(eq)
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"a", "c", "f", "g"}) {
+			t.Errorf("Expected the first key of each run [a c f g], got %v", keys)
+		}
+		if values := om.ValuesSlice(); !slices.Equal(values, []int{1, 2, 1, 3}) {
+			t.Errorf("Expected values [1 2 1 3], got %v", values)
+		}
+		if _, ok := om.Load("b"); ok || om.Len() != 4 {
+			t.Errorf("Expected removed keys to be dropped, got length %d", om.Len())
+		}
+	})
+
+	t.Run("AfterSort", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 2), maps.P("b", 1), maps.P("c", 2), maps.P("d", 1))
+		om.SortByValue(func(a, b int) bool { return a < b })
+		om.CompactByValue(eq)
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"b", "a"}) {
+			t.Errorf("Expected [b a] after sorting and compacting, got %v", keys)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, int]()
+		om.CompactByValue(eq)
+		if om.Len() != 0 {
+			t.Errorf("Expected empty map, got length %d", om.Len())
+		}
+	})
+}
+
+func TestOrderedMapRestoreOrder(t *testing.T) {
+	om := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("c", 3), maps.P("d", 4))
+	original := om.OrderSnapshot()
+
+	om.MoveToFront("c")
+	om.SortByValue(func(a, b int) bool { return a > b })
+	om.MoveToBack("d")
+	if keys := om.KeysSlice(); slices.Equal(keys, original) {
+		t.Fatalf("Expected the order to be scrambled, got %v", keys)
+	}
+	scrambled := om.OrderSnapshot()
+
+	if err := om.RestoreOrder(original); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if keys := om.KeysSlice(); !slices.Equal(keys, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected the original order [a b c d], got %v", keys)
+	}
+	if values := om.ValuesSlice(); !slices.Equal(values, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected values to follow their keys, got %v", values)
+	}
+
+	t.Run("Rejects", func(t *testing.T) {
+		for _, keys := range [][]string{
+			{"a", "b", "c"},
+			{"a", "b", "c", "d", "e"},
+			{"a", "b", "c", "x"},
+			{"a", "b", "c", "c"},
+		} {
+			if err := om.RestoreOrder(keys); err == nil {
+				t.Errorf("Expected an error restoring %v", keys)
+			}
+		}
+		if keys := om.KeysSlice(); !slices.Equal(keys, original) {
+			t.Errorf("Expected a rejected restore to leave the order unchanged, got %v", keys)
+		}
+	})
+
+	t.Run("SnapshotIsIndependent", func(t *testing.T) {
+		snapshot := om.OrderSnapshot()
+		om.MoveToFront("d")
+		if !slices.Equal(snapshot, original) {
+			t.Errorf("Expected the snapshot to be unaffected by later moves, got %v", snapshot)
+		}
+		if err := om.RestoreOrder(scrambled); err != nil || !slices.Equal(om.KeysSlice(), scrambled) {
+			t.Errorf("Expected to restore the scrambled order %v, got %v (err=%v)", scrambled, om.KeysSlice(), err)
+		}
+	})
+}
+
+func TestCap(t *testing.T) {
+	type capMap interface {
+		maps.AbstractMap[int, int]
+		Cap() int
+		ClearAndShrink()
+		Clone() maps.AbstractMap[int, int]
+		Grow(n int)
+	}
+	factories := map[string]func() capMap{
+		"UnorderedMap": func() capMap { return maps.NewUnorderedMap[int, int]() },
+		"OrderedMap":   func() capMap { return maps.NewOrderedMap[int, int]() },
+	}
+
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			m := factory()
+			last := m.Cap()
+			check := func(step string) {
+				t.Helper()
+				if got := m.Cap(); got < last {
+					t.Errorf("%s: expected Cap not to decrease from %d, got %d", step, last, got)
+				} else {
+					last = got
+				}
+				if m.Cap() < m.Len() {
+					t.Errorf("%s: expected Cap %d to be at least Len %d", step, m.Cap(), m.Len())
+				}
+				if om, ok := m.(*maps.OrderedMap[int, int]); ok {
+					if n := maps.OrderedMapListLen(om); n != om.Len() {
+						t.Errorf("%s: expected list length %d to equal Len %d", step, n, om.Len())
+					}
+				}
+			}
+
+			for i := range 10 {
+				m.Store(i, i)
+				check("Store")
+			}
+			for i := range 5 {
+				m.Delete(i)
+				check("Delete")
+			}
+			m.Clear()
+			check("Clear")
+			if m.Cap() != 10 {
+				t.Errorf("Expected Cap 10 after Clear, got %d", m.Cap())
+			}
+			m.Grow(20)
+			check("Grow")
+			if m.Cap() != 20 {
+				t.Errorf("Expected Cap 20 after Grow, got %d", m.Cap())
+			}
+			m.Store(1, 1)
+			if allocs := testing.AllocsPerRun(10, func() { m.Grow(10) }); allocs != 0 {
+				t.Errorf("Expected Grow within Cap not to reallocate, got %v allocations", allocs)
+			}
+			check("Grow within Cap")
+
+			for i := range 10 {
+				m.Store(i, i)
+			}
+			if clone := m.Clone().(capMap); clone.Cap() < clone.Len() {
+				t.Errorf("Expected clone Cap %d to be at least Len %d", clone.Cap(), clone.Len())
+			}
+
+			m.ClearAndShrink()
+			if m.Cap() != 0 {
+				t.Errorf("Expected Cap 0 after ClearAndShrink, got %d", m.Cap())
+			}
+			last = 0
+			m.Store(1, 1)
+			check("Store after shrink")
+		})
+	}
+
+	if got := maps.NewOrderedMapWithCapacity[int, int](64).Cap(); got != 64 {
+		t.Errorf("Expected capacity hint 64, got %d", got)
+	}
+	if got := maps.NewUnorderedMapWithCapacity[int, int](64).Cap(); got != 64 {
+		t.Errorf("Expected capacity hint 64, got %d", got)
+	}
+}
@@ -0,0 +1,103 @@
+package maps
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler, encoding the map in the
+// query-string form k1=v1&k2=v2 in insertion order. Keys and values are
+// escaped with url.QueryEscape, so they may contain '=', '&' and spaces.
+// Keys and values must have an underlying string type; anything else is an
+// error.
+func (om *OrderedMap[K, V]) MarshalText() ([]byte, error) {
+	var buf strings.Builder
+	if om == nil || om.l == nil {
+		return []byte{}, nil
+	}
+	for element := om.l.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*entry[K, V])
+		key, err := stringKey(entry.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := stringValue(entry.value)
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('&')
+		}
+		buf.WriteString(url.QueryEscape(key))
+		buf.WriteByte('=')
+		buf.WriteString(url.QueryEscape(value))
+	}
+	return []byte(buf.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, replacing the map's
+// contents with the pairs of a k1=v1&k2=v2 string stored in the order they
+// appear. A pair without '=' has an empty value, and a repeated key keeps
+// its first position and its last value. If text is invalid the map is left
+// untouched and an error is returned.
+// Keys and values must have an underlying string type; anything else is an
+// error.
+func (om *OrderedMap[K, V]) UnmarshalText(text []byte) error {
+	om.checkMutable("UnmarshalText")
+	var entries []entry[K, V]
+	for pair := range strings.SplitSeq(string(text), "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		k, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return fmt.Errorf("maps: invalid key in %q: %w", pair, err)
+		}
+		v, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			return fmt.Errorf("maps: invalid value in %q: %w", pair, err)
+		}
+		key, err := parseStringKey[K](k)
+		if err != nil {
+			return err
+		}
+		value, err := parseStringValue[V](v)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry[K, V]{key: key, value: value})
+	}
+
+	if om.l == nil {
+		om.init()
+	} else {
+		om.Clear()
+	}
+	for _, e := range entries {
+		om.Store(e.key, e.value)
+	}
+	return nil
+}
+
+// stringValue converts a value with an underlying string type to a string.
+func stringValue[V any](value V) (string, error) {
+	v := reflect.ValueOf(&value).Elem()
+	if v.Kind() != reflect.String {
+		return "", fmt.Errorf("maps: value type %s is not a string type", v.Type())
+	}
+	return v.String(), nil
+}
+
+// parseStringValue converts s to a value with an underlying string type.
+func parseStringValue[V any](s string) (V, error) {
+	var value V
+	v := reflect.ValueOf(&value).Elem()
+	if v.Kind() != reflect.String {
+		return value, fmt.Errorf("maps: value type %s is not a string type", v.Type())
+	}
+	v.SetString(s)
+	return value, nil
+}
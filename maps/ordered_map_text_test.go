@@ -0,0 +1,80 @@
+package maps_test
+
+import (
+	"encoding"
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*maps.OrderedMap[string, string])(nil)
+	_ encoding.TextUnmarshaler = (*maps.OrderedMap[string, string])(nil)
+)
+
+func TestOrderedMapText(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		om := maps.Of(
+			maps.P("z", "last letter"),
+			maps.P("a=b", "x&y"),
+			maps.P("plus+sign", "100%"),
+		)
+		text, err := om.MarshalText()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := "z=last+letter&a%3Db=x%26y&plus%2Bsign=100%25"
+		if string(text) != expected {
+			t.Errorf("Expected %q, got %q", expected, text)
+		}
+
+		decoded := maps.NewOrderedMap[string, string]()
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !maps.EqualOrdered[string, string](om, decoded) {
+			t.Errorf("Expected %v, got %v", om, decoded)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		text, err := maps.NewOrderedMap[string, string]().MarshalText()
+		if err != nil || string(text) != "" {
+			t.Errorf("Expected empty text, got %q, %v", text, err)
+		}
+		om := maps.Of(maps.P("stale", "entry"))
+		if err := om.UnmarshalText(nil); err != nil || om.Len() != 0 {
+			t.Errorf("Expected empty text to clear the map, got %v, %v", om, err)
+		}
+	})
+
+	t.Run("Lenient", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, string]()
+		if err := om.UnmarshalText([]byte("b=1&&a&b=2")); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got := om.KeysSlice(); !slices.Equal(got, []string{"b", "a"}) {
+			t.Errorf("Expected keys [b a], got %v", got)
+		}
+		if v, _ := om.Load("b"); v != "2" {
+			t.Errorf("Expected the last value of b, got %q", v)
+		}
+		if v, ok := om.Load("a"); !ok || v != "" {
+			t.Errorf("Expected a to be present and empty, got %q, %v", v, ok)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		om := maps.Of(maps.P("keep", "me"))
+		if err := om.UnmarshalText([]byte("a=%zz")); err == nil {
+			t.Errorf("Expected an error for a bad escape")
+		}
+		if v, _ := om.Load("keep"); v != "me" {
+			t.Errorf("Expected the map to be untouched after an error")
+		}
+		if _, err := maps.Of(maps.P("a", 1)).MarshalText(); err == nil {
+			t.Errorf("Expected an error for a non-string value type")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package maps
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// xmlEntryName is the element name of each entry in the XML encoding.
+const xmlEntryName = "entry"
+
+// MarshalXML implements xml.Marshaler, encoding the map as a sequence of
+// <entry key="k">v</entry> elements in insertion order inside start. The
+// encoder escapes keys and values, so they may contain any characters valid
+// in XML. Keys and values must have an underlying string type; anything else
+// is an error.
+func (om *OrderedMap[K, V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// Marshalled on its own the map is named after its type, and the type
+	// arguments in OrderedMap[K,V] are not valid in an XML name.
+	if i := strings.IndexByte(start.Name.Local, '['); i >= 0 {
+		start.Name.Local = start.Name.Local[:i]
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if om != nil && om.l != nil {
+		for element := om.l.Front(); element != nil; element = element.Next() {
+			entry := element.Value.(*entry[K, V])
+			key, err := stringKey(entry.key)
+			if err != nil {
+				return err
+			}
+			value, err := stringValue(entry.value)
+			if err != nil {
+				return err
+			}
+			entryStart := xml.StartElement{
+				Name: xml.Name{Local: xmlEntryName},
+				Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+			}
+			if err := e.EncodeElement(value, entryStart); err != nil {
+				return err
+			}
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the map's contents with
+// the <entry key="k">v</entry> elements inside start, stored in the order
+// they appear. A repeated key keeps its first position and its last value.
+// If the input is invalid the map is left untouched and an error is returned.
+// Keys and values must have an underlying string type; anything else is an
+// error.
+func (om *OrderedMap[K, V]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	om.checkMutable("UnmarshalXML")
+	var entries []entry[K, V]
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != xmlEntryName {
+				return fmt.Errorf("maps: unexpected element <%s> in OrderedMap, expected <%s>", t.Name.Local, xmlEntryName)
+			}
+			k, ok := xmlAttr(t, "key")
+			if !ok {
+				return fmt.Errorf("maps: <%s> element has no key attribute", xmlEntryName)
+			}
+			var v string
+			if err := d.DecodeElement(&v, &t); err != nil {
+				return err
+			}
+			key, err := parseStringKey[K](k)
+			if err != nil {
+				return err
+			}
+			value, err := parseStringValue[V](v)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry[K, V]{key: key, value: value})
+		case xml.EndElement:
+			if om.l == nil {
+				om.init()
+			} else {
+				om.Clear()
+			}
+			for _, e := range entries {
+				om.Store(e.key, e.value)
+			}
+			return nil
+		}
+	}
+}
+
+// xmlAttr returns the value of the attribute of start with the given local
+// name.
+func xmlAttr(start xml.StartElement, name string) (string, bool) {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
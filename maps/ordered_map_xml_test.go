@@ -0,0 +1,103 @@
+package maps_test
+
+import (
+	"encoding/xml"
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+var (
+	_ xml.Marshaler   = (*maps.OrderedMap[string, string])(nil)
+	_ xml.Unmarshaler = (*maps.OrderedMap[string, string])(nil)
+)
+
+func TestOrderedMapXML(t *testing.T) {
+	type config struct {
+		XMLName  xml.Name                         `xml:"config"`
+		Settings *maps.OrderedMap[string, string] `xml:"settings"`
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		om := maps.Of(
+			maps.P("z", "last"),
+			maps.P(`quote"amp&`, "<tag> & 'apos'"),
+			maps.P("a<b>", `"quoted"`),
+		)
+		data, err := xml.Marshal(config{Settings: om})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := `<config><settings>` +
+			`<entry key="z">last</entry>` +
+			`<entry key="quote&#34;amp&amp;">&lt;tag&gt; &amp; &#39;apos&#39;</entry>` +
+			`<entry key="a&lt;b&gt;">&#34;quoted&#34;</entry>` +
+			`</settings></config>`
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+
+		var decoded config
+		if err := xml.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !maps.EqualOrdered[string, string](om, decoded.Settings) {
+			t.Errorf("Expected %v, got %v", om, decoded.Settings)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		data, err := xml.Marshal(config{Settings: maps.NewOrderedMap[string, string]()})
+		if err != nil || string(data) != "<config><settings></settings></config>" {
+			t.Errorf("Expected empty settings, got %s, %v", data, err)
+		}
+		om := maps.Of(maps.P("stale", "entry"))
+		if err := xml.Unmarshal([]byte("<settings></settings>"), om); err != nil || om.Len() != 0 {
+			t.Errorf("Expected empty XML to clear the map, got %v, %v", om, err)
+		}
+	})
+
+	t.Run("RepeatedKey", func(t *testing.T) {
+		om := maps.NewOrderedMap[string, string]()
+		data := `<m><entry key="b">1</entry><entry key="a"></entry><entry key="b">2</entry></m>`
+		if err := xml.Unmarshal([]byte(data), om); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got := om.KeysSlice(); !slices.Equal(got, []string{"b", "a"}) {
+			t.Errorf("Expected keys [b a], got %v", got)
+		}
+		if v, _ := om.Load("b"); v != "2" {
+			t.Errorf("Expected the last value 2 for b, got %q", v)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		om := maps.Of(maps.P("keep", "me"))
+		for _, data := range []string{
+			`<m><entry>no key</entry></m>`,
+			`<m><item key="a">wrong element</item></m>`,
+			`<m><entry key="a">unterminated</m>`,
+		} {
+			if err := xml.Unmarshal([]byte(data), om); err == nil {
+				t.Errorf("Expected an error for %s", data)
+			}
+		}
+		if v, ok := om.Load("keep"); !ok || v != "me" || om.Len() != 1 {
+			t.Errorf("Expected invalid input to leave the map untouched, got %v", om)
+		}
+	})
+
+	t.Run("TopLevel", func(t *testing.T) {
+		data, err := xml.Marshal(maps.Of(maps.P("a", "b")))
+		if err != nil || string(data) != `<OrderedMap><entry key="a">b</entry></OrderedMap>` {
+			t.Errorf("Expected a valid element name, got %s, %v", data, err)
+		}
+	})
+
+	t.Run("NonStringValue", func(t *testing.T) {
+		if _, err := xml.Marshal(maps.Of(maps.P("a", 1))); err == nil {
+			t.Error("Expected an error for int values")
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package maps
+
+// Pair is a single key-value entry of a map.
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// P returns a Pair holding key and value, for building maps inline with Of
+// or StoreAll.
+func P[K, V any](key K, value V) Pair[K, V] {
+	return Pair[K, V]{Key: key, Value: value}
+}
+
+// Of returns a new OrderedMap holding pairs in argument order. A repeated key
+// keeps its first position and its last value.
+func Of[K comparable, V any](pairs ...Pair[K, V]) *OrderedMap[K, V] {
+	om := NewOrderedMapWithCapacity[K, V](len(pairs))
+	om.StoreAll(pairs...)
+	return om
+}
@@ -0,0 +1,47 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestOf(t *testing.T) {
+	t.Run("PreservesArgumentOrder", func(t *testing.T) {
+		om := maps.Of(maps.P("c", 1), maps.P("a", 2), maps.P("b", 3))
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"c", "a", "b"}) {
+			t.Errorf("Expected keys [c a b], got %v", keys)
+		}
+		if values := om.ValuesSlice(); !slices.Equal(values, []int{1, 2, 3}) {
+			t.Errorf("Expected values [1 2 3], got %v", values)
+		}
+	})
+
+	t.Run("DuplicateKeepsLastValueAtFirstPosition", func(t *testing.T) {
+		om := maps.Of(maps.P("a", 1), maps.P("b", 2), maps.P("a", 3))
+		if keys := om.KeysSlice(); !slices.Equal(keys, []string{"a", "b"}) {
+			t.Errorf("Expected keys [a b], got %v", keys)
+		}
+		if value, _ := om.Load("a"); value != 3 {
+			t.Errorf("Expected a=3, got %d", value)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if om := maps.Of[string, int](); om.Len() != 0 {
+			t.Errorf("Expected an empty map, got length %d", om.Len())
+		}
+	})
+}
+
+func TestStoreAll(t *testing.T) {
+	um := maps.NewUnorderedMap[string, int]()
+	um.Store("a", 0)
+	um.StoreAll(maps.P("a", 1), maps.P("b", 2))
+
+	expected := map[string]int{"a": 1, "b": 2}
+	if !maps.Equal[string, int](um, maps.FromGoMaps(maps.NewUnorderedMap[string, int](), expected)) {
+		t.Errorf("Expected %v, got %v", expected, um)
+	}
+}
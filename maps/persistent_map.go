@@ -0,0 +1,309 @@
+package maps
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+// hamtBits is the number of hash bits consumed per trie level.
+const hamtBits = 5
+
+// hamtSlot is one occupied position of a hamtNode: either a leaf holding an
+// entry or a pointer to a subtree. For a leaf, hash is the key's hash; for a
+// collision subtree it is the shared hash of all its entries.
+type hamtSlot[K comparable, V any] struct {
+	node  *hamtNode[K, V]
+	hash  uint64
+	key   K
+	value V
+}
+
+// hamtNode is an immutable node of the hash array mapped trie behind
+// PersistentMap. A bitmap node stores one slot per set bit of bitmap, in bit
+// order. A collision node holds leaves whose keys share a full hash and is
+// only reached when that hash is being looked up.
+type hamtNode[K comparable, V any] struct {
+	bitmap    uint32
+	slots     []hamtSlot[K, V]
+	collision bool
+}
+
+// position returns the bit for hash at shift and the index its slot has, or
+// would have, in n.slots.
+func (n *hamtNode[K, V]) position(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & (1<<hamtBits - 1))
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[K, V]) load(hash uint64, shift uint, key K) (value V, ok bool) {
+	for {
+		if n.collision {
+			for _, s := range n.slots {
+				if s.key == key {
+					return s.value, true
+				}
+			}
+			return value, false
+		}
+		bit, i := n.position(hash, shift)
+		if n.bitmap&bit == 0 {
+			return value, false
+		}
+		s := n.slots[i]
+		if s.node == nil {
+			if s.hash == hash && s.key == key {
+				return s.value, true
+			}
+			return value, false
+		}
+		if s.node.collision && s.hash != hash {
+			return value, false
+		}
+		n, shift = s.node, shift+hamtBits
+	}
+}
+
+// with returns a copy of n with key set to value, sharing every subtree it
+// does not touch, and whether key was newly added.
+func (n *hamtNode[K, V]) with(hash uint64, shift uint, key K, value V) (*hamtNode[K, V], bool) {
+	leaf := hamtSlot[K, V]{hash: hash, key: key, value: value}
+	if n.collision {
+		for i, s := range n.slots {
+			if s.key == key {
+				return n.replace(i, leaf), false
+			}
+		}
+		slots := append(n.slots[:len(n.slots):len(n.slots)], leaf)
+		return &hamtNode[K, V]{slots: slots, collision: true}, true
+	}
+
+	bit, i := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		slots := make([]hamtSlot[K, V], len(n.slots)+1)
+		copy(slots, n.slots[:i])
+		slots[i] = leaf
+		copy(slots[i+1:], n.slots[i:])
+		return &hamtNode[K, V]{bitmap: n.bitmap | bit, slots: slots}, true
+	}
+
+	s := n.slots[i]
+	switch {
+	case s.node == nil && s.hash == hash && s.key == key:
+		return n.replace(i, leaf), false
+	case s.node == nil && s.hash == hash:
+		collision := &hamtNode[K, V]{slots: []hamtSlot[K, V]{s, leaf}, collision: true}
+		return n.replace(i, hamtSlot[K, V]{node: collision, hash: hash}), true
+	case s.node == nil || (s.node.collision && s.hash != hash):
+		return n.replace(i, hamtSlot[K, V]{node: hamtMerge(shift+hamtBits, s, leaf)}), true
+	default:
+		child, added := s.node.with(hash, shift+hamtBits, key, value)
+		return n.replace(i, hamtSlot[K, V]{node: child, hash: s.hash}), added
+	}
+}
+
+// without returns a copy of n with key removed, or nil if nothing would be
+// left, and whether key was present.
+func (n *hamtNode[K, V]) without(hash uint64, shift uint, key K) (*hamtNode[K, V], bool) {
+	if n.collision {
+		for i, s := range n.slots {
+			if s.key == key {
+				return n.remove(i, 0), true
+			}
+		}
+		return n, false
+	}
+
+	bit, i := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	s := n.slots[i]
+	if s.node == nil {
+		if s.hash != hash || s.key != key {
+			return n, false
+		}
+		return n.remove(i, bit), true
+	}
+	if s.node.collision && s.hash != hash {
+		return n, false
+	}
+	child, removed := s.node.without(hash, shift+hamtBits, key)
+	switch {
+	case !removed:
+		return n, false
+	case child == nil:
+		return n.remove(i, bit), true
+	case len(child.slots) == 1 && child.slots[0].node == nil:
+		// Pull a lone leaf up so lookups do not walk a chain of single-slot nodes.
+		return n.replace(i, child.slots[0]), true
+	default:
+		return n.replace(i, hamtSlot[K, V]{node: child, hash: s.hash}), true
+	}
+}
+
+// replace returns a copy of n with slot i set to s.
+func (n *hamtNode[K, V]) replace(i int, s hamtSlot[K, V]) *hamtNode[K, V] {
+	slots := make([]hamtSlot[K, V], len(n.slots))
+	copy(slots, n.slots)
+	slots[i] = s
+	return &hamtNode[K, V]{bitmap: n.bitmap, slots: slots, collision: n.collision}
+}
+
+// remove returns a copy of n without slot i, whose bitmap bit is bit, or nil
+// if that was the last slot.
+func (n *hamtNode[K, V]) remove(i int, bit uint32) *hamtNode[K, V] {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	slots := make([]hamtSlot[K, V], 0, len(n.slots)-1)
+	slots = append(slots, n.slots[:i]...)
+	slots = append(slots, n.slots[i+1:]...)
+	return &hamtNode[K, V]{bitmap: n.bitmap &^ bit, slots: slots, collision: n.collision}
+}
+
+func (n *hamtNode[K, V]) walk(f func(key K, value V) bool) bool {
+	for _, s := range n.slots {
+		if s.node != nil {
+			if !s.node.walk(f) {
+				return false
+			}
+		} else if !f(s.key, s.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// hamtMerge builds the subtree at shift holding two slots with different
+// hashes, each a leaf or a collision node.
+func hamtMerge[K comparable, V any](shift uint, a, b hamtSlot[K, V]) *hamtNode[K, V] {
+	ia := (a.hash >> shift) & (1<<hamtBits - 1)
+	ib := (b.hash >> shift) & (1<<hamtBits - 1)
+	if ia == ib {
+		child := hamtMerge(shift+hamtBits, a, b)
+		return &hamtNode[K, V]{bitmap: 1 << ia, slots: []hamtSlot[K, V]{{node: child}}}
+	}
+	if ia > ib {
+		a, b = b, a
+	}
+	return &hamtNode[K, V]{bitmap: 1<<ia | 1<<ib, slots: []hamtSlot[K, V]{a, b}}
+}
+
+// PersistentMap is an immutable map backed by a hash array mapped trie.
+// With and Without return new maps that share all untouched structure with
+// the receiver, so updates cost O(log n) time and space and every earlier
+// version stays valid and unchanged. Values are shared shallowly.
+//
+// PersistentMap implements AbstractMap as a read-only view: reads work as
+// usual, while Store, Delete and the other mutating methods panic with an
+// error wrapping ErrReadOnly. Range order is unspecified but stable for a
+// given version. A PersistentMap is safe for concurrent use.
+type PersistentMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, V]
+	seed maphash.Seed
+	root *hamtNode[K, V]
+	len  int
+}
+
+// NewPersistentMap creates an empty PersistentMap.
+func NewPersistentMap[K comparable, V any]() *PersistentMap[K, V] {
+	return newPersistentMap(maphash.MakeSeed(), &hamtNode[K, V]{}, 0)
+}
+
+func newPersistentMap[K comparable, V any](seed maphash.Seed, root *hamtNode[K, V], len int) *PersistentMap[K, V] {
+	pm := &PersistentMap[K, V]{
+		seed: seed,
+		root: root,
+		len:  len,
+	}
+	pm.DefaultAbstractMap = NewDefaultAbstractMap(pm)
+	return pm
+}
+
+// With returns a map that has value stored for key and otherwise matches pm.
+// Time complexity: O(log n)
+func (pm *PersistentMap[K, V]) With(key K, value V) *PersistentMap[K, V] {
+	root, added := pm.root.with(maphash.Comparable(pm.seed, key), 0, key, value)
+	len := pm.len
+	if added {
+		len++
+	}
+	return newPersistentMap(pm.seed, root, len)
+}
+
+// Without returns a map that lacks key and otherwise matches pm. If key is
+// not present pm itself is returned.
+// Time complexity: O(log n)
+func (pm *PersistentMap[K, V]) Without(key K) *PersistentMap[K, V] {
+	root, removed := pm.root.without(maphash.Comparable(pm.seed, key), 0, key)
+	if !removed {
+		return pm
+	}
+	if root == nil {
+		root = &hamtNode[K, V]{}
+	}
+	return newPersistentMap(pm.seed, root, pm.len-1)
+}
+
+// Len returns the number of entries in the map.
+// Time complexity: O(1)
+func (pm *PersistentMap[K, V]) Len() int {
+	return pm.len
+}
+
+// Load returns the value stored for key.
+// Time complexity: O(log n)
+func (pm *PersistentMap[K, V]) Load(key K) (value V, ok bool) {
+	return pm.root.load(maphash.Comparable(pm.seed, key), 0, key)
+}
+
+// Range calls f for each entry until f returns false.
+// Time complexity: O(n)
+func (pm *PersistentMap[K, V]) Range(f func(key K, value V) bool) {
+	pm.root.walk(f)
+}
+
+// Clear panics; use NewPersistentMap for an empty map.
+func (pm *PersistentMap[K, V]) Clear() {
+	readOnlyPanic("Clear")
+}
+
+// CompareAndDelete panics; PersistentMap is immutable.
+func (pm *PersistentMap[K, V]) CompareAndDelete(K, V) bool {
+	readOnlyPanic("CompareAndDelete")
+	return false
+}
+
+// CompareAndSwap panics; PersistentMap is immutable.
+func (pm *PersistentMap[K, V]) CompareAndSwap(K, V, V) bool {
+	readOnlyPanic("CompareAndSwap")
+	return false
+}
+
+// Delete panics; use Without instead.
+func (pm *PersistentMap[K, V]) Delete(K) {
+	readOnlyPanic("Delete")
+}
+
+// LoadAndDelete panics; use Load and Without instead.
+func (pm *PersistentMap[K, V]) LoadAndDelete(K) (value V, loaded bool) {
+	readOnlyPanic("LoadAndDelete")
+	return value, false
+}
+
+// LoadOrStore panics; use Load and With instead.
+func (pm *PersistentMap[K, V]) LoadOrStore(K, V) (actual V, loaded bool) {
+	readOnlyPanic("LoadOrStore")
+	return actual, false
+}
+
+// Store panics; use With instead.
+func (pm *PersistentMap[K, V]) Store(K, V) {
+	readOnlyPanic("Store")
+}
+
+// Swap panics; use Load and With instead.
+func (pm *PersistentMap[K, V]) Swap(K, V) (previous V, loaded bool) {
+	readOnlyPanic("Swap")
+	return previous, false
+}
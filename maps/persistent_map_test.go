@@ -0,0 +1,132 @@
+package maps_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestPersistentMapVersions(t *testing.T) {
+	v0 := maps.NewPersistentMap[int, string]()
+	v1 := v0.With(1, "one")
+	v2 := v1.With(2, "two")
+	v3 := v2.With(1, "uno")
+	v4 := v3.Without(2)
+
+	versions := []struct {
+		m        *maps.PersistentMap[int, string]
+		expected map[int]string
+	}{
+		{v0, map[int]string{}},
+		{v1, map[int]string{1: "one"}},
+		{v2, map[int]string{1: "one", 2: "two"}},
+		{v3, map[int]string{1: "uno", 2: "two"}},
+		{v4, map[int]string{1: "uno"}},
+	}
+	for i, version := range versions {
+		if version.m.Len() != len(version.expected) {
+			t.Errorf("Version %d: expected length %d, got %d", i, len(version.expected), version.m.Len())
+		}
+		for key, value := range version.expected {
+			if got, ok := version.m.Load(key); !ok || got != value {
+				t.Errorf("Version %d: expected %d=%s, got %s (ok=%v)", i, key, value, got, ok)
+			}
+		}
+		count := 0
+		version.m.Range(func(key int, value string) bool {
+			count++
+			if version.expected[key] != value {
+				t.Errorf("Version %d: unexpected entry %d=%s", i, key, value)
+			}
+			return true
+		})
+		if count != len(version.expected) {
+			t.Errorf("Version %d: expected Range to visit %d entries, got %d", i, len(version.expected), count)
+		}
+	}
+
+	if v4.Without(42) != v4 {
+		t.Error("Expected Without on a missing key to return the same map")
+	}
+}
+
+func TestPersistentMapLarge(t *testing.T) {
+	const size = 10_000
+	pm := maps.NewPersistentMap[int, int]()
+	for i := 0; i < size; i++ {
+		pm = pm.With(i, i*i)
+	}
+	half := pm
+	for i := 0; i < size; i += 2 {
+		pm = pm.Without(i)
+	}
+
+	if half.Len() != size || pm.Len() != size/2 {
+		t.Fatalf("Expected lengths %d and %d, got %d and %d", size, size/2, half.Len(), pm.Len())
+	}
+	for i := 0; i < size; i++ {
+		if value, ok := half.Load(i); !ok || value != i*i {
+			t.Fatalf("Expected earlier version to keep %d=%d, got %d (ok=%v)", i, i*i, value, ok)
+		}
+		_, ok := pm.Load(i)
+		if ok != (i%2 == 1) {
+			t.Fatalf("Expected key %d present=%v, got %v", i, i%2 == 1, ok)
+		}
+	}
+}
+
+func TestPersistentMapReadOnly(t *testing.T) {
+	pm := maps.NewPersistentMap[string, int]().With("a", 1)
+
+	mutations := map[string]func(){
+		"Clear":            func() { pm.Clear() },
+		"CompareAndDelete": func() { pm.CompareAndDelete("a", 1) },
+		"CompareAndSwap":   func() { pm.CompareAndSwap("a", 1, 2) },
+		"Delete":           func() { pm.Delete("a") },
+		"LoadAndDelete":    func() { pm.LoadAndDelete("a") },
+		"LoadOrStore":      func() { pm.LoadOrStore("b", 2) },
+		"Store":            func() { pm.Store("b", 2) },
+		"Swap":             func() { pm.Swap("a", 2) },
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if err, ok := recover().(error); !ok || !errors.Is(err, maps.ErrReadOnly) {
+					t.Errorf("Expected panic wrapping ErrReadOnly, got %v", err)
+				}
+			}()
+			mutate()
+		})
+	}
+
+	if !pm.ContainsKey("a") || pm.Len() != 1 {
+		t.Error("Expected the map to be unchanged")
+	}
+}
+
+// BenchmarkPersistentUpdate compares deriving an updated version of a
+// 10k-entry map with PersistentMap.With and with cloning an UnorderedMap.
+func BenchmarkPersistentUpdate(b *testing.B) {
+	const size = 10_000
+	pm := maps.NewPersistentMap[int, int]()
+	um := maps.NewUnorderedMap[int, int]()
+	for i := 0; i < size; i++ {
+		pm = pm.With(i, i)
+		um.Store(i, i)
+	}
+
+	b.Run("PersistentMap", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			pm.With(i%size, i)
+		}
+	})
+
+	b.Run("CloneUnorderedMap", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			um.Clone().Store(i%size, i)
+		}
+	})
+}
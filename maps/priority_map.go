@@ -0,0 +1,142 @@
+package maps
+
+import "container/heap"
+
+// priorityItem is a key and its priority stored in a PriorityMap's heap.
+type priorityItem[K comparable, P any] struct {
+	key      K
+	priority P
+}
+
+// priorityHeap implements heap.Interface, keeping index in sync with the
+// position of every key so PriorityMap can fix an item after Update.
+type priorityHeap[K comparable, P any] struct {
+	items []priorityItem[K, P]
+	index map[K]int
+	less  func(a, b P) bool
+}
+
+func (h *priorityHeap[K, P]) Len() int { return len(h.items) }
+
+func (h *priorityHeap[K, P]) Less(i, j int) bool {
+	return h.less(h.items[i].priority, h.items[j].priority)
+}
+
+func (h *priorityHeap[K, P]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].key] = i
+	h.index[h.items[j].key] = j
+}
+
+func (h *priorityHeap[K, P]) Push(x any) {
+	item := x.(priorityItem[K, P])
+	h.index[item.key] = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *priorityHeap[K, P]) Pop() any {
+	last := len(h.items) - 1
+	item := h.items[last]
+	h.items[last] = priorityItem[K, P]{}
+	h.items = h.items[:last]
+	delete(h.index, item.key)
+	return item
+}
+
+// PriorityMap is a priority queue of distinct keys that supports changing a
+// queued key's priority, as needed by algorithms such as Dijkstra's shortest
+// paths. It pairs a binary heap with a map from each key to its heap
+// position. The key with the smallest priority according to less is at the
+// front.
+type PriorityMap[K comparable, P any] struct {
+	h priorityHeap[K, P]
+}
+
+// NewPriorityMap creates an empty PriorityMap ordered by less.
+func NewPriorityMap[K comparable, P any](less func(a, b P) bool) *PriorityMap[K, P] {
+	return &PriorityMap[K, P]{
+		h: priorityHeap[K, P]{
+			index: make(map[K]int),
+			less:  less,
+		},
+	}
+}
+
+// Contains reports whether key is queued.
+// Time complexity: O(1)
+func (pm *PriorityMap[K, P]) Contains(key K) bool {
+	_, exists := pm.h.index[key]
+	return exists
+}
+
+// Priority returns the priority key is queued with, or false if it is not
+// queued.
+// Time complexity: O(1)
+func (pm *PriorityMap[K, P]) Priority(key K) (priority P, ok bool) {
+	if i, exists := pm.h.index[key]; exists {
+		return pm.h.items[i].priority, true
+	}
+	return priority, false
+}
+
+// Len returns the number of queued keys.
+// Time complexity: O(1)
+func (pm *PriorityMap[K, P]) Len() int {
+	return pm.h.Len()
+}
+
+// Peek returns the front key and its priority without removing it, or false
+// if the queue is empty.
+// Time complexity: O(1)
+func (pm *PriorityMap[K, P]) Peek() (key K, priority P, ok bool) {
+	if pm.h.Len() == 0 {
+		return key, priority, false
+	}
+	item := pm.h.items[0]
+	return item.key, item.priority, true
+}
+
+// Pop removes and returns the front key and its priority, or false if the
+// queue is empty.
+// Time complexity: O(log n)
+func (pm *PriorityMap[K, P]) Pop() (key K, priority P, ok bool) {
+	if pm.h.Len() == 0 {
+		return key, priority, false
+	}
+	item := heap.Pop(&pm.h).(priorityItem[K, P])
+	return item.key, item.priority, true
+}
+
+// Push queues key with priority. If key is already queued its priority is
+// replaced, as with Update.
+// Time complexity: O(log n)
+func (pm *PriorityMap[K, P]) Push(key K, priority P) {
+	if pm.Update(key, priority) {
+		return
+	}
+	heap.Push(&pm.h, priorityItem[K, P]{key: key, priority: priority})
+}
+
+// Update changes the priority of a queued key, moving it up or down as
+// needed. It returns false if key is not queued.
+// Time complexity: O(log n)
+func (pm *PriorityMap[K, P]) Update(key K, priority P) bool {
+	i, exists := pm.h.index[key]
+	if !exists {
+		return false
+	}
+	pm.h.items[i].priority = priority
+	heap.Fix(&pm.h, i)
+	return true
+}
+
+// Remove removes key from the queue and returns its priority, or false if
+// it was not queued.
+// Time complexity: O(log n)
+func (pm *PriorityMap[K, P]) Remove(key K) (priority P, ok bool) {
+	i, exists := pm.h.index[key]
+	if !exists {
+		return priority, false
+	}
+	return heap.Remove(&pm.h, i).(priorityItem[K, P]).priority, true
+}
@@ -0,0 +1,112 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestPriorityMap(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	t.Run("PopsInPriorityOrder", func(t *testing.T) {
+		pm := maps.NewPriorityMap[string](less)
+		pm.Push("c", 3)
+		pm.Push("a", 1)
+		pm.Push("d", 4)
+		pm.Push("b", 2)
+
+		if key, priority, ok := pm.Peek(); !ok || key != "a" || priority != 1 {
+			t.Errorf("Expected Peek to return a=1, got %s=%d (ok=%v)", key, priority, ok)
+		}
+		var order []string
+		for pm.Len() > 0 {
+			key, _, _ := pm.Pop()
+			order = append(order, key)
+		}
+		if !slices.Equal(order, []string{"a", "b", "c", "d"}) {
+			t.Errorf("Expected [a b c d], got %v", order)
+		}
+		if _, _, ok := pm.Pop(); ok {
+			t.Error("Expected Pop on an empty queue to fail")
+		}
+	})
+
+	t.Run("DecreaseKeyBelowQueued", func(t *testing.T) {
+		pm := maps.NewPriorityMap[string](less)
+		pm.Push("x", 10)
+		pm.Push("y", 5)
+		pm.Push("z", 7)
+
+		if !pm.Update("x", 1) {
+			t.Fatal("Expected Update of a queued key to succeed")
+		}
+		if key, priority, _ := pm.Pop(); key != "x" || priority != 1 {
+			t.Errorf("Expected x=1 first after decrease-key, got %s=%d", key, priority)
+		}
+
+		pm.Push("z", 20) // Push of a queued key updates it
+		if key, _, _ := pm.Pop(); key != "y" {
+			t.Errorf("Expected y after raising z, got %s", key)
+		}
+		if pm.Update("missing", 0) {
+			t.Error("Expected Update of a missing key to fail")
+		}
+	})
+
+	t.Run("ContainsAndRemove", func(t *testing.T) {
+		pm := maps.NewPriorityMap[string](less)
+		pm.Push("a", 1)
+		pm.Push("b", 2)
+
+		if priority, ok := pm.Remove("a"); !ok || priority != 1 {
+			t.Errorf("Expected Remove to return 1, got %d (ok=%v)", priority, ok)
+		}
+		if pm.Contains("a") || !pm.Contains("b") {
+			t.Error("Expected only b to remain")
+		}
+	})
+}
+
+func TestPriorityMapShortestPath(t *testing.T) {
+	type edge struct {
+		to     string
+		weight int
+	}
+	graph := map[string][]edge{
+		"A": {{"B", 7}, {"C", 9}, {"F", 14}},
+		"B": {{"A", 7}, {"C", 10}, {"D", 15}},
+		"C": {{"A", 9}, {"B", 10}, {"D", 11}, {"F", 2}},
+		"D": {{"B", 15}, {"C", 11}, {"E", 6}},
+		"E": {{"D", 6}, {"F", 9}},
+		"F": {{"A", 14}, {"C", 2}, {"E", 9}},
+	}
+
+	dist := map[string]int{}
+	queue := maps.NewPriorityMap[string](func(a, b int) bool { return a < b })
+	queue.Push("A", 0)
+	for queue.Len() > 0 {
+		node, d, _ := queue.Pop()
+		dist[node] = d
+		for _, e := range graph[node] {
+			if _, done := dist[e.to]; done {
+				continue
+			}
+			candidate := d + e.weight
+			if current, queued := queue.Priority(e.to); !queued {
+				queue.Push(e.to, candidate)
+			} else if candidate < current {
+				// F is first queued at 14 via A, then lowered to 11 via C.
+				queue.Update(e.to, candidate)
+			}
+		}
+	}
+
+	expected := map[string]int{"A": 0, "B": 7, "C": 9, "D": 20, "E": 20, "F": 11}
+	for node, d := range expected {
+		if dist[node] != d {
+			t.Errorf("Expected distance to %s to be %d, got %d", node, d, dist[node])
+		}
+	}
+}
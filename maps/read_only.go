@@ -0,0 +1,86 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrReadOnly is the panic value, wrapped with the name of the offending
+// method, raised when a map returned by ReadOnly or a frozen map is mutated.
+var ErrReadOnly = errors.New("maps: map is read-only")
+
+// readOnlyMap is the view returned by ReadOnly.
+type readOnlyMap[K, V any] struct {
+	m AbstractMap[K, V]
+}
+
+// ReadOnly returns a view of m that passes reads through to m and panics
+// with an error wrapping ErrReadOnly on any mutating method. Changes made to
+// m directly remain visible through the view.
+func ReadOnly[K, V any](m AbstractMap[K, V]) AbstractMap[K, V] {
+	if ro, ok := m.(*readOnlyMap[K, V]); ok {
+		return ro
+	}
+	return &readOnlyMap[K, V]{m: m}
+}
+
+func readOnlyPanic(method string) {
+	panic(fmt.Errorf("%w: %s called", ErrReadOnly, method))
+}
+
+func (ro *readOnlyMap[K, V]) Clear() {
+	readOnlyPanic("Clear")
+}
+
+func (ro *readOnlyMap[K, V]) CompareAndDelete(K, V) bool {
+	readOnlyPanic("CompareAndDelete")
+	return false
+}
+
+func (ro *readOnlyMap[K, V]) CompareAndSwap(K, V, V) bool {
+	readOnlyPanic("CompareAndSwap")
+	return false
+}
+
+func (ro *readOnlyMap[K, V]) Delete(K) {
+	readOnlyPanic("Delete")
+}
+
+func (ro *readOnlyMap[K, V]) Keys(f func(key K) bool) {
+	ro.m.Keys(f)
+}
+
+func (ro *readOnlyMap[K, V]) Len() int {
+	return ro.m.Len()
+}
+
+func (ro *readOnlyMap[K, V]) Load(key K) (V, bool) {
+	return ro.m.Load(key)
+}
+
+func (ro *readOnlyMap[K, V]) LoadAndDelete(K) (value V, loaded bool) {
+	readOnlyPanic("LoadAndDelete")
+	return value, false
+}
+
+func (ro *readOnlyMap[K, V]) LoadOrStore(K, V) (actual V, loaded bool) {
+	readOnlyPanic("LoadOrStore")
+	return actual, false
+}
+
+func (ro *readOnlyMap[K, V]) Range(f func(key K, value V) bool) {
+	ro.m.Range(f)
+}
+
+func (ro *readOnlyMap[K, V]) Store(K, V) {
+	readOnlyPanic("Store")
+}
+
+func (ro *readOnlyMap[K, V]) Swap(K, V) (previous V, loaded bool) {
+	readOnlyPanic("Swap")
+	return previous, false
+}
+
+func (ro *readOnlyMap[K, V]) Values(f func(value V) bool) {
+	ro.m.Values(f)
+}
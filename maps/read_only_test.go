@@ -0,0 +1,97 @@
+package maps_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestReadOnlyReads(t *testing.T) {
+	om := maps.NewOrderedMap[string, int]()
+	om.Store("a", 1)
+	om.Store("b", 2)
+	ro := maps.ReadOnly[string, int](om)
+
+	if value, ok := ro.Load("a"); !ok || value != 1 {
+		t.Errorf("Expected a=1, got %d (ok=%v)", value, ok)
+	}
+	if ro.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", ro.Len())
+	}
+
+	var keys []string
+	ro.Keys(func(key string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if !slices.Equal(keys, []string{"a", "b"}) {
+		t.Errorf("Expected keys [a b], got %v", keys)
+	}
+
+	var values []int
+	ro.Values(func(value int) bool {
+		values = append(values, value)
+		return true
+	})
+	if !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("Expected values [1 2], got %v", values)
+	}
+
+	count := 0
+	ro.Range(func(string, int) bool {
+		count++
+		return true
+	})
+	if count != 2 {
+		t.Errorf("Expected Range to visit 2 entries, got %d", count)
+	}
+
+	om.Store("c", 3)
+	if _, ok := ro.Load("c"); !ok {
+		t.Errorf("Expected view to reflect changes to the underlying map")
+	}
+
+	if maps.ReadOnly(ro) != ro {
+		t.Errorf("Expected wrapping a read-only view to return it unchanged")
+	}
+}
+
+func TestReadOnlyMutationsPanic(t *testing.T) {
+	um := maps.NewUnorderedMap[string, int]()
+	um.Store("a", 1)
+	ro := maps.ReadOnly[string, int](um)
+
+	mutations := map[string]func(){
+		"Clear":            func() { ro.Clear() },
+		"CompareAndDelete": func() { ro.CompareAndDelete("a", 1) },
+		"CompareAndSwap":   func() { ro.CompareAndSwap("a", 1, 2) },
+		"Delete":           func() { ro.Delete("a") },
+		"LoadAndDelete":    func() { ro.LoadAndDelete("a") },
+		"LoadOrStore":      func() { ro.LoadOrStore("b", 2) },
+		"Store":            func() { ro.Store("b", 2) },
+		"Swap":             func() { ro.Swap("a", 2) },
+	}
+
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, maps.ErrReadOnly) {
+					t.Errorf("Expected panic wrapping ErrReadOnly, got %v", err)
+					return
+				}
+				expected := "maps: map is read-only: " + name + " called"
+				if err.Error() != expected {
+					t.Errorf("Expected %q, got %q", expected, err.Error())
+				}
+			}()
+			mutate()
+		})
+	}
+
+	if um.Len() != 1 {
+		t.Errorf("Expected underlying map to be untouched, got length %d", um.Len())
+	}
+}
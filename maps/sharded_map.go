@@ -0,0 +1,254 @@
+package maps
+
+import (
+	"fmt"
+	"hash/maphash"
+	"sync"
+)
+
+// shard is one independently locked partition of a ShardedMap.
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  *UnorderedMap[K, V]
+}
+
+// ShardedMap is a concurrency-safe AbstractMap that spreads keys across a
+// fixed number of independently locked UnorderedMap shards, so goroutines
+// working on keys in different shards do not contend for the same lock.
+// Operations on a single key, including the read-modify-write ones, lock
+// only that key's shard and are atomic.
+//
+// Len, Range, Keys, Values and Clear visit the shards one at a time and
+// therefore do not observe a consistent snapshot of the whole map while
+// other goroutines are writing. Callbacks passed to Range run while a
+// shard's read lock is held and must not modify the map.
+type ShardedMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, V]
+	seed   maphash.Seed
+	shards []*shard[K, V]
+}
+
+// NewShardedMap creates an empty ShardedMap with the given number of shards.
+// It panics if shards is less than one.
+func NewShardedMap[K comparable, V any](shards int) *ShardedMap[K, V] {
+	if shards < 1 {
+		panic(fmt.Sprintf("maps: NewShardedMap shard count must be at least 1, got %d", shards))
+	}
+	sm := &ShardedMap[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]*shard[K, V], shards),
+	}
+	for i := range sm.shards {
+		sm.shards[i] = &shard[K, V]{m: NewUnorderedMap[K, V]()}
+	}
+	sm.DefaultAbstractMap = NewDefaultAbstractMap(sm)
+	return sm
+}
+
+// shardIndex returns the index of the shard responsible for key.
+func (sm *ShardedMap[K, V]) shardIndex(key K) int {
+	return int(maphash.Comparable(sm.seed, key) % uint64(len(sm.shards)))
+}
+
+// shardFor returns the shard responsible for key.
+func (sm *ShardedMap[K, V]) shardFor(key K) *shard[K, V] {
+	return sm.shards[sm.shardIndex(key)]
+}
+
+func (sm *ShardedMap[K, V]) Clear() {
+	sm.checkMutable("Clear")
+	for _, s := range sm.shards {
+		s.mu.Lock()
+		sm.cleared(s.m.Range, s.m.Clear)
+		s.mu.Unlock()
+	}
+}
+
+func (sm *ShardedMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := &ShardedMap[K, V]{
+		seed:   sm.seed,
+		shards: make([]*shard[K, V], len(sm.shards)),
+	}
+	for i, s := range sm.shards {
+		s.mu.RLock()
+		clone.shards[i] = &shard[K, V]{m: s.m.Clone().(*UnorderedMap[K, V])}
+		s.mu.RUnlock()
+	}
+	clone.DefaultAbstractMap = NewDefaultAbstractMap(clone)
+	return clone
+}
+
+// AtomicUpdate runs the load, f and store under the key's shard lock.
+func (sm *ShardedMap[K, V]) AtomicUpdate(key K, f func(old V, loaded bool) (new V, store bool)) (V, bool) {
+	sm.checkMutable("AtomicUpdate")
+	s := sm.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return atomicUpdate(key, f, s.m.Load, func(key K, value V) {
+		s.m.Store(key, value)
+		sm.stored(key, value)
+	})
+}
+
+func (sm *ShardedMap[K, V]) updatesUnderLock() bool { return true }
+
+func (sm *ShardedMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return sm.CompareAndDeleteFunc(key, old, equalAny[V])
+}
+
+func (sm *ShardedMap[K, V]) CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) (deleted bool) {
+	sm.checkMutable("CompareAndDeleteFunc")
+	s := sm.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value, ok := s.m.Load(key); ok && eq(value, old) {
+		s.m.Delete(key)
+		sm.deleted(key, value)
+		return true
+	}
+	return false
+}
+
+func (sm *ShardedMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return sm.CompareAndSwapFunc(key, old, new, equalAny[V])
+}
+
+func (sm *ShardedMap[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) (swapped bool) {
+	sm.checkMutable("CompareAndSwapFunc")
+	s := sm.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value, ok := s.m.Load(key); ok && eq(value, old) {
+		s.m.Store(key, new)
+		sm.stored(key, new)
+		return true
+	}
+	return false
+}
+
+func (sm *ShardedMap[K, V]) Delete(key K) {
+	sm.LoadAndDelete(key)
+}
+
+func (sm *ShardedMap[K, V]) Len() int {
+	n := 0
+	for _, s := range sm.shards {
+		s.mu.RLock()
+		n += s.m.Len()
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+func (sm *ShardedMap[K, V]) Load(key K) (value V, ok bool) {
+	s := sm.shardFor(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m.Load(key)
+}
+
+func (sm *ShardedMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	sm.checkMutable("LoadAndDelete")
+	s := sm.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, loaded = s.m.LoadAndDelete(key)
+	if loaded {
+		sm.deleted(key, value)
+	}
+	return value, loaded
+}
+
+// LoadOrCompute runs f under the key's shard lock, so concurrent callers for a
+// missing key compute its value only once.
+func (sm *ShardedMap[K, V]) LoadOrCompute(key K, f func() V) (actual V, loaded bool) {
+	sm.checkMutable("LoadOrCompute")
+	return loadOrCompute(key, f, sm.AtomicUpdate)
+}
+
+func (sm *ShardedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	sm.checkMutable("LoadOrStore")
+	s := sm.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	actual, loaded = s.m.LoadOrStore(key, value)
+	if !loaded {
+		sm.stored(key, value)
+	}
+	return actual, loaded
+}
+
+func (sm *ShardedMap[K, V]) Range(f func(key K, value V) bool) {
+	for _, s := range sm.shards {
+		if !sm.rangeShard(s, f) {
+			return
+		}
+	}
+}
+
+// rangeShard ranges over a single shard under its read lock and reports
+// whether iteration should continue.
+func (sm *ShardedMap[K, V]) rangeShard(s *shard[K, V], f func(key K, value V) bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	more := true
+	s.m.Range(func(key K, value V) bool {
+		more = f(key, value)
+		return more
+	})
+	return more
+}
+
+func (sm *ShardedMap[K, V]) Store(key K, value V) {
+	sm.checkMutable("Store")
+	s := sm.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m.Store(key, value)
+	sm.stored(key, value)
+}
+
+func (sm *ShardedMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	sm.checkMutable("Swap")
+	s := sm.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, loaded = s.m.Swap(key, value)
+	sm.stored(key, value)
+	return previous, loaded
+}
+
+// Update calls fn with a staging map that reflects the current contents and
+// records the changes made through it. If fn returns nil, the changes are
+// applied while every shard is locked, so other goroutines observe either
+// none or all of them; otherwise the map is left untouched and fn's error is
+// returned. Reads made by fn are not isolated: changes committed by other
+// goroutines while fn runs may be overwritten.
+func (sm *ShardedMap[K, V]) Update(fn func(tx AbstractMap[K, V]) error) error {
+	sm.checkMutable("Update")
+	tx := newTxMap[K, V](sm)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	for _, s := range sm.shards {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	tx.commit(
+		func(key K, value V) {
+			sm.shardFor(key).m.Store(key, value)
+			sm.stored(key, value)
+		},
+		func(key K) {
+			if value, loaded := sm.shardFor(key).m.LoadAndDelete(key); loaded {
+				sm.deleted(key, value)
+			}
+		},
+		func() {
+			for _, s := range sm.shards {
+				sm.cleared(s.m.Range, s.m.Clear)
+			}
+		},
+	)
+	return nil
+}
@@ -0,0 +1,101 @@
+package maps_test
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestShardedMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewShardedMap[string, string](8)
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestShardedMapSingleShard(t *testing.T) {
+	factory := func() maps.AbstractMap[int, int] {
+		return maps.NewShardedMap[int, int](1)
+	}
+
+	testData := []TestCase[int, int]{
+		{1, 10},
+		{2, 20},
+		{3, 30},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+// Run with -race to verify the per-shard locking discipline.
+func TestShardedMapParallelAccess(t *testing.T) {
+	const workers, perWorker = 16, 500
+	sm := maps.NewShardedMap[string, int](16)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				key := strconv.Itoa(w*perWorker + i)
+				sm.Store(key, i)
+				if value, ok := sm.Load(key); !ok || value != i {
+					t.Errorf("Expected to read back %s=%d, got %d (ok=%v)", key, i, value, ok)
+				}
+				if i%2 == 1 {
+					sm.Delete(key)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if length := sm.Len(); length != workers*perWorker/2 {
+		t.Errorf("Expected length %d, got %d", workers*perWorker/2, length)
+	}
+}
+
+func TestShardedMapInvalidShards(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewShardedMap(0) to panic")
+		}
+	}()
+	maps.NewShardedMap[string, int](0)
+}
+
+// BenchmarkParallelWriters compares a single-mutex ConcurrentMap against a
+// ShardedMap under parallel writers. Run with -race to check for data races
+// as well, keeping in mind the race detector skews absolute timings.
+func BenchmarkParallelWriters(b *testing.B) {
+	run := func(b *testing.B, m maps.AbstractMap[int, int]) {
+		var next atomic.Int64
+		b.RunParallel(func(pb *testing.PB) {
+			base := int(next.Add(1)) << 20
+			i := 0
+			for pb.Next() {
+				m.Store(base+i%4096, i)
+				i++
+			}
+		})
+	}
+
+	b.Run("ConcurrentMap", func(b *testing.B) {
+		run(b, maps.NewConcurrentMap[int, int]())
+	})
+
+	b.Run("ShardedMap", func(b *testing.B) {
+		run(b, maps.NewShardedMap[int, int](64))
+	})
+}
@@ -0,0 +1,72 @@
+package maps
+
+import (
+	"fmt"
+	"sync"
+)
+
+// flightCall tracks a single in-progress computation and its result.
+type flightCall[V any] struct {
+	done    chan struct{}
+	value   V
+	err     error
+	waiters int // Callers waiting for the result, guarded by the group's mu
+}
+
+// flightGroup deduplicates concurrent computations for the same key:
+// while a computation for a key is running, further callers wait for it
+// and share its result instead of starting their own.
+// The zero value is ready to use.
+type flightGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*flightCall[V]
+}
+
+// do runs fn for key unless a call for key is already in flight,
+// in which case it waits for that call and returns its result. If fn
+// panics, the panic propagates to the caller that ran it and every waiter
+// gets an error instead.
+func (g *flightGroup[K, V]) do(key K, fn func() (V, error)) (V, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*flightCall[V])
+	}
+	if call, ok := g.calls[key]; ok {
+		call.waiters++
+		g.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &flightCall[V]{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	// Release waiters even if fn panics. They receive an error, and the
+	// panic continues in this caller.
+	defer func() {
+		r := recover()
+		if r != nil {
+			call.err = fmt.Errorf("maps: load panicked: %v", r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+		if r != nil {
+			panic(r)
+		}
+	}()
+	call.value, call.err = fn()
+	return call.value, call.err
+}
+
+// waiting returns the number of callers waiting for the call in flight
+// for key, or 0 if there is none.
+func (g *flightGroup[K, V]) waiting(key K) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if call, ok := g.calls[key]; ok {
+		return call.waiters
+	}
+	return 0
+}
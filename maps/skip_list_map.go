@@ -0,0 +1,196 @@
+package maps
+
+import "math/rand/v2"
+
+// skipListMaxLevel bounds the height of a SkipListMap tower, which is ample
+// for 4^32 entries at the promotion probability used.
+const skipListMaxLevel = 32
+
+// skipNode is an entry of a SkipListMap together with its forward links,
+// one per level it appears on.
+type skipNode[K, V any] struct {
+	key   K
+	value V
+	next  []*skipNode[K, V]
+}
+
+// SkipListMap implements AbstractMap as a skip list ordered by a
+// user-supplied less function, so Range, Keys and Values visit keys from
+// smallest to largest. Like SortedMap, two keys are equal when neither is
+// less than the other, so K does not need to be comparable.
+// Load, Store and Delete take O(log n) expected time and, unlike SortedMap,
+// never shift existing entries.
+type SkipListMap[K, V any] struct {
+	*DefaultAbstractMap[K, V]
+	head  *skipNode[K, V] // Sentinel whose links start every level
+	level int             // Number of levels in use
+	len   int
+	less  func(a, b K) bool
+}
+
+// NewSkipListMap creates an empty SkipListMap ordered by less.
+func NewSkipListMap[K, V any](less func(a, b K) bool) *SkipListMap[K, V] {
+	sl := &SkipListMap[K, V]{
+		head:  &skipNode[K, V]{next: make([]*skipNode[K, V], skipListMaxLevel)},
+		level: 1,
+		less:  less,
+	}
+	sl.DefaultAbstractMap = NewDefaultAbstractMap[K, V](sl)
+	return sl
+}
+
+// randomLevel returns the height of a new tower: each extra level is added
+// with probability 1/4.
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Uint32()&3 == 0 {
+		level++
+	}
+	return level
+}
+
+// search returns the last node before key on every level in use, and the
+// node holding key if present.
+// Time complexity: O(log n) expected
+func (sl *SkipListMap[K, V]) search(key K) (update [skipListMaxLevel]*skipNode[K, V], found *skipNode[K, V]) {
+	n := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for n.next[i] != nil && sl.less(n.next[i].key, key) {
+			n = n.next[i]
+		}
+		update[i] = n
+	}
+	if next := n.next[0]; next != nil && !sl.less(key, next.key) {
+		found = next
+	}
+	return update, found
+}
+
+// Clear removes all entries.
+// Time complexity: O(1)
+func (sl *SkipListMap[K, V]) Clear() {
+	sl.checkMutable("Clear")
+	sl.cleared(sl.Range, func() {
+		clear(sl.head.next)
+		sl.level = 1
+		sl.len = 0
+	})
+}
+
+// Clone returns an independent SkipListMap with the same ordering and
+// entries.
+// Time complexity: O(n)
+func (sl *SkipListMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := NewSkipListMap[K, V](sl.less)
+	sl.Range(func(key K, value V) bool {
+		clone.Store(key, value)
+		return true
+	})
+	return clone
+}
+
+// Delete removes key from the map if present.
+// Time complexity: O(log n) expected
+func (sl *SkipListMap[K, V]) Delete(key K) {
+	sl.checkMutable("Delete")
+	update, n := sl.search(key)
+	if n == nil {
+		return
+	}
+	for i := range n.next {
+		update[i].next[i] = n.next[i]
+	}
+	for sl.level > 1 && sl.head.next[sl.level-1] == nil {
+		sl.level--
+	}
+	sl.len--
+	sl.deleted(n.key, n.value)
+}
+
+// Len returns the number of entries in the map.
+// Time complexity: O(1)
+func (sl *SkipListMap[K, V]) Len() int {
+	return sl.len
+}
+
+// Load returns the value stored for key.
+// Time complexity: O(log n) expected
+func (sl *SkipListMap[K, V]) Load(key K) (value V, ok bool) {
+	if _, n := sl.search(key); n != nil {
+		return n.value, true
+	}
+	return value, false
+}
+
+// Range calls f for each entry in ascending key order until f returns false.
+// Time complexity: O(n)
+func (sl *SkipListMap[K, V]) Range(f func(key K, value V) bool) {
+	for n := sl.head.next[0]; n != nil; n = n.next[0] {
+		if !f(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// Store sets the value for key. Overwriting an existing key replaces the
+// stored key with the new one.
+// Time complexity: O(log n) expected
+func (sl *SkipListMap[K, V]) Store(key K, value V) {
+	sl.checkMutable("Store")
+	update, n := sl.search(key)
+	if n != nil {
+		n.key, n.value = key, value
+		sl.stored(key, value)
+		return
+	}
+	level := randomLevel()
+	for i := sl.level; i < level; i++ {
+		update[i] = sl.head
+	}
+	sl.level = max(sl.level, level)
+	n = &skipNode[K, V]{key: key, value: value, next: make([]*skipNode[K, V], level)}
+	for i := range level {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	sl.len++
+	sl.stored(key, value)
+}
+
+// Min returns the entry with the smallest key.
+// Time complexity: O(1)
+func (sl *SkipListMap[K, V]) Min() (key K, value V, ok bool) {
+	n := sl.head.next[0]
+	if n == nil {
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+// Max returns the entry with the largest key.
+// Time complexity: O(log n) expected
+func (sl *SkipListMap[K, V]) Max() (key K, value V, ok bool) {
+	n := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		for n.next[i] != nil {
+			n = n.next[i]
+		}
+	}
+	if n == sl.head {
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+// RangeBetween calls f, in ascending key order, for each entry whose key is
+// in the half-open interval [from, to) until f returns false. Nothing is
+// visited if to is not greater than from.
+// Time complexity: O(log n + m) expected where m is the number of entries visited
+func (sl *SkipListMap[K, V]) RangeBetween(from, to K, f func(key K, value V) bool) {
+	update, _ := sl.search(from)
+	for n := update[0].next[0]; n != nil && sl.less(n.key, to); n = n.next[0] {
+		if !f(n.key, n.value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package maps_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestSkipListMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewSkipListMap[string, string](func(a, b string) bool { return a < b })
+	}
+
+	testData := []TestCase[string, string]{
+		{"gamma", "third"},
+		{"alpha", "first"},
+		{"beta", "second"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestSkipListMapOrdering(t *testing.T) {
+	sl := maps.NewSkipListMap[int, string](func(a, b int) bool { return a < b })
+	sl.Store(3, "c")
+	sl.Store(1, "a")
+	sl.Store(2, "b")
+	sl.Store(1, "A")
+
+	if got := sl.KeysSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected keys [1 2 3], got %v", got)
+	}
+	if got := sl.ValuesSlice(); !slices.Equal(got, []string{"A", "b", "c"}) {
+		t.Errorf("Expected overwritten values [A b c], got %v", got)
+	}
+	if sl.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", sl.Len())
+	}
+}
+
+func TestSkipListMapRandom(t *testing.T) {
+	sl := maps.NewSkipListMap[int, int](func(a, b int) bool { return a < b })
+	reference := map[int]int{}
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 5000 {
+		k := r.IntN(1000)
+		if r.IntN(4) == 0 {
+			sl.Delete(k)
+			delete(reference, k)
+		} else {
+			sl.Store(k, k*2)
+			reference[k] = k * 2
+		}
+	}
+
+	if sl.Len() != len(reference) {
+		t.Errorf("Expected length %d, got %d", len(reference), sl.Len())
+	}
+	keys := sl.KeysSlice()
+	if !slices.IsSorted(keys) {
+		t.Error("Expected Range to visit keys in ascending order")
+	}
+	for _, k := range keys {
+		if v, ok := reference[k]; !ok || v != k*2 {
+			t.Errorf("Unexpected key %d in skip list", k)
+		}
+	}
+	for k, v := range reference {
+		if got, ok := sl.Load(k); !ok || got != v {
+			t.Errorf("Expected %d for key %d, got %d (ok=%v)", v, k, got, ok)
+		}
+	}
+}
+
+func TestSkipListMapNavigation(t *testing.T) {
+	sl := maps.NewSkipListMap[int, int](func(a, b int) bool { return a < b })
+	if _, _, ok := sl.Min(); ok {
+		t.Error("Expected Min of empty map to report false")
+	}
+	if _, _, ok := sl.Max(); ok {
+		t.Error("Expected Max of empty map to report false")
+	}
+
+	for _, k := range []int{40, 10, 50, 20, 30} {
+		sl.Store(k, k*10)
+	}
+	if k, v, ok := sl.Min(); !ok || k != 10 || v != 100 {
+		t.Errorf("Expected Min 10=100, got %d=%d, %v", k, v, ok)
+	}
+	if k, v, ok := sl.Max(); !ok || k != 50 || v != 500 {
+		t.Errorf("Expected Max 50=500, got %d=%d, %v", k, v, ok)
+	}
+
+	cases := []struct {
+		name     string
+		from, to int
+		expected []int
+	}{
+		{"Spanning", 15, 45, []int{20, 30, 40}},
+		{"OnBounds", 20, 40, []int{20, 30}},
+		{"Empty", 30, 30, nil},
+		{"AboveAll", 51, 100, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []int
+			sl.RangeBetween(c.from, c.to, func(key, value int) bool {
+				got = append(got, key)
+				return true
+			})
+			if !slices.Equal(got, c.expected) {
+				t.Errorf("Expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package maps
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SortedMap implements AbstractMap keeping entries ordered by a user-supplied
+// less function, so Range, Keys and Values visit keys from smallest to largest.
+// Two keys are considered equal when neither is less than the other, so K
+// does not need to be comparable.
+// Entries are stored in a sorted slice: Load is O(log n) while Store and
+// Delete are O(log n) to locate the key plus O(n) to shift the slice.
+type SortedMap[K, V any] struct {
+	*DefaultAbstractMap[K, V]
+	entries []entry[K, V] // Sorted by key according to less
+	less    func(a, b K) bool
+}
+
+// NewSortedMap creates an empty SortedMap ordered by less.
+func NewSortedMap[K, V any](less func(a, b K) bool) *SortedMap[K, V] {
+	sm := &SortedMap[K, V]{
+		less: less,
+	}
+	sm.DefaultAbstractMap = NewDefaultAbstractMap[K, V](sm)
+	return sm
+}
+
+// search returns the position of key, or the position where it would be
+// inserted, and whether it is present.
+// Time complexity: O(log n)
+func (sm *SortedMap[K, V]) search(key K) (int, bool) {
+	return slices.BinarySearchFunc(sm.entries, key, func(e entry[K, V], key K) int {
+		switch {
+		case sm.less(e.key, key):
+			return -1
+		case sm.less(key, e.key):
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// Clear removes all entries, keeping the allocated storage for reuse.
+func (sm *SortedMap[K, V]) Clear() {
+	sm.checkMutable("Clear")
+	sm.cleared(sm.Range, func() {
+		clear(sm.entries)
+		sm.entries = sm.entries[:0]
+	})
+}
+
+// Clone returns an independent SortedMap with the same ordering and entries.
+// Time complexity: O(n)
+func (sm *SortedMap[K, V]) Clone() AbstractMap[K, V] {
+	clone := NewSortedMap[K, V](sm.less)
+	clone.entries = slices.Clone(sm.entries)
+	return clone
+}
+
+// Delete removes key from the map if present.
+// Time complexity: O(n)
+func (sm *SortedMap[K, V]) Delete(key K) {
+	sm.checkMutable("Delete")
+	if i, found := sm.search(key); found {
+		removed := sm.entries[i]
+		sm.entries = slices.Delete(sm.entries, i, i+1)
+		sm.deleted(removed.key, removed.value)
+	}
+}
+
+// Len returns the number of entries in the map.
+// Time complexity: O(1)
+func (sm *SortedMap[K, V]) Len() int {
+	return len(sm.entries)
+}
+
+func (sm *SortedMap[K, V]) readSafe() bool { return true }
+
+// Load returns the value stored for key.
+// Time complexity: O(log n)
+func (sm *SortedMap[K, V]) Load(key K) (value V, ok bool) {
+	if i, found := sm.search(key); found {
+		return sm.entries[i].value, true
+	}
+	return value, false
+}
+
+// Range calls f for each entry in ascending key order until f returns false.
+// Time complexity: O(n)
+func (sm *SortedMap[K, V]) Range(f func(key K, value V) bool) {
+	for _, e := range sm.entries {
+		if !f(e.key, e.value) {
+			break
+		}
+	}
+}
+
+// Store sets the value for key. Overwriting an existing key keeps its position
+// and replaces the stored key with the new one.
+// Time complexity: O(n)
+func (sm *SortedMap[K, V]) Store(key K, value V) {
+	sm.checkMutable("Store")
+	i, found := sm.search(key)
+	if found {
+		sm.entries[i] = entry[K, V]{key: key, value: value}
+	} else {
+		sm.entries = slices.Insert(sm.entries, i, entry[K, V]{key: key, value: value})
+	}
+	sm.stored(key, value)
+}
+
+// Min returns the entry with the smallest key.
+// Time complexity: O(1)
+func (sm *SortedMap[K, V]) Min() (key K, value V, ok bool) {
+	if len(sm.entries) == 0 {
+		return key, value, false
+	}
+	e := sm.entries[0]
+	return e.key, e.value, true
+}
+
+// Max returns the entry with the largest key.
+// Time complexity: O(1)
+func (sm *SortedMap[K, V]) Max() (key K, value V, ok bool) {
+	if len(sm.entries) == 0 {
+		return key, value, false
+	}
+	e := sm.entries[len(sm.entries)-1]
+	return e.key, e.value, true
+}
+
+// Floor returns the entry with the largest key less than or equal to key.
+// Time complexity: O(log n)
+func (sm *SortedMap[K, V]) Floor(key K) (k K, value V, ok bool) {
+	i, found := sm.search(key)
+	if !found {
+		i--
+	}
+	if i < 0 {
+		return k, value, false
+	}
+	e := sm.entries[i]
+	return e.key, e.value, true
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to
+// key.
+// Time complexity: O(log n)
+func (sm *SortedMap[K, V]) Ceiling(key K) (k K, value V, ok bool) {
+	i, _ := sm.search(key)
+	if i == len(sm.entries) {
+		return k, value, false
+	}
+	e := sm.entries[i]
+	return e.key, e.value, true
+}
+
+// RangeBetween calls f, in ascending key order, for each entry whose key is
+// in the half-open interval [from, to) until f returns false. Nothing is
+// visited if to is not greater than from.
+// Time complexity: O(log n + m) where m is the number of entries visited
+func (sm *SortedMap[K, V]) RangeBetween(from, to K, f func(key K, value V) bool) {
+	i, _ := sm.search(from)
+	for _, e := range sm.entries[i:] {
+		if !sm.less(e.key, to) || !f(e.key, e.value) {
+			break
+		}
+	}
+}
+
+// SubMap returns a live view of the entries whose keys are in the half-open
+// interval [from, to), like Java's NavigableMap.subMap. Reads and writes
+// through the view go to sm and changes to sm within the interval show
+// through the view. Storing a key outside the interval through the view
+// panics; loading or deleting one behaves as if it were absent.
+func (sm *SortedMap[K, V]) SubMap(from, to K) AbstractMap[K, V] {
+	sub := &sortedSubMap[K, V]{sm: sm, from: from, to: to}
+	sub.DefaultAbstractMap = NewDefaultAbstractMap[K, V](sub)
+	return sub
+}
+
+// sortedSubMap is the view returned by SortedMap.SubMap.
+type sortedSubMap[K, V any] struct {
+	*DefaultAbstractMap[K, V]
+	sm       *SortedMap[K, V]
+	from, to K
+}
+
+// inRange reports whether key is in [from, to).
+func (sub *sortedSubMap[K, V]) inRange(key K) bool {
+	return !sub.sm.less(key, sub.from) && sub.sm.less(key, sub.to)
+}
+
+// bounds returns the index range of sm.entries covered by the view.
+// Time complexity: O(log n)
+func (sub *sortedSubMap[K, V]) bounds() (lo, hi int) {
+	lo, _ = sub.sm.search(sub.from)
+	hi, _ = sub.sm.search(sub.to)
+	return lo, max(lo, hi)
+}
+
+// Clear removes the entries in the view's range from the backing map.
+// Time complexity: O(n)
+func (sub *sortedSubMap[K, V]) Clear() {
+	sub.checkMutable("Clear")
+	sub.sm.checkMutable("Clear")
+	lo, hi := sub.bounds()
+	removed := slices.Clone(sub.sm.entries[lo:hi])
+	sub.sm.entries = slices.Delete(sub.sm.entries, lo, hi)
+	for _, e := range removed {
+		sub.sm.deleted(e.key, e.value)
+	}
+}
+
+// Delete removes key from the backing map if it is in the view's range.
+// Time complexity: O(n)
+func (sub *sortedSubMap[K, V]) Delete(key K) {
+	sub.checkMutable("Delete")
+	if sub.inRange(key) {
+		sub.sm.Delete(key)
+	}
+}
+
+// Len returns the number of entries in the view's range.
+// Time complexity: O(log n)
+func (sub *sortedSubMap[K, V]) Len() int {
+	lo, hi := sub.bounds()
+	return hi - lo
+}
+
+// Load returns the value stored for key if it is in the view's range.
+// Time complexity: O(log n)
+func (sub *sortedSubMap[K, V]) Load(key K) (value V, ok bool) {
+	if !sub.inRange(key) {
+		return value, false
+	}
+	return sub.sm.Load(key)
+}
+
+// Range calls f for each entry in the view's range in ascending key order
+// until f returns false.
+// Time complexity: O(log n + m) where m is the number of entries visited
+func (sub *sortedSubMap[K, V]) Range(f func(key K, value V) bool) {
+	sub.sm.RangeBetween(sub.from, sub.to, f)
+}
+
+// Store sets the value for key in the backing map. It panics if key is
+// outside the view's range.
+// Time complexity: O(n)
+func (sub *sortedSubMap[K, V]) Store(key K, value V) {
+	sub.checkMutable("Store")
+	if !sub.inRange(key) {
+		panic(fmt.Sprintf("maps: SubMap key %v is outside [%v, %v)", key, sub.from, sub.to))
+	}
+	sub.sm.Store(key, value)
+}
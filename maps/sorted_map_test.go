@@ -0,0 +1,317 @@
+package maps_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestSortedMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewSortedMap[string, string](func(a, b string) bool { return a < b })
+	}
+
+	testData := []TestCase[string, string]{
+		{"gamma", "third"},
+		{"alpha", "first"},
+		{"beta", "second"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestSortedMapInt(t *testing.T) {
+	factory := func() maps.AbstractMap[int, int] {
+		return maps.NewSortedMap[int, int](func(a, b int) bool { return a < b })
+	}
+
+	testData := []TestCase[int, int]{
+		{30, 300},
+		{10, 100},
+		{20, 200},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestSortedMapOrdering(t *testing.T) {
+	t.Run("AscendingRange", func(t *testing.T) {
+		sm := maps.NewSortedMap[int, string](func(a, b int) bool { return a < b })
+		for _, key := range []int{42, 7, 19, 3, 88, 25} {
+			sm.Store(key, "v")
+		}
+
+		var keys []int
+		sm.Keys(func(key int) bool {
+			keys = append(keys, key)
+			return true
+		})
+
+		expected := []int{3, 7, 19, 25, 42, 88}
+		if len(keys) != len(expected) {
+			t.Fatalf("Expected %d keys, got %d", len(expected), len(keys))
+		}
+		for i, key := range expected {
+			if keys[i] != key {
+				t.Errorf("Position %d: expected %d, got %d", i, key, keys[i])
+			}
+		}
+	})
+
+	t.Run("OverwritePreservesPosition", func(t *testing.T) {
+		sm := maps.NewSortedMap[string, int](func(a, b string) bool { return a < b })
+		sm.Store("b", 2)
+		sm.Store("c", 3)
+		sm.Store("a", 1)
+		sm.Store("b", 20)
+
+		expectedKeys := []string{"a", "b", "c"}
+		expectedValues := []int{1, 20, 3}
+		i := 0
+		sm.Range(func(key string, value int) bool {
+			if key != expectedKeys[i] || value != expectedValues[i] {
+				t.Errorf("Position %d: expected {%s: %d}, got {%s: %d}",
+					i, expectedKeys[i], expectedValues[i], key, value)
+			}
+			i++
+			return true
+		})
+		if sm.Len() != 3 {
+			t.Errorf("Expected length 3 after overwrite, got %d", sm.Len())
+		}
+	})
+
+	t.Run("CaseInsensitiveKeys", func(t *testing.T) {
+		sm := maps.NewSortedMap[string, int](func(a, b string) bool {
+			return strings.ToLower(a) < strings.ToLower(b)
+		})
+		sm.Store("Banana", 1)
+		sm.Store("apple", 2)
+		sm.Store("BANANA", 3)
+
+		if sm.Len() != 2 {
+			t.Errorf("Expected keys differing only in case to collapse, got length %d", sm.Len())
+		}
+		if value, ok := sm.Load("banana"); !ok || value != 3 {
+			t.Errorf("Expected case-insensitive lookup to return 3, got %d (ok=%v)", value, ok)
+		}
+	})
+
+	t.Run("StructKeys", func(t *testing.T) {
+		type version struct {
+			major, minor int
+			tags         []string
+		}
+		sm := maps.NewSortedMap[version, string](func(a, b version) bool {
+			if a.major != b.major {
+				return a.major < b.major
+			}
+			return a.minor < b.minor
+		})
+		sm.Store(version{major: 2, minor: 0}, "two")
+		sm.Store(version{major: 1, minor: 10, tags: []string{"lts"}}, "one-ten")
+		sm.Store(version{major: 1, minor: 2}, "one-two")
+		sm.Delete(version{major: 2})
+
+		var values []string
+		sm.Values(func(value string) bool {
+			values = append(values, value)
+			return true
+		})
+		if len(values) != 2 || values[0] != "one-two" || values[1] != "one-ten" {
+			t.Errorf("Expected [one-two one-ten], got %v", values)
+		}
+	})
+}
+
+func TestSortedMapNavigation(t *testing.T) {
+	sm := maps.NewSortedMap[int, string](func(a, b int) bool { return a < b })
+
+	t.Run("Empty", func(t *testing.T) {
+		if _, _, ok := sm.Min(); ok {
+			t.Errorf("Expected Min of empty map to report false")
+		}
+		if _, _, ok := sm.Max(); ok {
+			t.Errorf("Expected Max of empty map to report false")
+		}
+		if _, _, ok := sm.Floor(1); ok {
+			t.Errorf("Expected Floor of empty map to report false")
+		}
+		if _, _, ok := sm.Ceiling(1); ok {
+			t.Errorf("Expected Ceiling of empty map to report false")
+		}
+	})
+
+	for _, k := range []int{30, 10, 20} {
+		sm.Store(k, strings.Repeat("x", k/10))
+	}
+
+	t.Run("MinMax", func(t *testing.T) {
+		if k, v, ok := sm.Min(); !ok || k != 10 || v != "x" {
+			t.Errorf("Expected Min 10=x, got %d=%s, %v", k, v, ok)
+		}
+		if k, v, ok := sm.Max(); !ok || k != 30 || v != "xxx" {
+			t.Errorf("Expected Max 30=xxx, got %d=%s, %v", k, v, ok)
+		}
+	})
+
+	cases := []struct {
+		key                  int
+		floor, ceiling       int
+		hasFloor, hasCeiling bool
+	}{
+		{key: 20, floor: 20, ceiling: 20, hasFloor: true, hasCeiling: true},
+		{key: 15, floor: 10, ceiling: 20, hasFloor: true, hasCeiling: true},
+		{key: 5, ceiling: 10, hasCeiling: true},
+		{key: 35, floor: 30, hasFloor: true},
+		{key: 10, floor: 10, ceiling: 10, hasFloor: true, hasCeiling: true},
+		{key: 30, floor: 30, ceiling: 30, hasFloor: true, hasCeiling: true},
+	}
+	for _, c := range cases {
+		if k, _, ok := sm.Floor(c.key); ok != c.hasFloor || (ok && k != c.floor) {
+			t.Errorf("Floor(%d): expected %d, %v, got %d, %v", c.key, c.floor, c.hasFloor, k, ok)
+		}
+		if k, _, ok := sm.Ceiling(c.key); ok != c.hasCeiling || (ok && k != c.ceiling) {
+			t.Errorf("Ceiling(%d): expected %d, %v, got %d, %v", c.key, c.ceiling, c.hasCeiling, k, ok)
+		}
+	}
+}
+
+func TestSortedMapRangeBetween(t *testing.T) {
+	sm := maps.NewSortedMap[int, int](func(a, b int) bool { return a < b })
+	for _, k := range []int{10, 20, 30, 40, 50} {
+		sm.Store(k, k*10)
+	}
+	collect := func(from, to int) []int {
+		var keys []int
+		sm.RangeBetween(from, to, func(key, value int) bool {
+			if value != key*10 {
+				t.Errorf("Expected value %d for key %d, got %d", key*10, key, value)
+			}
+			keys = append(keys, key)
+			return true
+		})
+		return keys
+	}
+
+	cases := []struct {
+		name     string
+		from, to int
+		expected []int
+	}{
+		{"Spanning", 15, 45, []int{20, 30, 40}},
+		{"OnBounds", 20, 40, []int{20, 30}},
+		{"Everything", 0, 100, []int{10, 20, 30, 40, 50}},
+		{"Empty", 30, 30, nil},
+		{"Inverted", 40, 20, nil},
+		{"BelowAll", 0, 10, nil},
+		{"AboveAll", 51, 100, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := collect(c.from, c.to); !slices.Equal(got, c.expected) {
+				t.Errorf("Expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+
+	t.Run("Stop", func(t *testing.T) {
+		count := 0
+		sm.RangeBetween(0, 100, func(key, value int) bool {
+			count++
+			return count < 2
+		})
+		if count != 2 {
+			t.Errorf("Expected iteration to stop after 2 entries, got %d", count)
+		}
+	})
+}
+
+func TestSortedMapSubMap(t *testing.T) {
+	newBacking := func() *maps.SortedMap[int, string] {
+		sm := maps.NewSortedMap[int, string](func(a, b int) bool { return a < b })
+		for _, k := range []int{10, 20, 30, 40, 50} {
+			sm.Store(k, strings.Repeat("x", k/10))
+		}
+		return sm
+	}
+
+	t.Run("Reads", func(t *testing.T) {
+		sub := newBacking().SubMap(20, 40)
+		if sub.Len() != 2 {
+			t.Errorf("Expected 2 entries in [20, 40), got %d", sub.Len())
+		}
+		var keys []int
+		sub.Keys(func(key int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if !slices.Equal(keys, []int{20, 30}) {
+			t.Errorf("Expected keys [20 30], got %v", keys)
+		}
+		if value, ok := sub.Load(30); !ok || value != "xxx" {
+			t.Errorf("Expected 30 to load through the view, got %q (ok=%v)", value, ok)
+		}
+		if _, ok := sub.Load(40); ok {
+			t.Errorf("Expected the upper bound 40 to be outside the view")
+		}
+		if _, ok := sub.Load(10); ok {
+			t.Errorf("Expected 10 to be outside the view")
+		}
+	})
+
+	t.Run("BoundedWrites", func(t *testing.T) {
+		sm := newBacking()
+		sub := sm.SubMap(20, 40)
+		sub.Store(25, "new")
+		if value, _ := sm.Load(25); value != "new" {
+			t.Errorf("Expected store through the view to reach the backing map, got %q", value)
+		}
+		sub.Delete(10)
+		if _, ok := sm.Load(10); !ok {
+			t.Errorf("Expected out-of-range Delete through the view to be ignored")
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected out-of-range Store to panic")
+				}
+			}()
+			sub.Store(40, "out")
+		}()
+		if _, ok := sm.Load(40); !ok || sm.Len() != 6 {
+			t.Errorf("Expected backing map unchanged by rejected Store, got len %d", sm.Len())
+		}
+
+		sub.Clear()
+		if got := sm.KeysSlice(); !slices.Equal(got, []int{10, 40, 50}) {
+			t.Errorf("Expected Clear to remove only [20, 40), got %v", got)
+		}
+	})
+
+	t.Run("BackingChangesShowThrough", func(t *testing.T) {
+		sm := newBacking()
+		sub := sm.SubMap(20, 40)
+		sm.Store(35, "backing")
+		sm.Delete(20)
+		sm.Store(45, "outside")
+		if value, ok := sub.Load(35); !ok || value != "backing" {
+			t.Errorf("Expected backing Store to show through, got %q (ok=%v)", value, ok)
+		}
+		if _, ok := sub.Load(20); ok {
+			t.Errorf("Expected backing Delete to show through")
+		}
+		if sub.Len() != 2 {
+			t.Errorf("Expected 2 entries in view, got %d", sub.Len())
+		}
+	})
+
+	t.Run("EmptyRange", func(t *testing.T) {
+		sub := newBacking().SubMap(40, 20)
+		if sub.Len() != 0 {
+			t.Errorf("Expected inverted range to be empty, got %d", sub.Len())
+		}
+	})
+}
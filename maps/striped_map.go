@@ -0,0 +1,80 @@
+package maps
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// StripedMap is a ShardedMap that also lets callers hold a key's stripe
+// (shard) across several operations with LockKey, for read-modify-write
+// sequences that the single atomic operations cannot express:
+//
+//	unlock := sm.LockKey(key)
+//	defer unlock()
+//	v, _ := sm.Load(key)
+//	sm.Store(key, next(v))
+//
+// LockKey is advisory: it excludes other LockKey and LockKeys callers on the
+// same stripe, but not plain operations such as Store, which only take the
+// stripe's internal lock for their own duration. Every goroutine that needs
+// a multi-step update of a key to be atomic must therefore lock the key.
+//
+// Lock ordering: keys that share a stripe share a lock, and the lock is not
+// reentrant. A goroutine holding a key lock must not call LockKey again,
+// even for a different key; to lock several keys at once use LockKeys,
+// which acquires their stripes in a fixed order so that concurrent callers
+// cannot deadlock.
+type StripedMap[K comparable, V any] struct {
+	*ShardedMap[K, V]
+	keyMu []sync.Mutex // One per shard, held by LockKey callers
+}
+
+// NewStripedMap creates an empty StripedMap with the given number of stripes.
+// It panics if stripes is less than one.
+func NewStripedMap[K comparable, V any](stripes int) *StripedMap[K, V] {
+	if stripes < 1 {
+		panic(fmt.Sprintf("maps: NewStripedMap stripe count must be at least 1, got %d", stripes))
+	}
+	return &StripedMap[K, V]{
+		ShardedMap: NewShardedMap[K, V](stripes),
+		keyMu:      make([]sync.Mutex, stripes),
+	}
+}
+
+// LockKey acquires the lock for key's stripe and returns a function that
+// releases it. See StripedMap for the locking rules.
+func (sm *StripedMap[K, V]) LockKey(key K) (unlock func()) {
+	mu := &sm.keyMu[sm.shardIndex(key)]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// LockKeys acquires the locks for the stripes of all keys, in ascending
+// stripe order and once per stripe, and returns a function that releases
+// them.
+func (sm *StripedMap[K, V]) LockKeys(keys ...K) (unlock func()) {
+	indexes := make([]int, len(keys))
+	for i, key := range keys {
+		indexes[i] = sm.shardIndex(key)
+	}
+	slices.Sort(indexes)
+	indexes = slices.Compact(indexes)
+	for _, i := range indexes {
+		sm.keyMu[i].Lock()
+	}
+	return func() {
+		for _, i := range slices.Backward(indexes) {
+			sm.keyMu[i].Unlock()
+		}
+	}
+}
+
+// Clone returns an independent StripedMap with the same entries. Key locks
+// held on sm are not carried over.
+func (sm *StripedMap[K, V]) Clone() AbstractMap[K, V] {
+	return &StripedMap[K, V]{
+		ShardedMap: sm.ShardedMap.Clone().(*ShardedMap[K, V]),
+		keyMu:      make([]sync.Mutex, len(sm.keyMu)),
+	}
+}
@@ -0,0 +1,106 @@
+package maps_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestStripedMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewStripedMap[string, string](8)
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestStripedMapLockKey(t *testing.T) {
+	sm := maps.NewStripedMap[string, []int](4)
+	const goroutines, appends = 8, 200
+
+	// Appending to a slice value is a load followed by a store; without
+	// LockKey concurrent appends would lose updates.
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range appends {
+				key := "key" + strconv.Itoa(i%3)
+				unlock := sm.LockKey(key)
+				values, _ := sm.Load(key)
+				sm.Store(key, append(values[:len(values):len(values)], g))
+				unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	sm.Range(func(key string, values []int) bool {
+		total += len(values)
+		return true
+	})
+	if total != goroutines*appends {
+		t.Errorf("Expected %d values, got %d", goroutines*appends, total)
+	}
+}
+
+func TestStripedMapLockKeys(t *testing.T) {
+	sm := maps.NewStripedMap[int, int](4)
+	for k := range 10 {
+		sm.Store(k, 100)
+	}
+
+	// Transfers between pairs of keys locked in opposite orders must neither
+	// deadlock nor change the sum.
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				from, to := (g+i)%10, (g+i*3+1)%10
+				if g%2 == 1 {
+					from, to = to, from
+				}
+				if from == to {
+					continue
+				}
+				unlock := sm.LockKeys(from, to)
+				a, _ := sm.Load(from)
+				b, _ := sm.Load(to)
+				sm.Store(from, a-1)
+				sm.Store(to, b+1)
+				unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sum := 0
+	sm.Range(func(key, value int) bool {
+		sum += value
+		return true
+	})
+	if sum != 1000 {
+		t.Errorf("Expected sum 1000, got %d", sum)
+	}
+}
+
+func TestStripedMapInvalidStripes(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewStripedMap(0) to panic")
+		}
+	}()
+	maps.NewStripedMap[string, int](0)
+}
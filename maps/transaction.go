@@ -0,0 +1,205 @@
+package maps
+
+import "slices"
+
+// txWrite is a change staged by a transaction for a single key.
+type txWrite[V any] struct {
+	value    V
+	deleted  bool
+	recreate bool // Stored after being deleted; commit deletes it first
+}
+
+// txMap is the staging map handed to Update callbacks. It overlays the
+// Stores and Deletes made through it on a base map without modifying the
+// base, and replays them on commit in the order the keys were written, so
+// an ordered base receives new keys in the order they were stored.
+type txMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, V]
+	base   AbstractMap[K, V]
+	reset  bool // Clear was called; the base entries are hidden
+	writes map[K]txWrite[V]
+	order  []K // Keys of writes, in the order they were last (re)created
+}
+
+func newTxMap[K comparable, V any](base AbstractMap[K, V]) *txMap[K, V] {
+	tx := &txMap[K, V]{
+		base:   base,
+		writes: map[K]txWrite[V]{},
+	}
+	tx.DefaultAbstractMap = NewDefaultAbstractMap[K, V](tx)
+	return tx
+}
+
+// inBase reports whether key is visible in the base map.
+func (tx *txMap[K, V]) inBase(key K) bool {
+	if tx.reset {
+		return false
+	}
+	_, ok := tx.base.Load(key)
+	return ok
+}
+
+func (tx *txMap[K, V]) Clear() {
+	tx.reset = true
+	clear(tx.writes)
+	tx.order = tx.order[:0]
+}
+
+func (tx *txMap[K, V]) Delete(key K) {
+	if _, staged := tx.writes[key]; !staged {
+		tx.order = append(tx.order, key)
+	}
+	tx.writes[key] = txWrite[V]{deleted: true}
+}
+
+func (tx *txMap[K, V]) Len() int {
+	n := 0
+	if !tx.reset {
+		n = tx.base.Len()
+	}
+	for key, w := range tx.writes {
+		switch inBase := tx.inBase(key); {
+		case w.deleted && inBase:
+			n--
+		case !w.deleted && !inBase:
+			n++
+		}
+	}
+	return n
+}
+
+func (tx *txMap[K, V]) Load(key K) (value V, ok bool) {
+	if w, staged := tx.writes[key]; staged {
+		if w.deleted {
+			return value, false
+		}
+		return w.value, true
+	}
+	if tx.reset {
+		return value, false
+	}
+	return tx.base.Load(key)
+}
+
+// Range visits the unchanged base entries first, then the staged ones.
+func (tx *txMap[K, V]) Range(f func(key K, value V) bool) {
+	if !tx.reset {
+		stopped := false
+		tx.base.Range(func(key K, value V) bool {
+			if _, staged := tx.writes[key]; staged {
+				return true
+			}
+			stopped = !f(key, value)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+	for _, key := range tx.order {
+		if w := tx.writes[key]; !w.deleted && !f(key, w.value) {
+			return
+		}
+	}
+}
+
+// Store stages value for key. A key stored after being deleted in the same
+// transaction moves to the end of the write order, as it would in an
+// ordered map.
+func (tx *txMap[K, V]) Store(key K, value V) {
+	w, staged := tx.writes[key]
+	switch {
+	case !staged:
+		tx.order = append(tx.order, key)
+	case w.deleted:
+		i := slices.Index(tx.order, key)
+		tx.order = append(slices.Delete(tx.order, i, i+1), key)
+		w.recreate = true
+	}
+	tx.writes[key] = txWrite[V]{value: value, recreate: w.recreate}
+}
+
+// commit replays the staged changes using the given primitives of the
+// target map: clear first if the transaction cleared the map, then every
+// staged Store and Delete in write order.
+func (tx *txMap[K, V]) commit(store func(key K, value V), delete func(key K), clear func()) {
+	if tx.reset {
+		clear()
+	}
+	for _, key := range tx.order {
+		w := tx.writes[key]
+		if w.deleted || w.recreate {
+			delete(key)
+		}
+		if !w.deleted {
+			store(key, w.value)
+		}
+	}
+}
+
+// logTxMap is the staging map handed to ConcurrentMap.Update callbacks.
+// Unlike txMap it does not need comparable keys: the changes are applied to
+// a private copy of the contents, which answers reads, and logged in order
+// for replay on commit.
+type logTxMap[K, V any] struct {
+	*DefaultAbstractMap[K, V]
+	staged AbstractMap[K, V]
+	log    []logTxOp[K, V]
+}
+
+// logTxOp is one Store, Delete or Clear made through a logTxMap.
+type logTxOp[K, V any] struct {
+	key     K
+	value   V
+	deleted bool
+	cleared bool
+}
+
+func newLogTxMap[K, V any](staged AbstractMap[K, V]) *logTxMap[K, V] {
+	tx := &logTxMap[K, V]{staged: staged}
+	tx.DefaultAbstractMap = NewDefaultAbstractMap[K, V](tx)
+	return tx
+}
+
+// Clear drops the operations logged so far, which the Clear replaces.
+func (tx *logTxMap[K, V]) Clear() {
+	tx.staged.Clear()
+	tx.log = append(tx.log[:0], logTxOp[K, V]{cleared: true})
+}
+
+func (tx *logTxMap[K, V]) Delete(key K) {
+	tx.staged.Delete(key)
+	tx.log = append(tx.log, logTxOp[K, V]{key: key, deleted: true})
+}
+
+func (tx *logTxMap[K, V]) Len() int {
+	return tx.staged.Len()
+}
+
+func (tx *logTxMap[K, V]) Load(key K) (value V, ok bool) {
+	return tx.staged.Load(key)
+}
+
+func (tx *logTxMap[K, V]) Range(f func(key K, value V) bool) {
+	tx.staged.Range(f)
+}
+
+func (tx *logTxMap[K, V]) Store(key K, value V) {
+	tx.staged.Store(key, value)
+	tx.log = append(tx.log, logTxOp[K, V]{key: key, value: value})
+}
+
+// commit replays the logged operations in order using the given primitives
+// of the target map.
+func (tx *logTxMap[K, V]) commit(store func(key K, value V), delete func(key K), clear func()) {
+	for _, op := range tx.log {
+		switch {
+		case op.cleared:
+			clear()
+		case op.deleted:
+			delete(op.key)
+		default:
+			store(op.key, op.value)
+		}
+	}
+}
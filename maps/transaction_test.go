@@ -0,0 +1,154 @@
+package maps_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+// updater is an AbstractMap that supports transactional updates.
+type updater interface {
+	maps.AbstractMap[string, int]
+	Update(fn func(tx maps.AbstractMap[string, int]) error) error
+}
+
+func TestUpdate(t *testing.T) {
+	factories := map[string]func() updater{
+		"UnorderedMap":   func() updater { return maps.NewUnorderedMap[string, int]() },
+		"OrderedMap":     func() updater { return maps.NewOrderedMap[string, int]() },
+		"ShardedMap":     func() updater { return maps.NewShardedMap[string, int](4) },
+		"CopyOnWriteMap": func() updater { return maps.NewCopyOnWriteMap[string, int]() },
+		"ConcurrentMap":  func() updater { return maps.NewConcurrentMap[string, int]() },
+		"ConcurrentSortedMap": func() updater {
+			return maps.NewConcurrentMapFrom[string, int](maps.NewSortedMap[string, int](func(a, b string) bool { return a < b }))
+		},
+	}
+
+	for name, factory := range factories {
+		fill := func() updater {
+			m := factory()
+			m.Store("a", 1)
+			m.Store("b", 2)
+			return m
+		}
+
+		t.Run(name+"/Commit", func(t *testing.T) {
+			m := fill()
+			err := m.Update(func(tx maps.AbstractMap[string, int]) error {
+				tx.Store("a", 10)
+				tx.Delete("b")
+				tx.Store("c", 3)
+				if v, ok := tx.Load("a"); !ok || v != 10 {
+					t.Errorf("Expected staged a=10, got %d, %v", v, ok)
+				}
+				if _, ok := tx.Load("b"); ok {
+					t.Errorf("Expected staged delete of b to hide it")
+				}
+				if tx.Len() != 2 {
+					t.Errorf("Expected staged length 2, got %d", tx.Len())
+				}
+				if _, ok := m.Load("c"); ok {
+					t.Errorf("Expected changes not to be visible before commit")
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expected := map[string]int{"a": 10, "c": 3}
+			if got := maps.ToGoMap[string, int](m); !equalGoMaps(got, expected) {
+				t.Errorf("Expected %v, got %v", expected, got)
+			}
+		})
+
+		t.Run(name+"/Rollback", func(t *testing.T) {
+			m := fill()
+			failure := errors.New("failure")
+			err := m.Update(func(tx maps.AbstractMap[string, int]) error {
+				tx.Store("a", 10)
+				tx.Clear()
+				tx.Store("c", 3)
+				return failure
+			})
+			if !errors.Is(err, failure) {
+				t.Errorf("Expected the callback's error, got %v", err)
+			}
+			expected := map[string]int{"a": 1, "b": 2}
+			if got := maps.ToGoMap[string, int](m); !equalGoMaps(got, expected) {
+				t.Errorf("Expected %v, got %v", expected, got)
+			}
+		})
+
+		t.Run(name+"/Clear", func(t *testing.T) {
+			m := fill()
+			m.Update(func(tx maps.AbstractMap[string, int]) error {
+				tx.Clear()
+				tx.Store("b", 20)
+				seen := map[string]int{}
+				tx.Range(func(key string, value int) bool {
+					seen[key] = value
+					return true
+				})
+				if !equalGoMaps(seen, map[string]int{"b": 20}) {
+					t.Errorf("Expected staged range to see only b=20, got %v", seen)
+				}
+				return nil
+			})
+			expected := map[string]int{"b": 20}
+			if got := maps.ToGoMap[string, int](m); !equalGoMaps(got, expected) {
+				t.Errorf("Expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func equalGoMaps(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOrderedMapUpdateOrder(t *testing.T) {
+	om := maps.Of(maps.P("x", 0), maps.P("y", 0))
+	err := om.Update(func(tx maps.AbstractMap[string, int]) error {
+		tx.Store("c", 3)
+		tx.Store("a", 1)
+		tx.Store("b", 2)
+		tx.Delete("x")
+		tx.Store("x", 9) // Re-created, so it moves to the end
+		var staged []string
+		tx.Range(func(key string, _ int) bool {
+			staged = append(staged, key)
+			return true
+		})
+		if expected := []string{"y", "c", "a", "b", "x"}; !slices.Equal(staged, expected) {
+			t.Errorf("Expected staged Range order %v, got %v", expected, staged)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if keys := om.KeysSlice(); !slices.Equal(keys, []string{"y", "c", "a", "b", "x"}) {
+		t.Errorf("Expected keys [y c a b x], got %v", keys)
+	}
+
+	fresh := maps.NewOrderedMap[string, int]()
+	fresh.Update(func(tx maps.AbstractMap[string, int]) error {
+		tx.Store("c", 3)
+		tx.Store("a", 1)
+		tx.Store("b", 2)
+		return nil
+	})
+	if keys := fresh.KeysSlice(); !slices.Equal(keys, []string{"c", "a", "b"}) {
+		t.Errorf("Expected keys [c a b], got %v", keys)
+	}
+}
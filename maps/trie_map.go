@@ -0,0 +1,265 @@
+package maps
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// trieNode is a node of the radix tree behind TrieMap. Each node is reached
+// from its parent through the non-empty edge label prefix; the root has an
+// empty prefix.
+type trieNode[V any] struct {
+	prefix   string
+	children []*trieNode[V] // Sorted by the first byte of their prefix
+	value    V
+	hasValue bool
+}
+
+// child returns the index of the child whose prefix starts with b, or the
+// index where such a child would be inserted, and whether it exists.
+// Time complexity: O(log k) where k is the number of children
+func (n *trieNode[V]) child(b byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, b, func(c *trieNode[V], b byte) int {
+		return cmp.Compare(c.prefix[0], b)
+	})
+}
+
+// clone returns a deep copy of the subtree rooted at n.
+func (n *trieNode[V]) clone() *trieNode[V] {
+	c := &trieNode[V]{prefix: n.prefix, value: n.value, hasValue: n.hasValue}
+	if len(n.children) > 0 {
+		c.children = make([]*trieNode[V], len(n.children))
+		for i, child := range n.children {
+			c.children[i] = child.clone()
+		}
+	}
+	return c
+}
+
+// delete removes key, relative to n, from the subtree rooted at n and
+// returns its value if it was present. Children left without a value are
+// pruned or merged into their only child so the tree stays compressed.
+func (n *trieNode[V]) delete(key string) (value V, ok bool) {
+	if key == "" {
+		if !n.hasValue {
+			return value, false
+		}
+		value = n.value
+		var zero V
+		n.value, n.hasValue = zero, false
+		return value, true
+	}
+	i, found := n.child(key[0])
+	if !found || !strings.HasPrefix(key, n.children[i].prefix) {
+		return value, false
+	}
+	c := n.children[i]
+	if value, ok = c.delete(key[len(c.prefix):]); !ok {
+		return value, false
+	}
+	if !c.hasValue {
+		switch len(c.children) {
+		case 0:
+			n.children = slices.Delete(n.children, i, i+1)
+		case 1:
+			grandchild := c.children[0]
+			grandchild.prefix = c.prefix + grandchild.prefix
+			n.children[i] = grandchild
+		}
+	}
+	return value, true
+}
+
+// walk calls f for every entry in the subtree rooted at n in lexicographic
+// key order, where key is the full key of n. It returns false if f stopped
+// the iteration.
+func (n *trieNode[V]) walk(key string, f func(key string, value V) bool) bool {
+	if n.hasValue && !f(key, n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(key+c.prefix, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// TrieMap implements AbstractMap for string keys using a radix tree, which
+// makes prefix queries cheap. Range, Keys and Values visit keys in
+// lexicographic byte order.
+// Load, Store and Delete are O(len(key)).
+type TrieMap[V any] struct {
+	*DefaultAbstractMap[string, V]
+	root *trieNode[V]
+	len  int
+}
+
+// NewTrieMap creates an empty TrieMap.
+func NewTrieMap[V any]() *TrieMap[V] {
+	tm := &TrieMap[V]{
+		root: &trieNode[V]{},
+	}
+	tm.DefaultAbstractMap = NewDefaultAbstractMap[string, V](tm)
+	return tm
+}
+
+// Clear removes all entries.
+// Time complexity: O(1)
+func (tm *TrieMap[V]) Clear() {
+	tm.checkMutable("Clear")
+	tm.cleared(tm.Range, func() {
+		tm.root = &trieNode[V]{}
+		tm.len = 0
+	})
+}
+
+// Clone returns an independent TrieMap with the same entries.
+// Time complexity: O(n)
+func (tm *TrieMap[V]) Clone() AbstractMap[string, V] {
+	clone := NewTrieMap[V]()
+	clone.root = tm.root.clone()
+	clone.len = tm.len
+	return clone
+}
+
+// Delete removes key from the map if present.
+// Time complexity: O(len(key))
+func (tm *TrieMap[V]) Delete(key string) {
+	tm.checkMutable("Delete")
+	if value, ok := tm.root.delete(key); ok {
+		tm.len--
+		tm.deleted(key, value)
+	}
+}
+
+// Len returns the number of entries in the map.
+// Time complexity: O(1)
+func (tm *TrieMap[V]) Len() int {
+	return tm.len
+}
+
+// Load returns the value stored for key.
+// Time complexity: O(len(key))
+func (tm *TrieMap[V]) Load(key string) (value V, ok bool) {
+	n := tm.root
+	for key != "" {
+		i, found := n.child(key[0])
+		if !found {
+			return value, false
+		}
+		c := n.children[i]
+		if !strings.HasPrefix(key, c.prefix) {
+			return value, false
+		}
+		key = key[len(c.prefix):]
+		n = c
+	}
+	return n.value, n.hasValue
+}
+
+// Range calls f for each entry in lexicographic key order until f returns
+// false.
+// Time complexity: O(n)
+func (tm *TrieMap[V]) Range(f func(key string, value V) bool) {
+	tm.root.walk("", f)
+}
+
+// Store sets the value for key.
+// Time complexity: O(len(key))
+func (tm *TrieMap[V]) Store(key string, value V) {
+	tm.checkMutable("Store")
+	n, rest := tm.root, key
+	for rest != "" {
+		i, found := n.child(rest[0])
+		if !found {
+			leaf := &trieNode[V]{prefix: rest, value: value, hasValue: true}
+			n.children = slices.Insert(n.children, i, leaf)
+			tm.len++
+			tm.stored(key, value)
+			return
+		}
+		c := n.children[i]
+		common := commonPrefixLen(rest, c.prefix)
+		if common < len(c.prefix) {
+			// Split the edge so that key's shared part ends on a node.
+			mid := &trieNode[V]{prefix: c.prefix[:common], children: []*trieNode[V]{c}}
+			c.prefix = c.prefix[common:]
+			n.children[i] = mid
+			c = mid
+		}
+		rest = rest[common:]
+		n = c
+	}
+	if !n.hasValue {
+		tm.len++
+	}
+	n.value, n.hasValue = value, true
+	tm.stored(key, value)
+}
+
+// WithPrefix calls f, in lexicographic key order, for each entry whose key
+// starts with prefix until f returns false.
+// Time complexity: O(len(prefix) + m) where m is the size of the matching subtree
+func (tm *TrieMap[V]) WithPrefix(prefix string, f func(key string, value V) bool) {
+	n, key := tm.root, ""
+	for rest := prefix; rest != ""; {
+		i, found := n.child(rest[0])
+		if !found {
+			return
+		}
+		c := n.children[i]
+		switch {
+		case strings.HasPrefix(rest, c.prefix):
+			rest = rest[len(c.prefix):]
+		case strings.HasPrefix(c.prefix, rest):
+			// prefix ends in the middle of this edge; everything below matches.
+			rest = ""
+		default:
+			return
+		}
+		key += c.prefix
+		n = c
+	}
+	n.walk(key, f)
+}
+
+// LongestPrefix returns the longest stored key that is a prefix of key,
+// along with its value.
+// Time complexity: O(len(key))
+func (tm *TrieMap[V]) LongestPrefix(key string) (string, V, bool) {
+	n := tm.root
+	best, value, ok := 0, n.value, n.hasValue
+	for consumed := 0; consumed < len(key); {
+		i, found := n.child(key[consumed])
+		if !found {
+			break
+		}
+		c := n.children[i]
+		if !strings.HasPrefix(key[consumed:], c.prefix) {
+			break
+		}
+		consumed += len(c.prefix)
+		n = c
+		if n.hasValue {
+			best, value, ok = consumed, n.value, true
+		}
+	}
+	if !ok {
+		var zero V
+		return "", zero, false
+	}
+	return key[:best], value, true
+}
+
+// commonPrefixLen returns the length of the longest common prefix of a and b.
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
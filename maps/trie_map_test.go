@@ -0,0 +1,157 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestTrieMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewTrieMap[string]()
+	}
+
+	testData := []TestCase[string, string]{
+		{"romane", "first"},
+		{"romanus", "second"},
+		{"romulus", "third"},
+		{"rubens", "fourth"},
+		{"ruber", "fifth"},
+		{"rom", "sixth"},
+		{"", "empty"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestTrieMapOrdering(t *testing.T) {
+	tm := maps.NewTrieMap[int]()
+	for i, key := range []string{"team", "tea", "ten", "a", "to", "i", "inn", "in"} {
+		tm.Store(key, i)
+	}
+
+	expected := []string{"a", "i", "in", "inn", "tea", "team", "ten", "to"}
+	if keys := tm.KeysSlice(); !slices.Equal(keys, expected) {
+		t.Errorf("Expected %v, got %v", expected, keys)
+	}
+}
+
+func TestTrieMapWithPrefix(t *testing.T) {
+	tm := maps.NewTrieMap[int]()
+	for i, key := range []string{"car", "card", "care", "careful", "cat", "dog"} {
+		tm.Store(key, i)
+	}
+
+	collect := func(prefix string) []string {
+		var keys []string
+		tm.WithPrefix(prefix, func(key string, _ int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		return keys
+	}
+
+	tests := []struct {
+		prefix   string
+		expected []string
+	}{
+		{"car", []string{"car", "card", "care", "careful"}},
+		{"ca", []string{"car", "card", "care", "careful", "cat"}},
+		{"care", []string{"care", "careful"}},
+		{"caref", []string{"careful"}},
+		{"", []string{"car", "card", "care", "careful", "cat", "dog"}},
+		{"cb", nil},
+		{"carefully", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			if keys := collect(tt.prefix); !slices.Equal(keys, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, keys)
+			}
+		})
+	}
+
+	t.Run("EarlyStop", func(t *testing.T) {
+		count := 0
+		tm.WithPrefix("car", func(string, int) bool {
+			count++
+			return count < 2
+		})
+		if count != 2 {
+			t.Errorf("Expected iteration to stop after 2 entries, got %d", count)
+		}
+	})
+}
+
+func TestTrieMapLongestPrefix(t *testing.T) {
+	routes := maps.NewTrieMap[string]()
+	routes.Store("/", "root")
+	routes.Store("/api", "api")
+	routes.Store("/api/users", "users")
+
+	tests := []struct {
+		path, prefix, value string
+		ok                  bool
+	}{
+		{"/api/users/42", "/api/users", "users", true},
+		{"/api/user", "/api", "api", true},
+		{"/static/app.js", "/", "root", true},
+		{"/api", "/api", "api", true},
+		{"nothing", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			prefix, value, ok := routes.LongestPrefix(tt.path)
+			if prefix != tt.prefix || value != tt.value || ok != tt.ok {
+				t.Errorf("Expected (%q, %q, %v), got (%q, %q, %v)", tt.prefix, tt.value, tt.ok, prefix, value, ok)
+			}
+		})
+	}
+}
+
+func TestTrieMapDeleteCompacts(t *testing.T) {
+	tm := maps.NewTrieMap[int]()
+	tm.Store("test", 1)
+	tm.Store("team", 2)
+	tm.Store("te", 3)
+
+	tm.Delete("te")
+	tm.Delete("tea") // Not stored: a split point only
+	if tm.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", tm.Len())
+	}
+
+	tm.Delete("test")
+	if value, ok := tm.Load("team"); !ok || value != 2 {
+		t.Errorf("Expected team=2 after deleting siblings, got %d (ok=%v)", value, ok)
+	}
+	if _, ok := tm.Load("te"); ok {
+		t.Errorf("Expected te to be gone")
+	}
+
+	tm.Store("te", 4)
+	if keys := tm.KeysSlice(); !slices.Equal(keys, []string{"te", "team"}) {
+		t.Errorf("Expected [te team], got %v", keys)
+	}
+}
+
+func TestTrieMapClone(t *testing.T) {
+	tm := maps.NewTrieMap[int]()
+	tm.Store("alpha", 1)
+	tm.Store("alps", 2)
+
+	clone := tm.Clone()
+	clone.Store("alpine", 3)
+	clone.Delete("alpha")
+
+	if tm.Len() != 2 {
+		t.Errorf("Expected original length 2, got %d", tm.Len())
+	}
+	if _, ok := tm.Load("alpha"); !ok {
+		t.Errorf("Expected original to keep alpha")
+	}
+	if _, ok := tm.Load("alpine"); ok {
+		t.Errorf("Expected original not to see alpine")
+	}
+}
@@ -0,0 +1,367 @@
+package maps
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ttlEntry is a stored value together with its expiry time.
+// A zero expires means the entry never expires.
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// expired reports whether the entry is no longer live at now.
+func (e ttlEntry[V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// TTLMap implements AbstractMap with entries that expire a fixed duration
+// after they are stored. Expired entries are invisible to every read:
+// Load reports them missing and removes them lazily, while Len, Range, Keys
+// and Values skip them. A background reaper can be started with StartReaper
+// to proactively release expired entries.
+// TTLMap is safe for concurrent use; callbacks passed to Range run without
+// the internal lock held, over a snapshot of the live entries.
+type TTLMap[K comparable, V any] struct {
+	*DefaultAbstractMap[K, V]
+	mu         sync.Mutex
+	m          map[K]ttlEntry[V]
+	defaultTTL time.Duration
+	clock      Clock
+	stop       chan struct{}        // Closed to stop the reaper; nil when not running
+	done       chan struct{}        // Closed by the reaper once it has exited
+	onExpire   func(key K, value V) // Registered with OnExpire
+}
+
+// NewTTLMap creates an empty TTLMap whose entries expire defaultTTL after
+// being stored. A defaultTTL of zero or less means entries never expire
+// unless stored with StoreWithTTL.
+func NewTTLMap[K comparable, V any](defaultTTL time.Duration) *TTLMap[K, V] {
+	return NewTTLMapWithClock[K, V](defaultTTL, systemClock{})
+}
+
+// NewTTLMapWithClock creates an empty TTLMap that reads time from clock.
+func NewTTLMapWithClock[K comparable, V any](defaultTTL time.Duration, clock Clock) *TTLMap[K, V] {
+	tm := &TTLMap[K, V]{
+		m:          make(map[K]ttlEntry[V]),
+		defaultTTL: defaultTTL,
+		clock:      clock,
+	}
+	tm.DefaultAbstractMap = NewDefaultAbstractMap(tm)
+	return tm
+}
+
+func (tm *TTLMap[K, V]) Clear() {
+	tm.checkMutable("Clear")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.cleared(tm.rangeStored, func() { clear(tm.m) })
+}
+
+// rangeStored yields every stored entry, including expired ones that have
+// not been removed yet; tm.mu must be held.
+func (tm *TTLMap[K, V]) rangeStored(yield func(K, V) bool) {
+	for k, e := range tm.m {
+		if !yield(k, e.value) {
+			return
+		}
+	}
+}
+
+// Clone returns an independent TTLMap with the same clock, default TTL and
+// entries, each keeping its original expiry time. The reaper is not copied.
+func (tm *TTLMap[K, V]) Clone() AbstractMap[K, V] {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	clone := NewTTLMapWithClock[K, V](tm.defaultTTL, tm.clock)
+	for k, e := range tm.m {
+		clone.m[k] = e
+	}
+	return clone
+}
+
+// AtomicUpdate runs the load, f and store under the lock. An expired entry
+// is passed to f as missing, and a stored value expires after the map's
+// default TTL.
+func (tm *TTLMap[K, V]) AtomicUpdate(key K, f func(old V, loaded bool) (new V, store bool)) (V, bool) {
+	tm.checkMutable("AtomicUpdate")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return atomicUpdate(key, f, tm.loadLive, func(key K, value V) {
+		tm.storeLocked(key, value, tm.defaultTTL)
+	})
+}
+
+func (tm *TTLMap[K, V]) updatesUnderLock() bool { return true }
+
+func (tm *TTLMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return tm.CompareAndDeleteFunc(key, old, equalAny[V])
+}
+
+// CompareAndDeleteFunc deletes key if it is live and eq reports its value
+// equal to old.
+func (tm *TTLMap[K, V]) CompareAndDeleteFunc(key K, old V, eq func(a, b V) bool) (deleted bool) {
+	tm.checkMutable("CompareAndDeleteFunc")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if value, ok := tm.loadLive(key); ok && eq(value, old) {
+		delete(tm.m, key)
+		tm.deleted(key, value)
+		return true
+	}
+	return false
+}
+
+func (tm *TTLMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return tm.CompareAndSwapFunc(key, old, new, equalAny[V])
+}
+
+// CompareAndSwapFunc stores new for key if it is live and eq reports its
+// value equal to old. The new value expires after the map's default TTL.
+func (tm *TTLMap[K, V]) CompareAndSwapFunc(key K, old, new V, eq func(a, b V) bool) (swapped bool) {
+	tm.checkMutable("CompareAndSwapFunc")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if value, ok := tm.loadLive(key); ok && eq(value, old) {
+		tm.storeLocked(key, new, tm.defaultTTL)
+		return true
+	}
+	return false
+}
+
+func (tm *TTLMap[K, V]) Delete(key K) {
+	tm.checkMutable("Delete")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if e, ok := tm.m[key]; ok {
+		delete(tm.m, key)
+		tm.deleted(key, e.value)
+	}
+}
+
+// Len returns the number of live entries.
+// Time complexity: O(n), since expired entries must be skipped.
+func (tm *TTLMap[K, V]) Len() int {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	now := tm.clock.Now()
+	n := 0
+	for _, e := range tm.m {
+		if !e.expired(now) {
+			n++
+		}
+	}
+	return n
+}
+
+// Load returns the value stored for key if it has not expired.
+// An expired entry is removed as a side effect.
+func (tm *TTLMap[K, V]) Load(key K) (value V, ok bool) {
+	tm.mu.Lock()
+	e, ok := tm.m[key]
+	if !ok {
+		tm.mu.Unlock()
+		return value, false
+	}
+	if e.expired(tm.clock.Now()) {
+		delete(tm.m, key)
+		tm.deleted(key, e.value)
+		onExpire := tm.onExpire
+		tm.mu.Unlock()
+		if onExpire != nil {
+			onExpire(key, e.value)
+		}
+		return value, false
+	}
+	tm.mu.Unlock()
+	return e.value, true
+}
+
+// LoadAndDelete deletes key and returns its value if it is live. An expired
+// entry is reported missing and left for Load or the reaper to remove.
+func (tm *TTLMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	tm.checkMutable("LoadAndDelete")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if value, loaded = tm.loadLive(key); loaded {
+		delete(tm.m, key)
+		tm.deleted(key, value)
+	}
+	return value, loaded
+}
+
+// LoadOrStore returns the live value for key if present. Otherwise it
+// stores value, expiring after the map's default TTL, and returns it.
+func (tm *TTLMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	tm.checkMutable("LoadOrStore")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if actual, loaded = tm.loadLive(key); loaded {
+		return actual, true
+	}
+	tm.storeLocked(key, value, tm.defaultTTL)
+	return value, false
+}
+
+// LoadOrCompute holds the lock across f, so concurrent callers for a
+// missing or expired key compute its value only once. A computed value
+// expires after the map's default TTL.
+func (tm *TTLMap[K, V]) LoadOrCompute(key K, f func() V) (actual V, loaded bool) {
+	tm.checkMutable("LoadOrCompute")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if actual, loaded = tm.loadLive(key); loaded {
+		return actual, true
+	}
+	actual = f()
+	tm.storeLocked(key, actual, tm.defaultTTL)
+	return actual, false
+}
+
+// loadLive returns the value for key if it is present and has not expired,
+// leaving an expired entry in place; tm.mu must be held.
+func (tm *TTLMap[K, V]) loadLive(key K) (value V, ok bool) {
+	e, ok := tm.m[key]
+	if !ok || e.expired(tm.clock.Now()) {
+		return value, false
+	}
+	return e.value, true
+}
+
+// Range calls f for each live entry until f returns false.
+// The entries are snapshotted first, so f may safely modify the map.
+func (tm *TTLMap[K, V]) Range(f func(key K, value V) bool) {
+	tm.mu.Lock()
+	now := tm.clock.Now()
+	live := make([]entry[K, V], 0, len(tm.m))
+	for k, e := range tm.m {
+		if !e.expired(now) {
+			live = append(live, entry[K, V]{key: k, value: e.value})
+		}
+	}
+	tm.mu.Unlock()
+
+	for _, e := range live {
+		if !f(e.key, e.value) {
+			break
+		}
+	}
+}
+
+// Store sets the value for key, expiring after the map's default TTL.
+func (tm *TTLMap[K, V]) Store(key K, value V) {
+	tm.StoreWithTTL(key, value, tm.defaultTTL)
+}
+
+// StoreWithTTL sets the value for key, expiring after ttl instead of the
+// default. A ttl of zero or less stores an entry that never expires.
+func (tm *TTLMap[K, V]) StoreWithTTL(key K, value V, ttl time.Duration) {
+	tm.checkMutable("StoreWithTTL")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.storeLocked(key, value, ttl)
+}
+
+// Swap stores value for key, expiring after the map's default TTL, and
+// returns the previous value if it was live.
+func (tm *TTLMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	tm.checkMutable("Swap")
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	previous, loaded = tm.loadLive(key)
+	tm.storeLocked(key, value, tm.defaultTTL)
+	return previous, loaded
+}
+
+// storeLocked sets the value for key, expiring after ttl; tm.mu must be held.
+func (tm *TTLMap[K, V]) storeLocked(key K, value V, ttl time.Duration) {
+	e := ttlEntry[V]{value: value}
+	if ttl > 0 {
+		e.expires = tm.clock.Now().Add(ttl)
+	}
+	tm.m[key] = e
+	tm.stored(key, value)
+}
+
+// DeleteExpired removes every expired entry and returns how many were removed.
+func (tm *TTLMap[K, V]) DeleteExpired() int {
+	tm.mu.Lock()
+	now := tm.clock.Now()
+	var removed []entry[K, V]
+	for k, e := range tm.m {
+		if e.expired(now) {
+			delete(tm.m, k)
+			tm.deleted(k, e.value)
+			removed = append(removed, entry[K, V]{key: k, value: e.value})
+		}
+	}
+	onExpire := tm.onExpire
+	tm.mu.Unlock()
+
+	if onExpire != nil {
+		for _, e := range removed {
+			onExpire(e.key, e.value)
+		}
+	}
+	return len(removed)
+}
+
+// OnExpire registers f to be called with each expired entry when Load,
+// DeleteExpired or the reaper removes it. Each entry is removed under the
+// lock by exactly one of them, so f fires once per entry; it runs after the
+// lock is released and may use the map. Expired entries that are deleted,
+// overwritten or cleared before being removed this way do not fire.
+// Passing nil unregisters the callback.
+func (tm *TTLMap[K, V]) OnExpire(f func(key K, value V)) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.onExpire = f
+}
+
+// StartReaper starts a background goroutine that calls DeleteExpired every
+// interval until Stop is called. The interval is measured by the map's
+// Clock if it implements TickerClock, and in real time otherwise. Starting
+// an already running reaper replaces it with one using the new interval.
+// It panics if interval is not positive.
+func (tm *TTLMap[K, V]) StartReaper(interval time.Duration) {
+	if interval <= 0 {
+		panic(fmt.Sprintf("maps: StartReaper interval must be positive, got %v", interval))
+	}
+	tm.Stop()
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	tm.mu.Lock()
+	tm.stop, tm.done = stop, done
+	tm.mu.Unlock()
+
+	ticks, stopTicker := newTicker(tm.clock, interval)
+	go func() {
+		defer close(done)
+		defer stopTicker()
+		for {
+			select {
+			case <-ticks:
+				tm.DeleteExpired()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background reaper, if running, and waits for it to exit.
+// It is safe to call Stop multiple times.
+func (tm *TTLMap[K, V]) Stop() {
+	tm.mu.Lock()
+	stop, done := tm.stop, tm.done
+	tm.stop, tm.done = nil, nil
+	tm.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
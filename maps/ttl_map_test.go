@@ -0,0 +1,326 @@
+package maps_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/13770129/containers/maps"
+)
+
+func TestTTLMapString(t *testing.T) {
+	factory := func() maps.AbstractMap[string, string] {
+		return maps.NewTTLMapWithClock[string, string](time.Minute, newFakeClock())
+	}
+
+	testData := []TestCase[string, string]{
+		{"key1", "value1"},
+		{"key2", "value2"},
+		{"key3", "value3"},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestTTLMapExpiry(t *testing.T) {
+	t.Run("LoadAfterExpiry", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		tm.Store("key", 1)
+
+		clock.Advance(59 * time.Second)
+		if value, ok := tm.Load("key"); !ok || value != 1 {
+			t.Errorf("Expected live value 1 before expiry, got %d (ok=%v)", value, ok)
+		}
+
+		clock.Advance(time.Second)
+		if _, ok := tm.Load("key"); ok {
+			t.Error("Expected key to be expired after TTL elapsed")
+		}
+	})
+
+	t.Run("IterationSkipsExpired", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		tm.Store("short", 1)
+		tm.StoreWithTTL("long", 2, time.Hour)
+
+		clock.Advance(2 * time.Minute)
+
+		if length := tm.Len(); length != 1 {
+			t.Errorf("Expected length 1 after expiry, got %d", length)
+		}
+		var keys []string
+		tm.Keys(func(key string) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if len(keys) != 1 || keys[0] != "long" {
+			t.Errorf("Expected only [long] to remain, got %v", keys)
+		}
+		var values []int
+		tm.Values(func(value int) bool {
+			values = append(values, value)
+			return true
+		})
+		if len(values) != 1 || values[0] != 2 {
+			t.Errorf("Expected only [2] to remain, got %v", values)
+		}
+	})
+
+	t.Run("StoreRefreshesTTL", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		tm.Store("key", 1)
+
+		clock.Advance(45 * time.Second)
+		tm.Store("key", 2)
+		clock.Advance(45 * time.Second)
+
+		if value, ok := tm.Load("key"); !ok || value != 2 {
+			t.Errorf("Expected refreshed TTL to keep key alive, got %d (ok=%v)", value, ok)
+		}
+	})
+
+	t.Run("NonPositiveTTLNeverExpires", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		tm.StoreWithTTL("forever", 1, 0)
+
+		clock.Advance(24 * time.Hour)
+		if _, ok := tm.Load("forever"); !ok {
+			t.Error("Expected entry stored with zero TTL to never expire")
+		}
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[int, int](time.Minute, clock)
+		for i := 0; i < 5; i++ {
+			tm.Store(i, i)
+		}
+		tm.StoreWithTTL(99, 99, time.Hour)
+
+		clock.Advance(time.Minute)
+		if removed := tm.DeleteExpired(); removed != 5 {
+			t.Errorf("Expected 5 expired entries removed, got %d", removed)
+		}
+		if removed := tm.DeleteExpired(); removed != 0 {
+			t.Errorf("Expected nothing left to remove, got %d", removed)
+		}
+		if length := tm.Len(); length != 1 {
+			t.Errorf("Expected length 1, got %d", length)
+		}
+	})
+
+	t.Run("ReaperStartStop", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		removed := make(chan string, 1)
+		tm.Observe(nil, func(key string, _ int) { removed <- key })
+		tm.Store("key", 1)
+		clock.Advance(time.Minute)
+
+		tm.StartReaper(time.Second)
+		tm.StartReaper(time.Second) // restarting replaces the reaper
+		if n := clock.Tickers(); n != 1 {
+			t.Errorf("Expected the restart to stop the first ticker, %d running", n)
+		}
+		clock.Advance(time.Second)
+		if key := <-removed; key != "key" {
+			t.Errorf("Expected the reaper to remove key, got %s", key)
+		}
+		tm.Stop()
+		tm.Stop() // stopping twice is a no-op
+		if n := clock.Tickers(); n != 0 {
+			t.Errorf("Expected Stop to stop the ticker, %d running", n)
+		}
+
+		if removed := tm.DeleteExpired(); removed != 0 {
+			t.Errorf("Expected the reaper to have left nothing to remove, got %d", removed)
+		}
+	})
+
+	t.Run("ReaperRejectsNonPositiveInterval", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Expected StartReaper(0) to panic")
+			}
+		}()
+		maps.NewTTLMap[string, int](time.Minute).StartReaper(0)
+	})
+}
+
+func TestTTLMapAtomicOperations(t *testing.T) {
+	t.Run("ExpiredEntriesAreMissing", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		for _, key := range []string{"a", "b", "c", "d", "e"} {
+			tm.Store(key, 1)
+		}
+		clock.Advance(time.Minute)
+
+		if actual, loaded := tm.LoadOrStore("a", 2); loaded || actual != 2 {
+			t.Errorf("Expected LoadOrStore to replace the expired value, got %d (loaded=%v)", actual, loaded)
+		}
+		if _, loaded := tm.LoadAndDelete("b"); loaded {
+			t.Error("Expected LoadAndDelete to report the expired key missing")
+		}
+		if previous, loaded := tm.Swap("c", 3); loaded || previous != 0 {
+			t.Errorf("Expected Swap to report no live previous value, got %d (loaded=%v)", previous, loaded)
+		}
+		if tm.CompareAndSwap("d", 1, 4) {
+			t.Error("Expected CompareAndSwap to fail on an expired entry")
+		}
+		if tm.CompareAndDelete("e", 1) {
+			t.Error("Expected CompareAndDelete to fail on an expired entry")
+		}
+		if v, ok := tm.AtomicUpdate("e", func(old int, loaded bool) (int, bool) { return old + 5, !loaded }); !ok || v != 5 {
+			t.Errorf("Expected AtomicUpdate to see the expired key missing and store 5, got %d (ok=%v)", v, ok)
+		}
+	})
+
+	t.Run("LiveEntries", func(t *testing.T) {
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, newFakeClock())
+		tm.Store("a", 1)
+		if actual, loaded := tm.LoadOrStore("a", 2); !loaded || actual != 1 {
+			t.Errorf("Expected LoadOrStore to load 1, got %d (loaded=%v)", actual, loaded)
+		}
+		if previous, loaded := tm.Swap("a", 2); !loaded || previous != 1 {
+			t.Errorf("Expected Swap to return 1, got %d (loaded=%v)", previous, loaded)
+		}
+		if !tm.CompareAndSwap("a", 2, 3) || tm.CompareAndSwap("a", 2, 4) {
+			t.Error("Expected CompareAndSwap to succeed only for the current value")
+		}
+		if tm.CompareAndDelete("a", 2) || !tm.CompareAndDelete("a", 3) {
+			t.Error("Expected CompareAndDelete to succeed only for the current value")
+		}
+		tm.Store("b", 1)
+		if value, loaded := tm.LoadAndDelete("b"); !loaded || value != 1 || tm.Len() != 0 {
+			t.Errorf("Expected LoadAndDelete to remove b=1, got %d (loaded=%v) with length %d", value, loaded, tm.Len())
+		}
+	})
+
+	t.Run("ConcurrentIncrement", func(t *testing.T) {
+		tm := maps.NewTTLMap[string, int](time.Hour)
+		const goroutines, increments = 8, 500
+		var wg sync.WaitGroup
+		for range goroutines {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range increments {
+					tm.AtomicUpdate("counter", func(old int, _ bool) (int, bool) {
+						runtime.Gosched() // Invite a lost update if the load and store are not atomic
+						return old + 1, true
+					})
+					maps.Increment(tm, "counter", 1)
+				}
+			}()
+		}
+		wg.Wait()
+		if v, _ := tm.Load("counter"); v != 2*goroutines*increments {
+			t.Errorf("Expected %d, got %d", 2*goroutines*increments, v)
+		}
+	})
+}
+
+func TestTTLMapOnExpire(t *testing.T) {
+	type expiry struct {
+		key   string
+		value int
+	}
+	setup := func() (*maps.TTLMap[string, int], *fakeClock, *[]expiry) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		var fired []expiry
+		tm.OnExpire(func(key string, value int) {
+			fired = append(fired, expiry{key, value})
+		})
+		return tm, clock, &fired
+	}
+
+	t.Run("LazyOnLoad", func(t *testing.T) {
+		tm, clock, fired := setup()
+		tm.Store("key", 1)
+
+		clock.Advance(59 * time.Second)
+		tm.Load("key")
+		if len(*fired) != 0 {
+			t.Errorf("Expected no callback before expiry, got %v", *fired)
+		}
+
+		clock.Advance(time.Second)
+		tm.Load("key")
+		tm.Load("key")
+		if len(*fired) != 1 || (*fired)[0] != (expiry{"key", 1}) {
+			t.Errorf("Expected one callback for key=1, got %v", *fired)
+		}
+	})
+
+	t.Run("Proactive", func(t *testing.T) {
+		tm, clock, fired := setup()
+		tm.Store("short", 1)
+		tm.StoreWithTTL("long", 2, time.Hour)
+
+		clock.Advance(time.Minute)
+		if n := tm.DeleteExpired(); n != 1 {
+			t.Errorf("Expected 1 removal, got %d", n)
+		}
+		if len(*fired) != 1 || (*fired)[0] != (expiry{"short", 1}) {
+			t.Errorf("Expected one callback for short=1, got %v", *fired)
+		}
+	})
+
+	t.Run("NoDoubleFire", func(t *testing.T) {
+		tm, clock, fired := setup()
+		tm.Store("key", 1)
+
+		clock.Advance(time.Minute)
+		tm.DeleteExpired()
+		tm.Load("key")
+		tm.DeleteExpired()
+		if len(*fired) != 1 {
+			t.Errorf("Expected exactly one callback across reaper and Load, got %v", *fired)
+		}
+
+		tm.Store("key", 2)
+		clock.Advance(time.Minute)
+		tm.Load("key")
+		tm.DeleteExpired()
+		if len(*fired) != 2 || (*fired)[1] != (expiry{"key", 2}) {
+			t.Errorf("Expected a second callback for the re-stored key=2, got %v", *fired)
+		}
+	})
+
+	t.Run("Reaper", func(t *testing.T) {
+		clock := newFakeClock()
+		tm := maps.NewTTLMapWithClock[string, int](time.Minute, clock)
+		expired := make(chan expiry, 1)
+		tm.OnExpire(func(key string, value int) { expired <- expiry{key, value} })
+		tm.Store("key", 7)
+		clock.Advance(time.Minute)
+
+		tm.StartReaper(time.Second)
+		defer tm.Stop()
+		clock.Advance(time.Second)
+		if e := <-expired; e != (expiry{"key", 7}) {
+			t.Errorf("Expected callback for key=7, got %v", e)
+		}
+	})
+
+	t.Run("CallbackMayUseMap", func(t *testing.T) {
+		tm, clock, _ := setup()
+		tm.OnExpire(func(key string, value int) {
+			tm.StoreWithTTL(key, value+1, 0)
+		})
+		tm.Store("key", 1)
+
+		clock.Advance(time.Minute)
+		tm.DeleteExpired()
+		if value, ok := tm.Load("key"); !ok || value != 2 {
+			t.Errorf("Expected callback to re-store key=2, got %d (ok=%v)", value, ok)
+		}
+	})
+}
@@ -1,8 +1,15 @@
 package maps
 
+import (
+	"cmp"
+	"slices"
+)
+
 type UnorderedMap[Key comparable, Value any] struct {
 	*DefaultAbstractMap[Key, Value]
-	m map[Key]Value
+	m        map[Key]Value
+	sortKeys func(keys []Key) // Set by NewUnorderedMapDeterministic
+	peak     int              // Largest size since the last shrink; see Cap
 }
 
 func NewUnorderedMap[Key comparable, Value any]() *UnorderedMap[Key, Value] {
@@ -13,20 +20,96 @@ func NewUnorderedMap[Key comparable, Value any]() *UnorderedMap[Key, Value] {
 	return um
 }
 
+// NewUnorderedMapWithCapacity creates an empty UnorderedMap with room for
+// about n entries, avoiding rehashing while it is filled up to that size.
+func NewUnorderedMapWithCapacity[Key comparable, Value any](n int) *UnorderedMap[Key, Value] {
+	um := NewUnorderedMap[Key, Value]()
+	um.m = make(map[Key]Value, n)
+	um.peak = n
+	return um
+}
+
+// NewUnorderedMapDeterministic creates an empty UnorderedMap whose Range,
+// and everything built on it, visits keys in ascending order instead of Go's
+// randomized map order. Load, Store and Delete stay O(1), but Range sorts the
+// keys on every call, so this is meant for reproducible tests and output.
+func NewUnorderedMapDeterministic[Key cmp.Ordered, Value any]() *UnorderedMap[Key, Value] {
+	um := NewUnorderedMap[Key, Value]()
+	um.sortKeys = slices.Sort[[]Key]
+	return um
+}
+
+func (um *UnorderedMap[Key, Value]) Clear() {
+	um.checkMutable("Clear")
+	um.cleared(um.Range, func() { clear(um.m) })
+}
+
+// ClearAndShrink removes all entries and releases the backing storage,
+// which Clear keeps because Go maps never shrink.
+func (um *UnorderedMap[Key, Value]) ClearAndShrink() {
+	um.checkMutable("ClearAndShrink")
+	um.cleared(um.Range, func() {
+		um.m = map[Key]Value{}
+		um.peak = 0
+	})
+}
+
+func (um *UnorderedMap[Key, Value]) Clone() AbstractMap[Key, Value] {
+	clone := NewUnorderedMapWithCapacity[Key, Value](len(um.m))
+	clone.sortKeys = um.sortKeys
+	for k, v := range um.m {
+		clone.m[k] = v
+	}
+	return clone
+}
+
 func (um *UnorderedMap[Key, Value]) Delete(key Key) {
-	delete(um.m, key)
+	um.checkMutable("Delete")
+	if value, ok := um.m[key]; ok {
+		delete(um.m, key)
+		um.deleted(key, value)
+	}
+}
+
+// Grow makes room for at least n more entries. Go maps cannot reserve space
+// in place, so a non-empty map is copied into a larger one, unless Cap
+// already covers them.
+func (um *UnorderedMap[Key, Value]) Grow(n int) {
+	if n <= 0 || um.peak >= len(um.m)+n {
+		return
+	}
+	grown := make(map[Key]Value, len(um.m)+n)
+	for k, v := range um.m {
+		grown[k] = v
+	}
+	um.m = grown
+	um.peak = max(um.peak, len(um.m)+n)
+}
+
+// Cap returns the number of entries the backing map has room for, as far as
+// it can be known: Go does not expose map capacity, so this is the largest
+// of the size reached and the capacity requested since the last
+// ClearAndShrink. It never decreases otherwise, because Go maps never shrink.
+func (um *UnorderedMap[Key, Value]) Cap() int {
+	return um.peak
 }
 
 func (um *UnorderedMap[Key, Value]) Len() int {
 	return len(um.m)
 }
 
+func (um *UnorderedMap[Key, Value]) readSafe() bool { return true }
+
 func (um *UnorderedMap[Key, Value]) Load(key Key) (value Value, ok bool) {
 	value, ok = um.m[key]
 	return value, ok
 }
 
 func (um *UnorderedMap[Key, Value]) Range(f func(key Key, value Value) bool) {
+	if um.sortKeys != nil {
+		um.rangeSorted(f)
+		return
+	}
 	for k, v := range um.m {
 		if !f(k, v) {
 			break
@@ -34,6 +117,56 @@ func (um *UnorderedMap[Key, Value]) Range(f func(key Key, value Value) bool) {
 	}
 }
 
+// rangeSorted implements Range for deterministic maps. It iterates over a
+// sorted snapshot of the keys and skips any that f deletes.
+func (um *UnorderedMap[Key, Value]) rangeSorted(f func(key Key, value Value) bool) {
+	keys := make([]Key, 0, len(um.m))
+	for k := range um.m {
+		keys = append(keys, k)
+	}
+	um.sortKeys(keys)
+	for _, k := range keys {
+		if v, ok := um.m[k]; ok && !f(k, v) {
+			break
+		}
+	}
+}
+
+// ToGoMap returns a copy of the map's contents as a builtin map.
+func (um *UnorderedMap[Key, Value]) ToGoMap() map[Key]Value {
+	gm := make(map[Key]Value, len(um.m))
+	for k, v := range um.m {
+		gm[k] = v
+	}
+	return gm
+}
+
+// String formats the map as map[k1:v1 k2:v2] in an unspecified order.
+func (um *UnorderedMap[Key, Value]) String() string {
+	return formatMap(um.Range)
+}
+
+// GoString formats the map in Go syntax for the %#v verb.
+func (um *UnorderedMap[Key, Value]) GoString() string {
+	return goFormatMap(um, um.Range)
+}
+
 func (um *UnorderedMap[Key, Value]) Store(key Key, value Value) {
+	um.checkMutable("Store")
 	um.m[key] = value
+	um.peak = max(um.peak, len(um.m))
+	um.stored(key, value)
+}
+
+// Update calls fn with a staging map that reflects the current contents and
+// records the changes made through it. If fn returns nil, the changes are
+// applied to um; otherwise um is left untouched and fn's error is returned.
+func (um *UnorderedMap[Key, Value]) Update(fn func(tx AbstractMap[Key, Value]) error) error {
+	um.checkMutable("Update")
+	tx := newTxMap[Key, Value](um)
+	if err := fn(tx); err != nil {
+		return err
+	}
+	tx.commit(um.Store, um.Delete, um.Clear)
+	return nil
 }
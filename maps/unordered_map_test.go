@@ -2,6 +2,8 @@ package maps_test
 
 import (
 	"fmt"
+	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/13770129/containers/maps"
@@ -74,3 +76,235 @@ func BenchmarkUnorderedMapOperations(b *testing.B) {
 		}
 	})
 }
+
+func TestUnorderedMapWithCapacity(t *testing.T) {
+	factory := func() maps.AbstractMap[string, int] {
+		return maps.NewUnorderedMapWithCapacity[string, int](16)
+	}
+
+	testData := []TestCase[string, int]{
+		{"one", 1},
+		{"two", 2},
+		{"three", 3},
+	}
+
+	testSuite(t, factory, testData)
+}
+
+func TestUnorderedMapGrow(t *testing.T) {
+	um := maps.NewUnorderedMap[int, int]()
+	um.Store(1, 10)
+	um.Grow(100)
+	um.Grow(0)
+
+	if value, ok := um.Load(1); !ok || value != 10 {
+		t.Errorf("Expected entries to survive Grow, got %d (ok=%v)", value, ok)
+	}
+	if um.Len() != 1 {
+		t.Errorf("Expected length 1 after Grow, got %d", um.Len())
+	}
+}
+
+func TestFromGoMaps(t *testing.T) {
+	m := maps.FromGoMaps(maps.NewUnorderedMap[string, int](),
+		map[string]int{"a": 1, "b": 2},
+		map[string]int{"b": 20, "c": 3},
+	)
+
+	expected := map[string]int{"a": 1, "b": 20, "c": 3}
+	if !maps.Equal[string, int](m, maps.FromGoMaps(maps.NewOrderedMap[string, int](), expected)) {
+		t.Errorf("Expected %v, got %v", expected, m.ToGoMap())
+	}
+}
+
+func TestFromGoMapsFunc(t *testing.T) {
+	a := map[string]int{"x": 1, "y": 2}
+	b := map[string]int{"y": 20, "z": 3}
+	c := map[string]int{"y": 200}
+
+	t.Run("Sum", func(t *testing.T) {
+		m := maps.FromGoMapsFunc(maps.NewUnorderedMap[string, int](), func(_ string, existing, incoming int) int {
+			return existing + incoming
+		}, a, b, c)
+		if expected := map[string]int{"x": 1, "y": 222, "z": 3}; !equalGoMaps(m.ToGoMap(), expected) {
+			t.Errorf("Expected %v, got %v", expected, m.ToGoMap())
+		}
+	})
+
+	t.Run("KeepFirst", func(t *testing.T) {
+		m := maps.FromGoMapsFunc(maps.NewUnorderedMap[string, int](), func(_ string, existing, _ int) int {
+			return existing
+		}, a, b, c)
+		if expected := map[string]int{"x": 1, "y": 2, "z": 3}; !equalGoMaps(m.ToGoMap(), expected) {
+			t.Errorf("Expected %v, got %v", expected, m.ToGoMap())
+		}
+	})
+
+	t.Run("ExistingEntries", func(t *testing.T) {
+		m := maps.NewUnorderedMap[string, int]()
+		m.Store("x", 100)
+		maps.FromGoMapsFunc(m, func(_ string, existing, incoming int) int {
+			return max(existing, incoming)
+		}, a)
+		if v, _ := m.Load("x"); v != 100 {
+			t.Errorf("Expected the pre-existing larger value 100 to win, got %d", v)
+		}
+	})
+}
+
+// BenchmarkCapacityHint compares filling a map of 1M keys with and without
+// a capacity hint.
+func BenchmarkCapacityHint(b *testing.B) {
+	const size = 1_000_000
+
+	b.Run("UnorderedMapNoHint", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m := maps.NewUnorderedMap[int, int]()
+			for k := 0; k < size; k++ {
+				m.Store(k, k)
+			}
+		}
+	})
+
+	b.Run("UnorderedMapWithCapacity", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m := maps.NewUnorderedMapWithCapacity[int, int](size)
+			for k := 0; k < size; k++ {
+				m.Store(k, k)
+			}
+		}
+	})
+
+	b.Run("OrderedMapNoHint", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m := maps.NewOrderedMap[int, int]()
+			for k := 0; k < size; k++ {
+				m.Store(k, k)
+			}
+		}
+	})
+
+	b.Run("OrderedMapWithCapacity", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m := maps.NewOrderedMapWithCapacity[int, int](size)
+			for k := 0; k < size; k++ {
+				m.Store(k, k)
+			}
+		}
+	})
+}
+
+// heapAfter returns the live heap size after running fill and a full GC,
+// while the map returned by fill is still reachable.
+func heapAfter(fill func() any) uint64 {
+	m := fill()
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	runtime.KeepAlive(m)
+	return stats.HeapAlloc
+}
+
+// TestClearAndShrink checks the observable effects of ClearAndShrink; the
+// memory it releases is measured by BenchmarkFillAndClear instead, since
+// heap sizes are too noisy to assert on.
+func TestClearAndShrink(t *testing.T) {
+	const size = 1000
+
+	t.Run("UnorderedMap", func(t *testing.T) {
+		um := maps.NewUnorderedMap[int, int]()
+		for i := 0; i < size; i++ {
+			um.Store(i, i)
+		}
+		um.ClearAndShrink()
+		if um.Len() != 0 || um.Cap() != 0 {
+			t.Errorf("Expected length 0 and Cap 0 after ClearAndShrink, got %d and %d", um.Len(), um.Cap())
+		}
+		um.Store(1, 1)
+		if value, ok := um.Load(1); !ok || value != 1 {
+			t.Errorf("Expected map to be usable after ClearAndShrink")
+		}
+		if um.Cap() < um.Len() {
+			t.Errorf("Expected Cap %d to be at least Len %d", um.Cap(), um.Len())
+		}
+	})
+
+	t.Run("OrderedMap", func(t *testing.T) {
+		om := maps.NewOrderedMap[int, int]()
+		for i := 0; i < size; i++ {
+			om.Store(i, i)
+		}
+		om.ClearAndShrink()
+		if om.Len() != 0 || om.Cap() != 0 {
+			t.Errorf("Expected length 0 and Cap 0 after ClearAndShrink, got %d and %d", om.Len(), om.Cap())
+		}
+		om.Store(2, 2)
+		om.Store(1, 1)
+		if keys := om.KeysSlice(); len(keys) != 2 || keys[0] != 2 {
+			t.Errorf("Expected map to be usable after ClearAndShrink, got keys %v", keys)
+		}
+		if om.Cap() < om.Len() {
+			t.Errorf("Expected Cap %d to be at least Len %d", om.Cap(), om.Len())
+		}
+	})
+}
+
+// BenchmarkFillAndClear repeatedly fills a map and empties it, reporting the
+// live heap left behind after each cycle.
+func BenchmarkFillAndClear(b *testing.B) {
+	const size = 100_000
+	variants := map[string]func(*maps.UnorderedMap[int, int]){
+		"Clear":          (*maps.UnorderedMap[int, int]).Clear,
+		"ClearAndShrink": (*maps.UnorderedMap[int, int]).ClearAndShrink,
+	}
+	for name, empty := range variants {
+		b.Run(name, func(b *testing.B) {
+			um := maps.NewUnorderedMap[int, int]()
+			var retained uint64
+			for i := 0; i < b.N; i++ {
+				for k := 0; k < size; k++ {
+					um.Store(k, k)
+				}
+				empty(um)
+				retained = heapAfter(func() any { return um })
+			}
+			b.ReportMetric(float64(retained), "retained-B")
+		})
+	}
+}
+
+func TestUnorderedMapDeterministic(t *testing.T) {
+	um := maps.NewUnorderedMapDeterministic[string, int]()
+	for i, k := range []string{"pear", "apple", "fig", "banana", "cherry"} {
+		um.Store(k, i)
+	}
+	expected := []string{"apple", "banana", "cherry", "fig", "pear"}
+
+	for i := range 10 {
+		if got := um.KeysSlice(); !slices.Equal(got, expected) {
+			t.Fatalf("Call %d: expected %v, got %v", i, expected, got)
+		}
+	}
+	if got := um.Clone().(*maps.UnorderedMap[string, int]).KeysSlice(); !slices.Equal(got, expected) {
+		t.Errorf("Expected clone to stay deterministic, got %v", got)
+	}
+	if got := um.String(); got != "map[apple:1 banana:3 cherry:4 fig:2 pear:0]" {
+		t.Errorf("Expected sorted String output, got %s", got)
+	}
+
+	t.Run("DeleteDuringRange", func(t *testing.T) {
+		var visited []string
+		um.Range(func(key string, value int) bool {
+			visited = append(visited, key)
+			um.Delete("fig")
+			return true
+		})
+		if expected := []string{"apple", "banana", "cherry", "pear"}; !slices.Equal(visited, expected) {
+			t.Errorf("Expected %v, got %v", expected, visited)
+		}
+	})
+}
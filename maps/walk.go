@@ -0,0 +1,84 @@
+package maps
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Order selects the sequence in which Walk visits entries.
+type Order int
+
+const (
+	// Natural visits entries in the map's Range order.
+	Natural Order = iota
+	// InsertionOrder visits entries from oldest to newest insertion. Maps
+	// that do not track insertion order fall back to Range order.
+	InsertionOrder
+	// ReverseInsertionOrder visits entries from newest to oldest insertion.
+	// Maps that do not track insertion order visit Range order reversed.
+	ReverseInsertionOrder
+	// SortedKeyOrder visits entries in ascending key order. Maps that keep
+	// their keys sorted use their own ordering; others are collected and
+	// sorted, comparing numbers and strings by value and anything else by
+	// its fmt representation.
+	SortedKeyOrder
+)
+
+// String returns the name of the order.
+func (o Order) String() string {
+	switch o {
+	case Natural:
+		return "Natural"
+	case InsertionOrder:
+		return "InsertionOrder"
+	case ReverseInsertionOrder:
+		return "ReverseInsertionOrder"
+	case SortedKeyOrder:
+		return "SortedKeyOrder"
+	}
+	return fmt.Sprintf("Order(%d)", int(o))
+}
+
+// reverseRanger is implemented by maps that can range in reverse insertion
+// order without collecting their entries.
+type reverseRanger[K, V any] interface {
+	RangeReverse(f func(key K, value V) bool)
+}
+
+// Walk calls f for each entry in the given order until f returns false.
+// Orders a map cannot produce natively are served from a snapshot of the
+// entries, so f may then modify the map.
+func (m *DefaultAbstractMap[K, V]) Walk(order Order, f func(key K, value V) bool) {
+	switch order {
+	case ReverseInsertionOrder:
+		if r, ok := m.impl.(reverseRanger[K, V]); ok {
+			r.RangeReverse(f)
+			return
+		}
+		entries := m.EntriesSlice()
+		slices.Reverse(entries)
+		walkEntries(entries, f)
+	case SortedKeyOrder:
+		switch m.impl.(type) {
+		case *SortedMap[K, V], *SkipListMap[K, V]:
+			m.impl.Range(f)
+			return
+		}
+		entries := m.EntriesSlice()
+		slices.SortStableFunc(entries, func(a, b Pair[K, V]) int {
+			return compareAny(a.Key, b.Key)
+		})
+		walkEntries(entries, f)
+	default:
+		m.impl.Range(f)
+	}
+}
+
+// walkEntries calls f for each of entries until f returns false.
+func walkEntries[K, V any](entries []Pair[K, V], f func(key K, value V) bool) {
+	for _, e := range entries {
+		if !f(e.Key, e.Value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package maps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/maps"
+)
+
+// walkKeys returns the keys Walk visits in order.
+func walkKeys[V any](m interface {
+	Walk(order maps.Order, f func(key string, value V) bool)
+}, order maps.Order) []string {
+	var keys []string
+	m.Walk(order, func(key string, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func TestWalk(t *testing.T) {
+	t.Run("OrderedMap", func(t *testing.T) {
+		om := maps.Of(maps.P("cherry", 1), maps.P("apple", 2), maps.P("banana", 3))
+		expected := map[maps.Order][]string{
+			maps.Natural:               {"cherry", "apple", "banana"},
+			maps.InsertionOrder:        {"cherry", "apple", "banana"},
+			maps.ReverseInsertionOrder: {"banana", "apple", "cherry"},
+			maps.SortedKeyOrder:        {"apple", "banana", "cherry"},
+		}
+		for order, want := range expected {
+			if got := walkKeys[int](om, order); !slices.Equal(got, want) {
+				t.Errorf("%v: expected %v, got %v", order, want, got)
+			}
+		}
+	})
+
+	t.Run("UnorderedMapFallback", func(t *testing.T) {
+		um := maps.NewUnorderedMap[string, int]()
+		for i, k := range []string{"delta", "alpha", "charlie", "bravo"} {
+			um.Store(k, i)
+		}
+		if got := walkKeys[int](um, maps.SortedKeyOrder); !slices.Equal(got, []string{"alpha", "bravo", "charlie", "delta"}) {
+			t.Errorf("Expected sorted keys, got %v", got)
+		}
+		forward, reverse := walkKeys[int](um, maps.Natural), walkKeys[int](um, maps.ReverseInsertionOrder)
+		if len(forward) != 4 || len(reverse) != 4 {
+			t.Errorf("Expected every key to be visited, got %v and %v", forward, reverse)
+		}
+	})
+
+	t.Run("SortedMapNative", func(t *testing.T) {
+		sm := maps.NewSortedMap[string, int](func(a, b string) bool { return a > b })
+		for _, k := range []string{"a", "c", "b"} {
+			sm.Store(k, 0)
+		}
+		if got := walkKeys[int](sm, maps.SortedKeyOrder); !slices.Equal(got, []string{"c", "b", "a"}) {
+			t.Errorf("Expected the map's own descending order, got %v", got)
+		}
+	})
+
+	t.Run("EarlyStop", func(t *testing.T) {
+		om := maps.Of(maps.P("b", 1), maps.P("a", 2), maps.P("c", 3))
+		for _, order := range []maps.Order{maps.Natural, maps.ReverseInsertionOrder, maps.SortedKeyOrder} {
+			calls := 0
+			om.Walk(order, func(string, int) bool {
+				calls++
+				return false
+			})
+			if calls != 1 {
+				t.Errorf("%v: expected Walk to stop after 1 call, got %d", order, calls)
+			}
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if got := maps.SortedKeyOrder.String(); got != "SortedKeyOrder" {
+			t.Errorf("Expected SortedKeyOrder, got %s", got)
+		}
+		if got := maps.Order(42).String(); got != "Order(42)" {
+			t.Errorf("Expected Order(42), got %s", got)
+		}
+	})
+}
@@ -0,0 +1,3 @@
+module github.com/13770129/containers/queues
+
+go 1.24.3
@@ -0,0 +1,107 @@
+package queues
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Queue is a FIFO queue backed by a circular slice that doubles when full.
+// Dequeued slots are reused, so a queue cycling through many values only
+// grows to the largest number it held at once.
+// The zero value is an empty, unbounded queue ready to use.
+type Queue[T any] struct {
+	buf   []T
+	head  int // Index of the oldest value
+	len   int
+	bound int // Maximum length, or 0 for no limit; set by NewBoundedQueue
+}
+
+// NewQueue creates an empty, unbounded Queue.
+func NewQueue[T any]() *Queue[T] {
+	return new(Queue[T])
+}
+
+// NewBoundedQueue creates an empty Queue holding at most capacity values.
+// It panics if capacity is less than one.
+func NewBoundedQueue[T any](capacity int) *Queue[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("queues: NewBoundedQueue capacity must be at least 1, got %d", capacity))
+	}
+	return &Queue[T]{buf: make([]T, capacity), bound: capacity}
+}
+
+// index returns the position in buf of the i-th oldest value.
+func (q *Queue[T]) index(i int) int {
+	return (q.head + i) % len(q.buf)
+}
+
+// grow doubles the buffer, moving the values to its start in FIFO order.
+func (q *Queue[T]) grow() {
+	buf := make([]T, max(4, 2*len(q.buf)))
+	for i := range q.len {
+		buf[i] = q.buf[q.index(i)]
+	}
+	q.buf, q.head = buf, 0
+}
+
+// Len returns the number of values in the queue.
+// Time complexity: O(1)
+func (q *Queue[T]) Len() int {
+	return q.len
+}
+
+// Enqueue appends value as the newest element and reports whether it did.
+// It returns false, leaving the queue unchanged, only for a bounded queue
+// that is full.
+// Time complexity: amortized O(1)
+func (q *Queue[T]) Enqueue(value T) bool {
+	if q.len == len(q.buf) {
+		if q.bound > 0 {
+			return false
+		}
+		q.grow()
+	}
+	q.buf[q.index(q.len)] = value
+	q.len++
+	return true
+}
+
+// Dequeue removes and returns the oldest value, or the zero value and false
+// if the queue is empty.
+// Time complexity: O(1)
+func (q *Queue[T]) Dequeue() (value T, ok bool) {
+	if q.len == 0 {
+		return value, false
+	}
+	var zero T
+	value, q.buf[q.head] = q.buf[q.head], zero
+	q.head = q.index(1)
+	q.len--
+	return value, true
+}
+
+// Peek returns the oldest value without removing it, or the zero value and
+// false if the queue is empty.
+// Time complexity: O(1)
+func (q *Queue[T]) Peek() (value T, ok bool) {
+	if q.len == 0 {
+		return value, false
+	}
+	return q.buf[q.head], true
+}
+
+// Range calls f for each value from oldest to newest until f returns false.
+// The queue must not be modified during iteration.
+// Time complexity: O(n)
+func (q *Queue[T]) Range(f func(value T) bool) {
+	for i := range q.len {
+		if !f(q.buf[q.index(i)]) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the values from oldest to newest.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.Range
+}
@@ -0,0 +1,93 @@
+package queues_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/queues"
+)
+
+func TestQueue(t *testing.T) {
+	t.Run("FIFO", func(t *testing.T) {
+		q := queues.NewQueue[int]()
+		for i := range 10 {
+			if !q.Enqueue(i) {
+				t.Fatalf("Expected Enqueue on an unbounded queue to succeed")
+			}
+		}
+		if value, ok := q.Peek(); !ok || value != 0 {
+			t.Errorf("Expected Peek to return 0, got %d (ok=%v)", value, ok)
+		}
+		if got := slices.Collect(q.All()); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+			t.Errorf("Expected oldest-to-newest order, got %v", got)
+		}
+		for i := range 10 {
+			if value, ok := q.Dequeue(); !ok || value != i {
+				t.Errorf("Expected Dequeue to return %d, got %d (ok=%v)", i, value, ok)
+			}
+		}
+		if q.Len() != 0 {
+			t.Errorf("Expected empty queue, got length %d", q.Len())
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var q queues.Queue[string]
+		if _, ok := q.Dequeue(); ok {
+			t.Error("Expected Dequeue on an empty queue to fail")
+		}
+		if _, ok := q.Peek(); ok {
+			t.Error("Expected Peek on an empty queue to fail")
+		}
+	})
+
+	t.Run("WraparoundReuse", func(t *testing.T) {
+		q := queues.NewQueue[int]()
+		for i := range 3 {
+			q.Enqueue(i)
+		}
+		next := 0
+		allocs := testing.AllocsPerRun(1000, func() {
+			q.Enqueue(next + 3)
+			if value, _ := q.Dequeue(); value != next {
+				t.Fatalf("Expected Dequeue to return %d, got %d", next, value)
+			}
+			next++
+		})
+		if allocs != 0 {
+			t.Errorf("Expected steady enqueue/dequeue cycles to reuse the buffer, got %v allocations per cycle", allocs)
+		}
+		if got := slices.Collect(q.All()); !slices.Equal(got, []int{next, next + 1, next + 2}) {
+			t.Errorf("Expected the last three values after wrapping, got %v", got)
+		}
+	})
+
+	t.Run("Bounded", func(t *testing.T) {
+		q := queues.NewBoundedQueue[int](2)
+		if !q.Enqueue(1) || !q.Enqueue(2) {
+			t.Fatal("Expected Enqueue below capacity to succeed")
+		}
+		if q.Enqueue(3) {
+			t.Error("Expected Enqueue on a full bounded queue to fail")
+		}
+		if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("Expected a rejected Enqueue to leave [1 2], got %v", got)
+		}
+		q.Dequeue()
+		if !q.Enqueue(3) {
+			t.Error("Expected Enqueue to succeed once there is room")
+		}
+		if got := slices.Collect(q.All()); !slices.Equal(got, []int{2, 3}) {
+			t.Errorf("Expected [2 3], got %v", got)
+		}
+	})
+
+	t.Run("BoundedPanics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected NewBoundedQueue(0) to panic")
+			}
+		}()
+		queues.NewBoundedQueue[int](0)
+	})
+}
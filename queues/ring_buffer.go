@@ -0,0 +1,86 @@
+package queues
+
+import (
+	"fmt"
+	"iter"
+)
+
+// RingBuffer is a fixed-capacity FIFO queue backed by a circular slice.
+// Pushing into a full buffer overwrites the oldest value, so memory use
+// never grows beyond the capacity given at construction.
+type RingBuffer[T any] struct {
+	buf  []T
+	head int // Index of the oldest value
+	len  int
+}
+
+// NewRingBuffer creates an empty RingBuffer holding at most capacity values.
+// It panics if capacity is less than one.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("queues: NewRingBuffer capacity must be at least 1, got %d", capacity))
+	}
+	return &RingBuffer[T]{buf: make([]T, capacity)}
+}
+
+// index returns the position in buf of the i-th oldest value.
+func (rb *RingBuffer[T]) index(i int) int {
+	return (rb.head + i) % len(rb.buf)
+}
+
+// Cap returns the maximum number of values the buffer holds.
+// Time complexity: O(1)
+func (rb *RingBuffer[T]) Cap() int {
+	return len(rb.buf)
+}
+
+// Len returns the number of values in the buffer.
+// Time complexity: O(1)
+func (rb *RingBuffer[T]) Len() int {
+	return rb.len
+}
+
+// Push appends value as the newest element. If the buffer is full, the
+// oldest value is overwritten and returned with didEvict set to true.
+// Time complexity: O(1)
+func (rb *RingBuffer[T]) Push(value T) (evicted T, didEvict bool) {
+	if rb.len == len(rb.buf) {
+		evicted = rb.buf[rb.head]
+		rb.buf[rb.head] = value
+		rb.head = rb.index(1)
+		return evicted, true
+	}
+	rb.buf[rb.index(rb.len)] = value
+	rb.len++
+	return evicted, false
+}
+
+// Pop removes and returns the oldest value, or the zero value and false if
+// the buffer is empty.
+// Time complexity: O(1)
+func (rb *RingBuffer[T]) Pop() (value T, ok bool) {
+	if rb.len == 0 {
+		return value, false
+	}
+	var zero T
+	value, rb.buf[rb.head] = rb.buf[rb.head], zero
+	rb.head = rb.index(1)
+	rb.len--
+	return value, true
+}
+
+// Range calls f for each value from oldest to newest until f returns false.
+// The buffer must not be modified during iteration.
+// Time complexity: O(n)
+func (rb *RingBuffer[T]) Range(f func(value T) bool) {
+	for i := range rb.len {
+		if !f(rb.buf[rb.index(i)]) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the values from oldest to newest.
+func (rb *RingBuffer[T]) All() iter.Seq[T] {
+	return rb.Range
+}
@@ -0,0 +1,82 @@
+package queues_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/queues"
+)
+
+func TestRingBuffer(t *testing.T) {
+	t.Run("FillAndDrain", func(t *testing.T) {
+		rb := queues.NewRingBuffer[int](3)
+		for i := range 3 {
+			if _, evicted := rb.Push(i); evicted {
+				t.Errorf("Expected no eviction while filling, got one at %d", i)
+			}
+		}
+		if rb.Len() != 3 || rb.Cap() != 3 {
+			t.Errorf("Expected length and capacity 3, got %d and %d", rb.Len(), rb.Cap())
+		}
+		for i := range 3 {
+			if value, ok := rb.Pop(); !ok || value != i {
+				t.Errorf("Expected Pop to return %d, got %d (ok=%v)", i, value, ok)
+			}
+		}
+		if _, ok := rb.Pop(); ok {
+			t.Error("Expected Pop on an empty buffer to report false")
+		}
+	})
+
+	t.Run("Eviction", func(t *testing.T) {
+		rb := queues.NewRingBuffer[string](2)
+		rb.Push("a")
+		rb.Push("b")
+		if evicted, ok := rb.Push("c"); !ok || evicted != "a" {
+			t.Errorf("Expected to evict a, got %q (ok=%v)", evicted, ok)
+		}
+		if evicted, ok := rb.Push("d"); !ok || evicted != "b" {
+			t.Errorf("Expected to evict b, got %q (ok=%v)", evicted, ok)
+		}
+		if rb.Len() != 2 {
+			t.Errorf("Expected length 2, got %d", rb.Len())
+		}
+	})
+
+	t.Run("RangeAfterWrap", func(t *testing.T) {
+		rb := queues.NewRingBuffer[int](4)
+		for i := range 10 {
+			rb.Push(i)
+		}
+		if got := slices.Collect(rb.All()); !slices.Equal(got, []int{6, 7, 8, 9}) {
+			t.Errorf("Expected [6 7 8 9], got %v", got)
+		}
+
+		// Interleave pops and pushes so the live window straddles the end
+		// of the backing slice.
+		rb.Pop()
+		rb.Pop()
+		rb.Push(10)
+		if got := slices.Collect(rb.All()); !slices.Equal(got, []int{8, 9, 10}) {
+			t.Errorf("Expected [8 9 10], got %v", got)
+		}
+
+		var first []int
+		rb.Range(func(value int) bool {
+			first = append(first, value)
+			return len(first) < 2
+		})
+		if !slices.Equal(first, []int{8, 9}) {
+			t.Errorf("Expected Range to stop after [8 9], got %v", first)
+		}
+	})
+
+	t.Run("InvalidCapacity", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected NewRingBuffer(0) to panic")
+			}
+		}()
+		queues.NewRingBuffer[int](0)
+	})
+}
@@ -0,0 +1,91 @@
+package sets
+
+// Cloner is a Set that can copy itself into a new set of its own concrete
+// type S. The set algebra functions use it to return results of the same
+// kind as their inputs, so combining two OrderedSets yields an OrderedSet.
+type Cloner[T any, S any] interface {
+	Set[T]
+	Clone() S
+}
+
+// Union returns a new set holding every value in a or b.
+// For ordered sets the result lists a's values in order, followed by the
+// values only in b in b's order.
+func Union[T any, S Cloner[T, S]](a, b S) S {
+	result := a.Clone()
+	b.Range(func(value T) bool {
+		result.Add(value)
+		return true
+	})
+	return result
+}
+
+// Intersection returns a new set holding the values present in both a and b.
+// Only the smaller input is iterated; for ordered sets the result follows
+// the order of the smaller set (a when both have the same size).
+func Intersection[T any, S Cloner[T, S]](a, b S) S {
+	small, large := a, b
+	if b.Len() < a.Len() {
+		small, large = b, a
+	}
+	result := small.Clone()
+	small.Range(func(value T) bool {
+		if !large.Contains(value) {
+			result.Remove(value)
+		}
+		return true
+	})
+	return result
+}
+
+// Difference returns a new set holding the values of a that are not in b,
+// in a's order.
+func Difference[T any, S Cloner[T, S]](a, b S) S {
+	result := a.Clone()
+	if b.Len() < a.Len() {
+		b.Range(func(value T) bool {
+			result.Remove(value)
+			return true
+		})
+		return result
+	}
+	a.Range(func(value T) bool {
+		if b.Contains(value) {
+			result.Remove(value)
+		}
+		return true
+	})
+	return result
+}
+
+// SymmetricDifference returns a new set holding the values that are in
+// exactly one of a and b. For ordered sets the values only in a come first,
+// followed by the values only in b.
+func SymmetricDifference[T any, S Cloner[T, S]](a, b S) S {
+	result := Difference(a, b)
+	b.Range(func(value T) bool {
+		if !a.Contains(value) {
+			result.Add(value)
+		}
+		return true
+	})
+	return result
+}
+
+// IsSubset reports whether every value of a is also in b.
+func IsSubset[T any](a, b Set[T]) bool {
+	if a.Len() > b.Len() {
+		return false
+	}
+	subset := true
+	a.Range(func(value T) bool {
+		subset = b.Contains(value)
+		return subset
+	})
+	return subset
+}
+
+// IsSuperset reports whether a contains every value of b.
+func IsSuperset[T any](a, b Set[T]) bool {
+	return IsSubset(b, a)
+}
@@ -0,0 +1,138 @@
+package sets_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/sets"
+)
+
+func orderedOf(values ...int) *sets.OrderedSet[int] {
+	s := sets.NewOrderedSet[int]()
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func unorderedOf(values ...int) *sets.UnorderedSet[int] {
+	s := sets.NewUnorderedSet[int]()
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func valuesOf(s sets.Set[int]) []int {
+	var values []int
+	s.Range(func(value int) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+func sortedValuesOf(s sets.Set[int]) []int {
+	values := valuesOf(s)
+	slices.Sort(values)
+	return values
+}
+
+func TestSetAlgebra(t *testing.T) {
+	cases := []struct {
+		name                 string
+		a, b                 []int
+		union, inter, diff   []int
+		symDiff              []int
+		aSubsetB, aSupersetB bool
+	}{
+		{
+			name: "Disjoint", a: []int{1, 2}, b: []int{3, 4},
+			union: []int{1, 2, 3, 4}, inter: nil, diff: []int{1, 2}, symDiff: []int{1, 2, 3, 4},
+		},
+		{
+			name: "Overlapping", a: []int{1, 2, 3}, b: []int{2, 3, 4},
+			union: []int{1, 2, 3, 4}, inter: []int{2, 3}, diff: []int{1}, symDiff: []int{1, 4},
+		},
+		{
+			name: "Identical", a: []int{1, 2, 3}, b: []int{1, 2, 3},
+			union: []int{1, 2, 3}, inter: []int{1, 2, 3}, diff: nil, symDiff: nil,
+			aSubsetB: true, aSupersetB: true,
+		},
+		{
+			name: "ProperSubset", a: []int{2}, b: []int{1, 2, 3},
+			union: []int{1, 2, 3}, inter: []int{2}, diff: nil, symDiff: []int{1, 3},
+			aSubsetB: true,
+		},
+		{
+			name: "Empty", a: nil, b: []int{1},
+			union: []int{1}, inter: nil, diff: nil, symDiff: []int{1},
+			aSubsetB: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := unorderedOf(tc.a...), unorderedOf(tc.b...)
+
+			check := func(op string, got *sets.UnorderedSet[int], want []int) {
+				if values := sortedValuesOf(got); !slices.Equal(values, want) {
+					t.Errorf("%s: expected %v, got %v", op, want, values)
+				}
+			}
+			check("Union", sets.Union(a, b), tc.union)
+			check("Intersection", sets.Intersection(a, b), tc.inter)
+			check("Difference", sets.Difference(a, b), tc.diff)
+			check("SymmetricDifference", sets.SymmetricDifference(a, b), tc.symDiff)
+
+			if got := sets.IsSubset[int](a, b); got != tc.aSubsetB {
+				t.Errorf("IsSubset: expected %v, got %v", tc.aSubsetB, got)
+			}
+			if got := sets.IsSuperset[int](a, b); got != tc.aSupersetB {
+				t.Errorf("IsSuperset: expected %v, got %v", tc.aSupersetB, got)
+			}
+
+			// The inputs must not be modified.
+			if values := sortedValuesOf(a); len(values) != len(tc.a) {
+				t.Errorf("Expected input a to be unchanged, got %v", values)
+			}
+		})
+	}
+}
+
+func TestSetAlgebraOrdering(t *testing.T) {
+	t.Run("UnionPreservesFirstSetOrder", func(t *testing.T) {
+		union := sets.Union(orderedOf(5, 1, 3), orderedOf(4, 1, 2))
+		if values := valuesOf(union); !slices.Equal(values, []int{5, 1, 3, 4, 2}) {
+			t.Errorf("Expected [5 1 3 4 2], got %v", values)
+		}
+	})
+
+	t.Run("IntersectionFollowsSmallerSet", func(t *testing.T) {
+		inter := sets.Intersection(orderedOf(1, 2, 3, 4, 5), orderedOf(4, 2))
+		if values := valuesOf(inter); !slices.Equal(values, []int{4, 2}) {
+			t.Errorf("Expected [4 2], got %v", values)
+		}
+	})
+
+	t.Run("DifferenceKeepsOrder", func(t *testing.T) {
+		diff := sets.Difference(orderedOf(9, 7, 5, 3), orderedOf(7))
+		if values := valuesOf(diff); !slices.Equal(values, []int{9, 5, 3}) {
+			t.Errorf("Expected [9 5 3], got %v", values)
+		}
+	})
+
+	t.Run("SymmetricDifferenceOrder", func(t *testing.T) {
+		symDiff := sets.SymmetricDifference(orderedOf(3, 1, 2), orderedOf(2, 5, 4))
+		if values := valuesOf(symDiff); !slices.Equal(values, []int{3, 1, 5, 4}) {
+			t.Errorf("Expected [3 1 5 4], got %v", values)
+		}
+	})
+
+	t.Run("ResultKeepsConcreteKind", func(t *testing.T) {
+		var union *sets.OrderedSet[int] = sets.Union(orderedOf(1), orderedOf(2))
+		if union.Len() != 2 {
+			t.Errorf("Expected 2 values, got %d", union.Len())
+		}
+	})
+}
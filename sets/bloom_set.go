@@ -0,0 +1,114 @@
+package sets
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+)
+
+// BloomSet is an approximate set backed by a bloom filter. It uses a fixed
+// amount of memory regardless of how many values are added, at the cost of
+// exactness: MayContain never reports false for a value that was added, but
+// may report true for one that was not.
+//
+// BloomSet deliberately does not implement Set. Values cannot be removed,
+// Len is only an estimate and the values themselves are not stored, so
+// there is nothing to Range over.
+type BloomSet[T any] struct {
+	bits   []uint64
+	m      uint64 // Number of bits
+	k      uint64 // Number of hash functions
+	hash   func(T) uint64
+	filled uint64 // Number of set bits, for EstimatedLen
+}
+
+// NewBloomSet creates a BloomSet sized to hold expectedItems values with
+// roughly the given false-positive rate. Values are hashed with
+// hash/maphash under a random seed, so T's dynamic values must be
+// comparable; MayContain and Add panic otherwise. Use NewBloomSetFunc to
+// supply a hash for other types.
+func NewBloomSet[T any](expectedItems int, falsePositiveRate float64) *BloomSet[T] {
+	seed := maphash.MakeSeed()
+	return NewBloomSetFunc(expectedItems, falsePositiveRate, func(value T) uint64 {
+		return maphash.Comparable[any](seed, value)
+	})
+}
+
+// NewBloomSetFunc is like NewBloomSet but hashes values with hash, which
+// should spread its output over all 64 bits.
+// It panics if expectedItems is less than 1 or falsePositiveRate is not
+// strictly between 0 and 1.
+func NewBloomSetFunc[T any](expectedItems int, falsePositiveRate float64, hash func(T) uint64) *BloomSet[T] {
+	if expectedItems < 1 {
+		panic(fmt.Sprintf("sets: NewBloomSet expected items must be at least 1, got %d", expectedItems))
+	}
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		panic(fmt.Sprintf("sets: NewBloomSet false-positive rate must be between 0 and 1, got %v", falsePositiveRate))
+	}
+
+	// Optimal sizing: m = -n ln(p) / ln(2)^2 bits and k = m/n ln(2) hashes.
+	n := float64(expectedItems)
+	m := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	m = max(64, (m+63)/64*64)
+	k := max(1, uint64(math.Round(float64(m)/n*math.Ln2)))
+
+	return &BloomSet[T]{
+		bits: make([]uint64, m/64),
+		m:    m,
+		k:    k,
+		hash: hash,
+	}
+}
+
+// positions calls f with each of the k bit positions for value, derived
+// from a single 64-bit hash by double hashing.
+func (s *BloomSet[T]) positions(value T, f func(pos uint64) bool) {
+	h := s.hash(value)
+	h1, h2 := h&math.MaxUint32, h>>32|1
+	for i := range s.k {
+		if !f((h1 + i*h2) % s.m) {
+			return
+		}
+	}
+}
+
+// Add inserts value into the set.
+func (s *BloomSet[T]) Add(value T) {
+	s.positions(value, func(pos uint64) bool {
+		word, mask := pos/64, uint64(1)<<(pos%64)
+		if s.bits[word]&mask == 0 {
+			s.bits[word] |= mask
+			s.filled++
+		}
+		return true
+	})
+}
+
+// MayContain reports whether value may have been added. A false result is
+// definite; a true result is wrong with roughly the false-positive rate the
+// set was created with, provided no more than the expected number of values
+// were added.
+func (s *BloomSet[T]) MayContain(value T) bool {
+	found := true
+	s.positions(value, func(pos uint64) bool {
+		found = s.bits[pos/64]&(1<<(pos%64)) != 0
+		return found
+	})
+	return found
+}
+
+// EstimatedLen estimates the number of distinct values added from the
+// fraction of bits set.
+func (s *BloomSet[T]) EstimatedLen() int {
+	if s.filled == s.m {
+		return math.MaxInt
+	}
+	m, k := float64(s.m), float64(s.k)
+	return int(math.Round(-m / k * math.Log1p(-float64(s.filled)/m)))
+}
+
+// Clear resets the set to empty.
+func (s *BloomSet[T]) Clear() {
+	clear(s.bits)
+	s.filled = 0
+}
@@ -0,0 +1,85 @@
+package sets_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/13770129/containers/sets"
+)
+
+func TestBloomSet(t *testing.T) {
+	const n = 10_000
+	const rate = 0.01
+
+	s := sets.NewBloomSet[string](n, rate)
+	for i := range n {
+		s.Add(fmt.Sprintf("in-%d", i))
+	}
+
+	t.Run("NoFalseNegatives", func(t *testing.T) {
+		for i := range n {
+			if key := fmt.Sprintf("in-%d", i); !s.MayContain(key) {
+				t.Fatalf("Expected MayContain(%q) after Add", key)
+			}
+		}
+	})
+
+	t.Run("FalsePositiveRate", func(t *testing.T) {
+		const probes = 100_000
+		hits := 0
+		for i := range probes {
+			if s.MayContain(fmt.Sprintf("out-%d", i)) {
+				hits++
+			}
+		}
+		if got := float64(hits) / probes; got > 2*rate {
+			t.Errorf("Expected false-positive rate near %v, got %v", rate, got)
+		}
+	})
+
+	t.Run("EstimatedLen", func(t *testing.T) {
+		if got := s.EstimatedLen(); got < n*95/100 || got > n*105/100 {
+			t.Errorf("Expected estimate within 5%% of %d, got %d", n, got)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		s.Clear()
+		if s.MayContain("in-0") || s.EstimatedLen() != 0 {
+			t.Errorf("Expected empty set after Clear")
+		}
+	})
+}
+
+func TestBloomSetFunc(t *testing.T) {
+	type point struct{ x, y []int }
+	hash := func(p point) uint64 {
+		h := uint64(14695981039346656037)
+		for _, v := range append(append([]int{}, p.x...), p.y...) {
+			h = (h ^ uint64(v)) * 1099511628211
+		}
+		return h ^ h>>29
+	}
+
+	s := sets.NewBloomSetFunc(100, 0.01, hash)
+	s.Add(point{[]int{1}, []int{2}})
+	if !s.MayContain(point{[]int{1}, []int{2}}) {
+		t.Errorf("Expected MayContain to find an added value")
+	}
+}
+
+func TestNewBloomSetPanics(t *testing.T) {
+	for _, tc := range []struct {
+		items int
+		rate  float64
+	}{{0, 0.1}, {10, 0}, {10, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for items=%d rate=%v", tc.items, tc.rate)
+				}
+			}()
+			sets.NewBloomSet[int](tc.items, tc.rate)
+		}()
+	}
+}
@@ -0,0 +1,7 @@
+module github.com/13770129/containers/sets
+
+go 1.24.3
+
+require github.com/13770129/containers/maps v0.0.0
+
+replace github.com/13770129/containers/maps => ../maps
@@ -0,0 +1,24 @@
+package sets
+
+import "github.com/13770129/containers/maps"
+
+// OrderedSet is a Set backed by an OrderedMap.
+// Range visits values in the order they were first added; re-adding a value
+// that is already present does not change its position.
+type OrderedSet[T comparable] struct {
+	mapSet[T]
+}
+
+// NewOrderedSet creates an empty OrderedSet.
+func NewOrderedSet[T comparable]() *OrderedSet[T] {
+	return &OrderedSet[T]{
+		mapSet: mapSet[T]{m: maps.NewOrderedMap[T, struct{}]()},
+	}
+}
+
+// Clone returns an independent copy of the set with the same order.
+func (s *OrderedSet[T]) Clone() *OrderedSet[T] {
+	return &OrderedSet[T]{
+		mapSet: mapSet[T]{m: maps.Clone(s.m)},
+	}
+}
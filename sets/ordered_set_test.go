@@ -0,0 +1,49 @@
+package sets_test
+
+import (
+	"testing"
+
+	"github.com/13770129/containers/sets"
+)
+
+func TestOrderedSetString(t *testing.T) {
+	factory := func() sets.Set[string] {
+		return sets.NewOrderedSet[string]()
+	}
+
+	testSuite(t, factory, []string{"alpha", "beta", "gamma"})
+}
+
+func TestOrderedSetInt(t *testing.T) {
+	factory := func() sets.Set[int] {
+		return sets.NewOrderedSet[int]()
+	}
+
+	testSuite(t, factory, []int{1, 2, 3, 4})
+}
+
+// OrderedSet-specific tests that verify insertion order preservation
+func TestOrderedSetInsertionOrder(t *testing.T) {
+	s := sets.NewOrderedSet[string]()
+	for _, value := range []string{"charlie", "alpha", "delta", "alpha", "bravo"} {
+		s.Add(value)
+	}
+	s.Remove("delta")
+	s.Add("delta")
+
+	expected := []string{"charlie", "alpha", "bravo", "delta"}
+	var actual []string
+	s.Range(func(value string) bool {
+		actual = append(actual, value)
+		return true
+	})
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d values, got %d", len(expected), len(actual))
+	}
+	for i, value := range expected {
+		if actual[i] != value {
+			t.Errorf("Position %d: expected %s, got %s", i, value, actual[i])
+		}
+	}
+}
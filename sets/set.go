@@ -0,0 +1,50 @@
+package sets
+
+import "github.com/13770129/containers/maps"
+
+// Set is the interface implemented by the set types in this package.
+type Set[T any] interface {
+	Add(value T)
+	Clear()
+	Contains(value T) bool
+	Len() int
+	Range(f func(value T) bool)
+	Remove(value T)
+}
+
+// mapSet implements Set on top of a map whose values carry no data.
+// The concrete set types embed it and choose the backing map.
+type mapSet[T any] struct {
+	m maps.AbstractMap[T, struct{}]
+}
+
+// Add inserts value into the set. Adding a value already present is a no-op.
+func (s mapSet[T]) Add(value T) {
+	s.m.Store(value, struct{}{})
+}
+
+// Clear removes all values from the set.
+func (s mapSet[T]) Clear() {
+	s.m.Clear()
+}
+
+// Contains reports whether value is in the set.
+func (s mapSet[T]) Contains(value T) bool {
+	_, ok := s.m.Load(value)
+	return ok
+}
+
+// Len returns the number of values in the set.
+func (s mapSet[T]) Len() int {
+	return s.m.Len()
+}
+
+// Range calls f for each value in the set until f returns false.
+func (s mapSet[T]) Range(f func(value T) bool) {
+	s.m.Keys(f)
+}
+
+// Remove deletes value from the set if present.
+func (s mapSet[T]) Remove(value T) {
+	s.m.Delete(value)
+}
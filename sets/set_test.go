@@ -0,0 +1,133 @@
+package sets_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/sets"
+)
+
+// SetFactory creates new set instances for testing different implementations.
+// This abstraction enables testing multiple set implementations with identical test logic.
+type SetFactory[T any] func() sets.Set[T]
+
+// testSuite executes common tests against any Set implementation.
+func testSuite[T comparable](t *testing.T, factory SetFactory[T], testData []T) {
+	t.Helper()
+
+	t.Run("AddAndContains", func(t *testing.T) {
+		s := factory()
+
+		for _, value := range testData {
+			s.Add(value)
+			if !s.Contains(value) {
+				t.Errorf("Expected set to contain %v after Add", value)
+			}
+		}
+
+		if length := s.Len(); length != len(testData) {
+			t.Errorf("Expected length %d, got %d", len(testData), length)
+		}
+	})
+
+	t.Run("AddDuplicate", func(t *testing.T) {
+		s := factory()
+
+		for _, value := range testData {
+			s.Add(value)
+			s.Add(value)
+		}
+
+		if length := s.Len(); length != len(testData) {
+			t.Errorf("Expected duplicates to be ignored, got length %d", length)
+		}
+	})
+
+	t.Run("ContainsMissing", func(t *testing.T) {
+		s := factory()
+
+		if len(testData) > 0 && s.Contains(testData[0]) {
+			t.Errorf("Expected empty set not to contain %v", testData[0])
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		s := factory()
+
+		for _, value := range testData {
+			s.Add(value)
+		}
+		for _, value := range testData {
+			s.Remove(value)
+			if s.Contains(value) {
+				t.Errorf("Expected %v to be removed", value)
+			}
+		}
+
+		if length := s.Len(); length != 0 {
+			t.Errorf("Expected empty set after removing all values, got length %d", length)
+		}
+
+		// Removing an absent value is a no-op.
+		if len(testData) > 0 {
+			s.Remove(testData[0])
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		s := factory()
+
+		for _, value := range testData {
+			s.Add(value)
+		}
+		s.Clear()
+
+		if length := s.Len(); length != 0 {
+			t.Errorf("Expected length 0 after Clear(), got %d", length)
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		s := factory()
+
+		for _, value := range testData {
+			s.Add(value)
+		}
+
+		var collected []T
+		s.Range(func(value T) bool {
+			collected = append(collected, value)
+			return true
+		})
+
+		if len(collected) != len(testData) {
+			t.Errorf("Expected %d values from Range, got %d", len(testData), len(collected))
+		}
+		for _, expected := range testData {
+			if !slices.Contains(collected, expected) {
+				t.Errorf("Expected to find %v in Range results", expected)
+			}
+		}
+	})
+
+	t.Run("RangeEarlyTermination", func(t *testing.T) {
+		if len(testData) <= 1 {
+			t.Skip("Need at least 2 values for early termination test")
+		}
+
+		s := factory()
+		for _, value := range testData {
+			s.Add(value)
+		}
+
+		count := 0
+		s.Range(func(value T) bool {
+			count++
+			return false
+		})
+
+		if count != 1 {
+			t.Errorf("Expected Range to stop after 1 iteration, got %d", count)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package sets
+
+import "github.com/13770129/containers/maps"
+
+// UnorderedSet is a Set backed by an UnorderedMap.
+// Range visits values in no particular order.
+type UnorderedSet[T comparable] struct {
+	mapSet[T]
+}
+
+// NewUnorderedSet creates an empty UnorderedSet.
+func NewUnorderedSet[T comparable]() *UnorderedSet[T] {
+	return &UnorderedSet[T]{
+		mapSet: mapSet[T]{m: maps.NewUnorderedMap[T, struct{}]()},
+	}
+}
+
+// Clone returns an independent copy of the set.
+func (s *UnorderedSet[T]) Clone() *UnorderedSet[T] {
+	return &UnorderedSet[T]{
+		mapSet: mapSet[T]{m: maps.Clone(s.m)},
+	}
+}
@@ -0,0 +1,23 @@
+package sets_test
+
+import (
+	"testing"
+
+	"github.com/13770129/containers/sets"
+)
+
+func TestUnorderedSetString(t *testing.T) {
+	factory := func() sets.Set[string] {
+		return sets.NewUnorderedSet[string]()
+	}
+
+	testSuite(t, factory, []string{"alpha", "beta", "gamma"})
+}
+
+func TestUnorderedSetInt(t *testing.T) {
+	factory := func() sets.Set[int] {
+		return sets.NewUnorderedSet[int]()
+	}
+
+	testSuite(t, factory, []int{1, 2, 3, 4})
+}
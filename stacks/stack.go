@@ -0,0 +1,73 @@
+package stacks
+
+import "iter"
+
+// Stack is a LIFO stack of values of type T backed by a growable slice.
+// The zero value is an empty stack ready to use.
+type Stack[T any] struct {
+	items []T // Bottom first; the top is the last element
+}
+
+// NewStack creates an empty Stack.
+func NewStack[T any]() *Stack[T] {
+	return new(Stack[T])
+}
+
+// Len returns the number of values on the stack.
+// Time complexity: O(1)
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Push puts value on top of the stack.
+// Time complexity: amortized O(1)
+func (s *Stack[T]) Push(value T) {
+	s.items = append(s.items, value)
+}
+
+// PushAll pushes values in argument order, so the last one ends up on top.
+// Time complexity: amortized O(len(values))
+func (s *Stack[T]) PushAll(values ...T) {
+	s.items = append(s.items, values...)
+}
+
+// Pop removes and returns the top value, or the zero value and false if the
+// stack is empty.
+// Time complexity: O(1)
+func (s *Stack[T]) Pop() (value T, ok bool) {
+	n := len(s.items)
+	if n == 0 {
+		return value, false
+	}
+	value = s.items[n-1]
+	var zero T
+	s.items[n-1] = zero // Let the popped value be garbage collected
+	s.items = s.items[:n-1]
+	return value, true
+}
+
+// Peek returns the top value without removing it, or the zero value and
+// false if the stack is empty.
+// Time complexity: O(1)
+func (s *Stack[T]) Peek() (value T, ok bool) {
+	if len(s.items) == 0 {
+		return value, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Range calls f for each value from top to bottom until f returns false.
+// The stack must not be modified during iteration.
+// Time complexity: O(n)
+func (s *Stack[T]) Range(f func(value T) bool) {
+	for i := len(s.items) - 1; i >= 0; i-- {
+		if !f(s.items[i]) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the values from top to bottom.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return s.Range
+}
@@ -0,0 +1,74 @@
+package stacks_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/13770129/containers/stacks"
+)
+
+func TestStackPushPop(t *testing.T) {
+	t.Run("LIFO", func(t *testing.T) {
+		s := stacks.NewStack[int]()
+		s.Push(1)
+		s.PushAll(2, 3, 4)
+		if s.Len() != 4 {
+			t.Errorf("Expected length 4, got %d", s.Len())
+		}
+		if value, ok := s.Peek(); !ok || value != 4 {
+			t.Errorf("Expected Peek to return 4, got %d (ok=%v)", value, ok)
+		}
+
+		var popped []int
+		for {
+			value, ok := s.Pop()
+			if !ok {
+				break
+			}
+			popped = append(popped, value)
+		}
+		if !slices.Equal(popped, []int{4, 3, 2, 1}) {
+			t.Errorf("Expected LIFO order [4 3 2 1], got %v", popped)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var s stacks.Stack[string]
+		if _, ok := s.Pop(); ok {
+			t.Error("Expected Pop on an empty stack to fail")
+		}
+		if _, ok := s.Peek(); ok {
+			t.Error("Expected Peek on an empty stack to fail")
+		}
+
+		// The zero value is usable.
+		s.Push("a")
+		if value, ok := s.Pop(); !ok || value != "a" {
+			t.Errorf("Expected Pop to return a, got %q (ok=%v)", value, ok)
+		}
+		if s.Len() != 0 {
+			t.Errorf("Expected empty stack, got length %d", s.Len())
+		}
+	})
+}
+
+func TestStackIteration(t *testing.T) {
+	s := stacks.NewStack[int]()
+	s.PushAll(0, 1, 2, 3, 4)
+
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("Expected top-to-bottom order [4 3 2 1 0], got %v", got)
+	}
+
+	var visited []int
+	s.Range(func(value int) bool {
+		visited = append(visited, value)
+		return value > 3
+	})
+	if !slices.Equal(visited, []int{4, 3}) {
+		t.Errorf("Expected Range to stop after [4 3], visited %v", visited)
+	}
+	if s.Len() != 5 {
+		t.Errorf("Expected iteration to leave the stack intact, got length %d", s.Len())
+	}
+}